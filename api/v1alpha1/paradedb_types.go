@@ -17,38 +17,121 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // ParadeDBSpec defines the desired state of ParadeDB
 type ParadeDBSpec struct {
-	// Image is the ParadeDB container image to use
-	// +kubebuilder:default="paradedb/paradedb:latest"
+	// ClassName references a cluster-scoped ParadeDBClass whose settings are used
+	// for any of image, resources, storage class, backup and monitoring left unset here
+	// +optional
+	ClassName string `json:"className,omitempty"`
+
+	// Image is the ParadeDB container image to use. Defaults to the class image, the
+	// operator's default image, or paradedb/paradedb:latest.
 	// +optional
 	Image string `json:"image,omitempty"`
 
-	// Replicas is the number of ParadeDB instances (1 for standalone, >1 for HA)
+	// ImagePullPolicy applies to every container managed by the operator
+	// +kubebuilder:validation:Enum=Always;IfNotPresent;Never
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// ImagePullSecrets are used to pull the ParadeDB, exporter and pooler images
+	// from private registries
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// Replicas is the number of ParadeDB instances. Values above 1 require replication to
+	// be set, as the operator does not manage replication between the pods yet.
 	// +kubebuilder:default=1
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=10
 	// +optional
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Replication acknowledges how more than one replica behaves. It is required for
+	// replicas above 1.
+	// +optional
+	Replication *ReplicationSpec `json:"replication,omitempty"`
+
 	// PostgresVersion specifies the PostgreSQL version
 	// +kubebuilder:default="16"
 	// +optional
 	PostgresVersion string `json:"postgresVersion,omitempty"`
 
+	// UpdatePolicy selects the image from a version catalog and applies newer releases
+	// automatically. It is only used when image is not set.
+	// +optional
+	UpdatePolicy *UpdatePolicySpec `json:"updatePolicy,omitempty"`
+
 	// Storage configuration for ParadeDB
 	// +required
 	Storage StorageSpec `json:"storage"`
 
-	// Resources defines the CPU and memory resources for ParadeDB pods
+	// Resources defines the CPU and memory resources for ParadeDB pods. Requesting huge
+	// pages, e.g. hugepages-2Mi, turns huge_pages on.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// MaxConnections is the max_connections setting. Changing it restarts the pods.
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConnections *int32 `json:"maxConnections,omitempty"`
+
+	// Schedules change the replicas and resources at set times. The schedule that fired
+	// most recently is in effect; one that sets neither returns the instance to its spec.
+	// +optional
+	Schedules []ScalingScheduleSpec `json:"schedules,omitempty"`
+
+	// Command replaces the entrypoint of the ParadeDB container, e.g. with a wrapper script
+	// that waits for the network or decrypts secrets. It receives the arguments and has to
+	// exec docker-entrypoint.sh with them.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are passed to the entrypoint ahead of the postgres settings managed by the
+	// operator, which take precedence over any -c setting given here
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env adds environment variables to the ParadeDB container. Variables managed by
+	// the operator (POSTGRES_USER, POSTGRES_PASSWORD, POSTGRES_DB, PGDATA) cannot be overridden.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom adds environment variables to the ParadeDB container from ConfigMaps or Secrets
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// ExtraVolumes are added to the ParadeDB pod, e.g. for custom CA bundles or scripts. The
+	// names of the operator's volumes, such as "data", "config" and "wal-archive", are
+	// reserved.
+	// +optional
+	ExtraVolumes []corev1.Volume `json:"extraVolumes,omitempty"`
+
+	// ExtraVolumeMounts are added to the ParadeDB container. The names and mount paths of
+	// the operator's volumes are reserved.
+	// +optional
+	ExtraVolumeMounts []corev1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+
+	// Sidecars are additional containers appended to the ParadeDB pod
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// InitContainers run before the ParadeDB container starts
+	// +optional
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
 	// Auth contains authentication configuration
 	// +optional
 	Auth AuthSpec `json:"auth,omitempty"`
@@ -57,6 +140,10 @@ type ParadeDBSpec struct {
 	// +optional
 	TLS *TLSSpec `json:"tls,omitempty"`
 
+	// ServiceMesh adapts pods and connections to an Istio or Linkerd mesh
+	// +optional
+	ServiceMesh *ServiceMeshSpec `json:"serviceMesh,omitempty"`
+
 	// ConnectionPooling configuration (PgBouncer)
 	// +optional
 	ConnectionPooling *ConnectionPoolingSpec `json:"connectionPooling,omitempty"`
@@ -65,7 +152,8 @@ type ParadeDBSpec struct {
 	// +optional
 	Backup *BackupSpec `json:"backup,omitempty"`
 
-	// Monitoring configuration
+	// Monitoring configuration. When unset, the metrics exporter runs only if the
+	// operator is started with --monitoring-enabled-by-default.
 	// +optional
 	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
 
@@ -73,16 +161,98 @@ type ParadeDBSpec struct {
 	// +optional
 	Extensions ExtensionsSpec `json:"extensions,omitempty"`
 
+	// Vector configures pgvector index builds. It requires extensions.pgVector.
+	// +optional
+	Vector *VectorSpec `json:"vector,omitempty"`
+
+	// Analytics configures pg_analytics foreign servers. It requires extensions.pgAnalytics.
+	// +optional
+	Analytics *AnalyticsSpec `json:"analytics,omitempty"`
+
 	// PostgresConfig allows custom PostgreSQL configuration parameters
 	// +optional
 	PostgresConfig map[string]string `json:"postgresConfig,omitempty"`
 
+	// PostgresConfigFrom includes PostgreSQL configuration fragments from ConfigMap keys,
+	// such as tuning profiles shared between instances. They are included in order after
+	// the data directory's postgresql.conf, so later fragments take precedence.
+	// +optional
+	PostgresConfigFrom []corev1.ConfigMapKeySelector `json:"postgresConfigFrom,omitempty"`
+
+	// EnforceConfig reverts settings changed out-of-band with ALTER SYSTEM, which would
+	// otherwise override the configuration from the spec. Drift is reported in the
+	// ConfigDrift condition either way.
+	// +optional
+	EnforceConfig bool `json:"enforceConfig,omitempty"`
+
+	// WALConfig tunes WAL sizing, checkpoints and archiving. It takes precedence over
+	// postgresConfigFrom. Changing it restarts the pods.
+	// +optional
+	WALConfig *WALConfigSpec `json:"walConfig,omitempty"`
+
+	// Maintenance tunes autovacuum and schedules VACUUM, ANALYZE and REINDEX of
+	// individual tables in a maintenance window
+	// +optional
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+
+	// ReplicaOf runs the instance as a standby streaming from another PostgreSQL server,
+	// such as a ParadeDB in another Kubernetes cluster, for disaster recovery
+	// +optional
+	ReplicaOf *ReplicaOfSpec `json:"replicaOf,omitempty"`
+
+	// Bootstrap configures how a new instance is populated
+	// +optional
+	Bootstrap *BootstrapSpec `json:"bootstrap,omitempty"`
+
+	// Restore selectively restores databases or tables from a logical backup, on a new
+	// instance or an existing one. It runs once per distinct restore spec.
+	// +optional
+	Restore *LogicalRestoreSpec `json:"restore,omitempty"`
+
+	// CDC prepares the instance for change data capture tools such as Debezium
+	// +optional
+	CDC *CDCSpec `json:"cdc,omitempty"`
+
+	// Port is the PostgreSQL port used by the container and the Services
+	// +kubebuilder:default=5432
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
 	// ServiceType specifies the type of Service to create
 	// +kubebuilder:default="ClusterIP"
 	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
 	// +optional
 	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
 
+	// Service configures the primary Service, e.g. for cloud LoadBalancer integrations
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+
+	// PerPodServices creates a ClusterIP Service per pod, named after the pod, for
+	// tooling that needs to reach a specific instance
+	// +kubebuilder:default=false
+	// +optional
+	PerPodServices bool `json:"perPodServices,omitempty"`
+
+	// Expose configures access to ParadeDB from outside the cluster
+	// +optional
+	Expose *ExposeSpec `json:"expose,omitempty"`
+
+	// PodMetadata adds labels and annotations to the ParadeDB and pooler pods and to the
+	// PersistentVolumeClaims created for them
+	// +optional
+	PodMetadata *ResourceMetadata `json:"podMetadata,omitempty"`
+
+	// ServiceMetadata adds labels and annotations to the Services created by the operator
+	// +optional
+	ServiceMetadata *ResourceMetadata `json:"serviceMetadata,omitempty"`
+
+	// SecretMetadata adds labels and annotations to the Secrets created by the operator
+	// +optional
+	SecretMetadata *ResourceMetadata `json:"secretMetadata,omitempty"`
+
 	// NodeSelector for pod scheduling
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
@@ -91,348 +261,2193 @@ type ParadeDBSpec struct {
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 
-	// Affinity for pod scheduling
+	// Affinity for pod scheduling. Its podAntiAffinity, if set, replaces the one
+	// generated by affinityPreset.
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 
-	// PodSecurityContext for the ParadeDB pods
+	// AffinityPreset generates pod anti-affinity that keeps the database pods, and the
+	// pooler pods, on separate nodes: soft prefers it, hard requires it
+	// +kubebuilder:validation:Enum=none;soft;hard
+	// +kubebuilder:default=none
 	// +optional
-	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	AffinityPreset string `json:"affinityPreset,omitempty"`
 
-	// ContainerSecurityContext for the ParadeDB container
+	// Architectures the image is built for. The database pods, and the Jobs that run
+	// the image, are only scheduled onto nodes whose kubernetes.io/arch is one of these.
 	// +optional
-	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
-}
-
-// StorageSpec defines storage configuration
-type StorageSpec struct {
-	// Size is the size of the PersistentVolumeClaim
-	// +kubebuilder:default="10Gi"
-	Size resource.Quantity `json:"size"`
+	Architectures []Architecture `json:"architectures,omitempty"`
 
-	// StorageClassName is the name of the StorageClass to use
+	// TopologySpreadConstraints for pod scheduling
 	// +optional
-	StorageClassName *string `json:"storageClassName,omitempty"`
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 
-	// AccessModes for the PVC
-	// +kubebuilder:default={"ReadWriteOnce"}
+	// HostAliases are added to /etc/hosts of the database pods and the backup, restore
+	// and import Jobs, for hosts such as LDAP servers or S3 endpoints that cluster DNS
+	// does not resolve
 	// +optional
-	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
 
-	// WalStorage for separate WAL storage
+	// DNSPolicy of the database pods and the backup, restore and import Jobs. None
+	// requires dnsConfig with at least one nameserver.
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
 	// +optional
-	WalStorage *WalStorageSpec `json:"walStorage,omitempty"`
-}
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
 
-// WalStorageSpec defines separate WAL storage configuration
-type WalStorageSpec struct {
-	// Size of the WAL storage
-	// +kubebuilder:default="5Gi"
-	Size resource.Quantity `json:"size"`
+	// DNSConfig adds nameservers, search domains and resolver options to those of the
+	// DNS policy
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
 
-	// StorageClassName for WAL storage
+	// HighAvailability configuration for multi-replica instances
 	// +optional
-	StorageClassName *string `json:"storageClassName,omitempty"`
-}
+	HighAvailability *HighAvailabilitySpec `json:"highAvailability,omitempty"`
 
-// AuthSpec defines authentication configuration
-type AuthSpec struct {
-	// SuperuserSecretRef references a Secret containing superuser credentials
-	// The secret must contain 'username' and 'password' keys
+	// Remediation configures automatic recovery of stuck or crash-looping pods
 	// +optional
-	SuperuserSecretRef *corev1.SecretReference `json:"superuserSecretRef,omitempty"`
+	Remediation *RemediationSpec `json:"remediation,omitempty"`
 
-	// Database is the default database to create
-	// +kubebuilder:default="paradedb"
+	// CleanupPolicy removes state outside the cluster when the ParadeDB is deleted. By
+	// default, backups, DNS records and Vault secrets outlive the instance.
 	// +optional
-	Database string `json:"database,omitempty"`
+	CleanupPolicy *CleanupPolicySpec `json:"cleanupPolicy,omitempty"`
 
-	// Users defines additional database users to create
+	// Safeguards are guardrails the operator enforces on dangerous operations, whoever
+	// requests them
 	// +optional
-	Users []DatabaseUser `json:"users,omitempty"`
+	Safeguards *SafeguardsSpec `json:"safeguards,omitempty"`
 
-	// EnablePgHBA enables custom pg_hba.conf configuration
+	// ServiceAccount configures the ServiceAccount the database, pooler and backup pods run as
 	// +optional
-	PgHBA []string `json:"pgHBA,omitempty"`
-}
+	ServiceAccount *ServiceAccountSpec `json:"serviceAccount,omitempty"`
 
-// DatabaseUser defines a database user
-type DatabaseUser struct {
-	// Name of the user
-	Name string `json:"name"`
+	// PodSecurityContext for the ParadeDB pods
+	// +optional
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
 
-	// SecretRef references a Secret containing the user's password
-	SecretRef corev1.SecretReference `json:"secretRef"`
+	// ContainerSecurityContext for the ParadeDB container
+	// +optional
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
 
-	// Databases the user has access to
+	// Sysctls are namespaced kernel parameters set on the ParadeDB pods, added to those of
+	// podSecurityContext. Sysctls outside the kubelet's safe set must be allowed on the nodes.
 	// +optional
-	Databases []string `json:"databases,omitempty"`
+	Sysctls []corev1.Sysctl `json:"sysctls,omitempty"`
 
-	// Privileges for the user
+	// TerminationGracePeriodSeconds is the time PostgreSQL is given to checkpoint
+	// and shut down cleanly before the pod is killed
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=0
 	// +optional
-	Privileges []string `json:"privileges,omitempty"`
-}
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 
-// TLSSpec defines TLS configuration
-type TLSSpec struct {
-	// Enabled enables TLS for PostgreSQL connections
-	// +kubebuilder:default=false
-	Enabled bool `json:"enabled"`
+	// Probes tunes the liveness, readiness and startup probes of the ParadeDB container
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
 
-	// SecretRef references a Secret containing TLS certificates
-	// The secret must contain 'tls.crt', 'tls.key', and optionally 'ca.crt'
+	// UpdateStrategy controls how changes are rolled out to the ParadeDB pods. Use a
+	// RollingUpdate partition to stage a rollout pod-by-pod, or OnDelete to only update
+	// pods when they are deleted manually.
 	// +optional
-	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+	UpdateStrategy *appsv1.StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
 
-	// CertManager enables automatic certificate management via cert-manager
+	// PodTemplateOverrides is a strategic merge patch applied to the generated ParadeDB pod
+	// template, for pod settings the spec does not model. Containers are merged by name,
+	// e.g. "paradedb". The operator's selector labels cannot be changed.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
 	// +optional
-	CertManager *CertManagerSpec `json:"certManager,omitempty"`
+	PodTemplateOverrides *runtime.RawExtension `json:"podTemplateOverrides,omitempty"`
 }
 
-// CertManagerSpec defines cert-manager integration
-type CertManagerSpec struct {
-	// Enabled enables cert-manager integration
-	Enabled bool `json:"enabled"`
+// ReplicationSpec defines how the pods of a ParadeDB relate to each other
+type ReplicationSpec struct {
+	// Mode is Independent: each pod initializes and serves its own database behind the
+	// same Service. It is the only mode until the operator manages streaming replication
+	// between the pods.
+	// +kubebuilder:validation:Enum=Independent
+	// +required
+	Mode string `json:"mode"`
+}
 
-	// IssuerRef references a cert-manager Issuer or ClusterIssuer
+// UpdatePolicySpec defines how the image is chosen from a version catalog
+type UpdatePolicySpec struct {
+	// CatalogRef selects the ConfigMap key holding the version catalog. The catalog maps
+	// channel names to lists of releases with a version, postgresVersion and image.
+	// +required
+	CatalogRef corev1.ConfigMapKeySelector `json:"catalogRef"`
+
+	// Channel is the catalog channel to follow
+	// +kubebuilder:default="stable"
 	// +optional
-	IssuerRef *CertIssuerRef `json:"issuerRef,omitempty"`
+	Channel string `json:"channel,omitempty"`
+
+	// Auto is the largest version change applied automatically: "patch" only moves to
+	// releases with the same minor version, "minor" to any release of the same major
+	// version, and "none" keeps the version the instance was created with
+	// +kubebuilder:default="patch"
+	// +kubebuilder:validation:Enum=none;patch;minor
+	// +optional
+	Auto string `json:"auto,omitempty"`
+
+	// MaintenanceWindow restricts automatic updates to a daily time window. Without it,
+	// updates are applied as soon as they appear in the catalog.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowSpec `json:"maintenanceWindow,omitempty"`
 }
 
-// CertIssuerRef references a cert-manager issuer
-type CertIssuerRef struct {
-	// Name of the issuer
+// ScalingScheduleSpec defines replicas and resources that take effect at set times
+type ScalingScheduleSpec struct {
+	// Name identifies the schedule in status and Events
+	// +required
 	Name string `json:"name"`
 
-	// Kind of the issuer (Issuer or ClusterIssuer)
-	// +kubebuilder:default="Issuer"
-	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
-	Kind string `json:"kind"`
+	// Schedule is a five-field cron expression in UTC, or a macro such as @daily
+	// +required
+	Schedule string `json:"schedule"`
+
+	// Replicas while the schedule is in effect
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources for the ParadeDB container while the schedule is in effect
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
-// ConnectionPoolingSpec defines connection pooling configuration
-type ConnectionPoolingSpec struct {
-	// Enabled enables PgBouncer connection pooling
-	// +kubebuilder:default=false
-	Enabled bool `json:"enabled"`
+// MaintenanceWindowSpec defines a daily window in UTC for disruptive changes
+type MaintenanceWindowSpec struct {
+	// StartTime is the start of the window in UTC, as HH:MM
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +required
+	StartTime string `json:"startTime"`
 
-	// Image is the PgBouncer container image
-	// +kubebuilder:default="bitnami/pgbouncer:latest"
+	// Duration is the length of the window
+	// +kubebuilder:default="2h"
 	// +optional
-	Image string `json:"image,omitempty"`
+	Duration metav1.Duration `json:"duration,omitempty"`
+}
 
-	// PoolMode specifies the pool mode
-	// +kubebuilder:default="transaction"
-	// +kubebuilder:validation:Enum=session;transaction;statement
+// ProbesSpec defines probe tuning for the ParadeDB container
+type ProbesSpec struct {
+	// Liveness overrides the liveness probe timings
 	// +optional
-	PoolMode string `json:"poolMode,omitempty"`
+	Liveness *ProbeSpec `json:"liveness,omitempty"`
 
-	// MaxClientConnections is the maximum number of client connections
-	// +kubebuilder:default=100
+	// Readiness overrides the readiness probe timings
 	// +optional
-	MaxClientConnections int32 `json:"maxClientConnections,omitempty"`
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
 
-	// DefaultPoolSize is the default pool size per user/database pair
-	// +kubebuilder:default=20
+	// Startup overrides the startup probe timings. The startup probe holds off
+	// liveness checks until PostgreSQL has finished (crash) recovery.
 	// +optional
-	DefaultPoolSize int32 `json:"defaultPoolSize,omitempty"`
+	Startup *ProbeSpec `json:"startup,omitempty"`
 
-	// MinPoolSize is the minimum pool size
-	// +kubebuilder:default=0
+	// ReadinessQuery is the SQL statement run against the configured database as the
+	// configured user; the pod is only ready when it succeeds. A primary must also
+	// accept writes to be ready.
+	// +kubebuilder:default="SELECT 1"
 	// +optional
-	MinPoolSize int32 `json:"minPoolSize,omitempty"`
+	ReadinessQuery string `json:"readinessQuery,omitempty"`
 
-	// ReservePoolSize is the number of reserve connections
-	// +kubebuilder:default=5
+	// ReplicaReadinessQuery replaces readinessQuery on pods in recovery, such as those of a
+	// replica cluster. Defaults to readinessQuery.
 	// +optional
-	ReservePoolSize int32 `json:"reservePoolSize,omitempty"`
+	ReplicaReadinessQuery string `json:"replicaReadinessQuery,omitempty"`
 
-	// Resources for the PgBouncer container
+	// RequireStreaming marks a replica as not ready unless it is streaming WAL from its
+	// primary, rather than replaying from an archive
 	// +optional
-	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	RequireStreaming bool `json:"requireStreaming,omitempty"`
+
+	// MaxReplicationLagSeconds marks a replica as not ready once WAL replay falls further
+	// behind the primary than this. 0 disables the replay check.
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReplicationLagSeconds *int32 `json:"maxReplicationLagSeconds,omitempty"`
 }
 
-// BackupSpec defines backup configuration
-type BackupSpec struct {
-	// Enabled enables automated backups
-	// +kubebuilder:default=false
-	Enabled bool `json:"enabled"`
+// ProbeSpec defines the timings of a single probe; unset fields keep the operator defaults
+type ProbeSpec struct {
+	// InitialDelaySeconds before the probe is first run
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
 
-	// Schedule is a cron expression for backup scheduling
-	// +kubebuilder:default="0 2 * * *"
+	// PeriodSeconds between probe runs
+	// +kubebuilder:validation:Minimum=1
 	// +optional
-	Schedule string `json:"schedule,omitempty"`
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
 
-	// RetentionPolicy defines how long to keep backups
+	// TimeoutSeconds after which a probe run is considered failed
+	// +kubebuilder:validation:Minimum=1
 	// +optional
-	RetentionPolicy *RetentionPolicy `json:"retentionPolicy,omitempty"`
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
 
-	// S3 configuration for storing backups in S3-compatible storage
+	// FailureThreshold is the number of consecutive failures before acting
+	// +kubebuilder:validation:Minimum=1
 	// +optional
-	S3 *S3BackupSpec `json:"s3,omitempty"`
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
 
-	// PVC configuration for storing backups on PersistentVolumes
+	// SuccessThreshold is the number of consecutive successes to be considered healthy
+	// (only honored for the readiness probe)
+	// +kubebuilder:validation:Minimum=1
 	// +optional
-	PVC *PVCBackupSpec `json:"pvc,omitempty"`
+	SuccessThreshold *int32 `json:"successThreshold,omitempty"`
 }
 
-// RetentionPolicy defines backup retention
-type RetentionPolicy struct {
-	// KeepLast is the number of recent backups to keep
-	// +kubebuilder:default=7
+// ServiceSpec defines provider-specific settings for the primary Service
+type ServiceSpec struct {
+	// Annotations to add to the primary Service, e.g. to select an AWS NLB or GCP internal LoadBalancer
 	// +optional
-	KeepLast int32 `json:"keepLast,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
 
-	// KeepDaily is the number of daily backups to keep
-	// +kubebuilder:default=7
+	// LoadBalancerSourceRanges restricts the client IP ranges allowed to reach a LoadBalancer Service
 	// +optional
-	KeepDaily int32 `json:"keepDaily,omitempty"`
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
 
-	// KeepWeekly is the number of weekly backups to keep
-	// +kubebuilder:default=4
+	// ExternalTrafficPolicy for NodePort and LoadBalancer Services
+	// +kubebuilder:validation:Enum=Cluster;Local
 	// +optional
-	KeepWeekly int32 `json:"keepWeekly,omitempty"`
-}
+	ExternalTrafficPolicy corev1.ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
 
-// S3BackupSpec defines S3-compatible backup storage
-type S3BackupSpec struct {
-	// Endpoint is the S3 endpoint URL
-	Endpoint string `json:"endpoint"`
+	// LoadBalancerIP requests a static IP for a LoadBalancer Service, where the provider supports it
+	// +optional
+	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
 
-	// Bucket is the S3 bucket name
-	Bucket string `json:"bucket"`
+	// NodePort pins the node port of a NodePort or LoadBalancer Service instead of
+	// letting Kubernetes allocate one
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
+}
 
-	// Region is the S3 region
+// ExposeSpec defines how ParadeDB is exposed outside the cluster
+type ExposeSpec struct {
+	// Hostname is the external DNS name of the primary Service. It is published via
+	// external-dns annotations and reported as the endpoint instead of the cluster-local name.
 	// +optional
-	Region string `json:"region,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
 
-	// SecretRef references a Secret containing S3 credentials
-	// The secret must contain 'accessKeyId' and 'secretAccessKey'
-	SecretRef corev1.SecretReference `json:"secretRef"`
+	// GatewayAPI exposes the primary Service through an existing Gateway API Gateway
+	// +optional
+	GatewayAPI *GatewayAPISpec `json:"gatewayAPI,omitempty"`
+}
 
-	// Path prefix for backups in the bucket
+// GatewayAPISpec defines the route attached to a Gateway. A TCPRoute is created by
+// default; setting hostnames creates a TLSRoute that is matched on SNI instead, which
+// requires clients to use direct TLS negotiation (PostgreSQL 17+ sslnegotiation=direct).
+type GatewayAPISpec struct {
+	// GatewayRef references the Gateway the route attaches to
+	GatewayRef GatewayReference `json:"gatewayRef"`
+
+	// Hostnames the TLSRoute matches on
 	// +optional
-	Path string `json:"path,omitempty"`
+	Hostnames []string `json:"hostnames,omitempty"`
 }
 
-// PVCBackupSpec defines PVC-based backup storage
-type PVCBackupSpec struct {
-	// Size is the size of the backup PVC
-	// +kubebuilder:default="20Gi"
-	Size resource.Quantity `json:"size"`
+// GatewayReference identifies a Gateway and optionally one of its listeners
+type GatewayReference struct {
+	// Name of the Gateway
+	Name string `json:"name"`
 
-	// StorageClassName for the backup PVC
+	// Namespace of the Gateway, defaults to the ParadeDB namespace
 	// +optional
-	StorageClassName *string `json:"storageClassName,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName selects a listener of the Gateway
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
 }
 
-// MonitoringSpec defines monitoring configuration
-type MonitoringSpec struct {
-	// Enabled enables Prometheus metrics exporter
+// ResourceMetadata defines extra labels and annotations for generated resources.
+// Labels set by the operator take precedence over these.
+type ResourceMetadata struct {
+	// Labels to add to the resource
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations to add to the resource
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// HighAvailabilitySpec defines scheduling behaviour for multi-replica instances
+type HighAvailabilitySpec struct {
+	// SpreadAcrossZones spreads replicas evenly across nodes and availability zones
+	// +kubebuilder:default=false
+	// +optional
+	SpreadAcrossZones bool `json:"spreadAcrossZones,omitempty"`
+
+	// Zones pins the data volume of replica i to Zones[i mod len(Zones)] when it is first
+	// provisioned, so that each replica's volume is created in the zone it is meant to
+	// run in rather than wherever an Immediate-binding storage class puts it. The pod then
+	// follows its volume. Existing volumes are not moved.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+}
+
+// RemediationSpec defines automatic recovery of stuck pods
+type RemediationSpec struct {
+	// Enabled deletes pods that are crash-looping or stuck on an unreachable node so the
+	// StatefulSet can recreate them
 	// +kubebuilder:default=true
 	Enabled bool `json:"enabled"`
 
-	// Image is the postgres_exporter container image
-	// +kubebuilder:default="quay.io/prometheuscommunity/postgres-exporter:latest"
+	// CrashLoopRestartThreshold is the container restart count at which a pod in
+	// CrashLoopBackOff is deleted
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CrashLoopRestartThreshold int32 `json:"crashLoopRestartThreshold,omitempty"`
+
+	// StuckPodTimeout is how long a pod may be in the Unknown phase, or terminating on a
+	// node that is not ready, before it is force deleted and its volumes detached
+	// +kubebuilder:default="5m"
 	// +optional
-	Image string `json:"image,omitempty"`
+	StuckPodTimeout *metav1.Duration `json:"stuckPodTimeout,omitempty"`
+}
 
-	// Port for the metrics endpoint
-	// +kubebuilder:default=9187
+// CleanupPolicySpec defines the external state removed when the ParadeDB is deleted.
+// Deletion waits for each cleanup to finish; a failed cleanup is retried until it succeeds
+// or is turned off in the spec.
+type CleanupPolicySpec struct {
+	// DeleteBackups deletes everything under the instance's prefix in the backup.s3 bucket
 	// +optional
-	Port int32 `json:"port,omitempty"`
+	DeleteBackups bool `json:"deleteBackups,omitempty"`
 
-	// Resources for the exporter container
+	// DeregisterDNS removes the expose.hostname annotation from the primary Service and
+	// keeps the Service, and its load balancer address, until external-dns has had
+	// dnsDeregistrationDelay to delete the records, so they never point at a released address
 	// +optional
-	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	DeregisterDNS bool `json:"deregisterDNS,omitempty"`
 
-	// ServiceMonitor enables creating a ServiceMonitor for Prometheus Operator
+	// DNSDeregistrationDelay is how long deletion waits for external-dns, which should be
+	// longer than its sync interval
+	// +kubebuilder:default="2m"
 	// +optional
-	ServiceMonitor *ServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+	DNSDeregistrationDelay *metav1.Duration `json:"dnsDeregistrationDelay,omitempty"`
 
-	// CustomQueries allows defining custom metrics queries
+	// Vault revokes the instance's credentials stored in HashiCorp Vault
 	// +optional
-	CustomQueries map[string]string `json:"customQueries,omitempty"`
+	Vault *VaultCleanupSpec `json:"vault,omitempty"`
 }
 
-// ServiceMonitorSpec defines ServiceMonitor configuration
-type ServiceMonitorSpec struct {
-	// Enabled enables ServiceMonitor creation
+// SafeguardsSpec defines guardrails on dangerous operations. A pod rollout they hold back
+// is reported in the OperationBlocked condition and applied once they allow it.
+type SafeguardsSpec struct {
+	// PreserveVolumes keeps every PersistentVolumeClaim when the ParadeDB is deleted. The
+	// data volumes are always kept; this also releases the backup PVC from the ParadeDB
+	// so it is not garbage collected with it.
+	// +optional
+	PreserveVolumes bool `json:"preserveVolumes,omitempty"`
+
+	// RequireMajorUpgradeApproval holds a change of postgresVersion until the ParadeDB is
+	// annotated with database.paradedb.io/approve-major-upgrade set to the new version
+	// +optional
+	RequireMajorUpgradeApproval bool `json:"requireMajorUpgradeApproval,omitempty"`
+
+	// DisruptionBudget limits how many pod rollouts (upgrades, restarts and other pod
+	// template changes) start within a period. Promotions of a replica cluster are never held.
+	// +optional
+	DisruptionBudget *DisruptionBudgetSpec `json:"disruptionBudget,omitempty"`
+}
+
+// DisruptionBudgetSpec defines the number of disruptive operations allowed per period
+type DisruptionBudgetSpec struct {
+	// MaxOperations is the number of rollouts that may start within the period
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	MaxOperations int32 `json:"maxOperations,omitempty"`
+
+	// Period over which rollouts are counted, ending now
+	// +kubebuilder:default="24h"
+	// +optional
+	Period metav1.Duration `json:"period,omitempty"`
+}
+
+// VaultCleanupSpec defines the Vault leases and secrets removed when the ParadeDB is
+// deleted. The cleanup Job logs in with the Kubernetes auth method as the instance's
+// ServiceAccount.
+type VaultCleanupSpec struct {
+	// Address of the Vault server, e.g. https://vault.vault.svc:8200
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Address string `json:"address"`
+
+	// AuthMount is the path the Kubernetes auth method is mounted at
+	// +kubebuilder:default="kubernetes"
+	// +optional
+	AuthMount string `json:"authMount,omitempty"`
+
+	// Role is the Kubernetes auth role bound to the instance's ServiceAccount
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Role string `json:"role"`
+
+	// LeasePrefix revokes every lease under the prefix, e.g. database/creds/<name>
+	// +optional
+	LeasePrefix string `json:"leasePrefix,omitempty"`
+
+	// KVPaths are KV version 2 secrets deleted with all their versions, e.g.
+	// secret/paradedb/<name>
+	// +optional
+	KVPaths []string `json:"kvPaths,omitempty"`
+
+	// Image is the Vault CLI image. Defaults to hashicorp/vault:1.20.0.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// ServiceAccountSpec defines the ServiceAccount of the instance's pods
+type ServiceAccountSpec struct {
+	// Name of the ServiceAccount. If unset, the operator manages a ServiceAccount named
+	// after the instance.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Create makes the operator manage the named ServiceAccount rather than expect an
+	// existing one. Always true when name is unset.
+	// +optional
+	Create bool `json:"create,omitempty"`
+
+	// Annotations are set on the managed ServiceAccount, e.g. eks.amazonaws.com/role-arn
+	// or iam.gke.io/gcp-service-account for workload identity
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// AutomountToken mounts the ServiceAccount's API token into the pods. Neither
+	// PostgreSQL nor PgBouncer talk to the Kubernetes API, so it is off by default.
 	// +kubebuilder:default=false
+	// +optional
+	AutomountToken *bool `json:"automountToken,omitempty"`
+}
+
+// StorageSpec defines storage configuration
+type StorageSpec struct {
+	// Size is the size of the PersistentVolumeClaim
+	// +kubebuilder:default="10Gi"
+	Size resource.Quantity `json:"size"`
+
+	// StorageClassName is the name of the StorageClass to use
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// AccessModes for the PVC. ReadWriteOncePod, which needs a CSI driver, guarantees that
+	// no other pod mounts the data volume, and cannot be combined with other modes.
+	// +kubebuilder:default={"ReadWriteOnce"}
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// WalStorage for separate WAL storage
+	// +optional
+	WalStorage *WalStorageSpec `json:"walStorage,omitempty"`
+
+	// VolumeCheck runs an init container that gives the data directory to the postgres
+	// user, for pre-provisioned or restored volumes and CSI drivers that ignore fsGroup,
+	// and stops the pod when the data directory belongs to another PostgreSQL major version
+	// +kubebuilder:default=true
+	// +optional
+	VolumeCheck *bool `json:"volumeCheck,omitempty"`
+
+	// LocalCache mounts node-local ephemeral storage, such as a local NVMe disk, for
+	// PostgreSQL's temporary files
+	// +optional
+	LocalCache *LocalCacheSpec `json:"localCache,omitempty"`
+}
+
+// LocalCacheSpec defines node-local ephemeral storage that the operator registers as a
+// temp tablespace, so that pg_search index builds and sorts spilling past work_mem do not
+// go to network-attached storage. Its contents do not outlive the pod.
+type LocalCacheSpec struct {
+	// Enabled mounts the volume and points temp_tablespaces at it
 	Enabled bool `json:"enabled"`
 
-	// Labels to add to the ServiceMonitor
+	// SizeLimit of the volume. Defaults to 10Gi for a volume from storageClassName.
 	// +optional
-	Labels map[string]string `json:"labels,omitempty"`
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
 
-	// Interval for scraping metrics
-	// +kubebuilder:default="30s"
+	// Medium of the emptyDir. Memory uses a tmpfs that counts against the memory limit.
+	// +kubebuilder:validation:Enum="";Memory
 	// +optional
-	Interval string `json:"interval,omitempty"`
+	Medium corev1.StorageMedium `json:"medium,omitempty"`
+
+	// StorageClassName provisions a generic ephemeral volume from a node-local storage
+	// class rather than using an emptyDir on the node's root disk
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
 }
 
-// ExtensionsSpec defines ParadeDB extensions configuration
-type ExtensionsSpec struct {
-	// PgSearch enables the pg_search extension (full-text search)
+// WALConfigSpec defines the WAL and checkpoint settings
+type WALConfigSpec struct {
+	// MaxWALSize is max_wal_size. Defaults to a quarter of the volume the WAL is on.
+	// +optional
+	MaxWALSize *resource.Quantity `json:"maxWalSize,omitempty"`
+
+	// MinWALSize is min_wal_size. Defaults to a quarter of maxWalSize.
+	// +optional
+	MinWALSize *resource.Quantity `json:"minWalSize,omitempty"`
+
+	// CheckpointTimeout is checkpoint_timeout, between 30s and 24h
+	// +optional
+	CheckpointTimeout *metav1.Duration `json:"checkpointTimeout,omitempty"`
+
+	// ArchiveTimeout is archive_timeout, which forces a WAL segment switch so that
+	// archived WAL is never older than this. Zero disables it.
+	// +optional
+	ArchiveTimeout *metav1.Duration `json:"archiveTimeout,omitempty"`
+
+	// WALCompression compresses full-page images written to the WAL. lz4 and zstd
+	// need PostgreSQL 15 or later.
+	// +kubebuilder:validation:Enum=pglz;lz4;zstd
+	// +optional
+	WALCompression string `json:"walCompression,omitempty"`
+}
+
+// MaintenanceSpec defines autovacuum tuning and scheduled table maintenance
+type MaintenanceSpec struct {
+	// AutovacuumProfile is a preset of autovacuum settings: "aggressive" vacuums and
+	// analyzes write-heavy tables sooner and faster, "throttled" limits the I/O autovacuum
+	// takes from queries and leaves the heavy lifting to the scheduled maintenance, and
+	// "default" keeps PostgreSQL's settings. Changing it restarts the pods.
+	// +kubebuilder:validation:Enum=default;aggressive;throttled
+	// +kubebuilder:default="default"
+	// +optional
+	AutovacuumProfile string `json:"autovacuumProfile,omitempty"`
+
+	// Window is the daily window the tables are maintained in. Required with tables.
+	// +optional
+	Window *MaintenanceWindowSpec `json:"window,omitempty"`
+
+	// Tables are maintained by a CronJob that starts at the beginning of the window and
+	// is stopped when the window ends
+	// +optional
+	Tables []MaintenanceTableSpec `json:"tables,omitempty"`
+}
+
+// MaintenanceOperation is a maintenance command run on a table
+// +kubebuilder:validation:Enum=vacuum;analyze;reindex
+type MaintenanceOperation string
+
+const (
+	MaintenanceOperationVacuum  MaintenanceOperation = "vacuum"
+	MaintenanceOperationAnalyze MaintenanceOperation = "analyze"
+	MaintenanceOperationReindex MaintenanceOperation = "reindex"
+)
+
+// MaintenanceTableSpec defines the maintenance of a table
+type MaintenanceTableSpec struct {
+	// Database the table is in. Defaults to auth.database.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Table to maintain, optionally schema-qualified
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Table string `json:"table"`
+
+	// Operations to run on the table, in the order vacuum, analyze, reindex. REINDEX
+	// runs CONCURRENTLY.
+	// +kubebuilder:default={vacuum,analyze}
+	// +kubebuilder:validation:MinItems=1
+	// +optional
+	Operations []MaintenanceOperation `json:"operations,omitempty"`
+}
+
+// WalStorageSpec defines separate WAL storage configuration
+type WalStorageSpec struct {
+	// Size of the WAL storage
+	// +kubebuilder:default="5Gi"
+	Size resource.Quantity `json:"size"`
+
+	// StorageClassName for WAL storage
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// AuthSpec defines authentication configuration
+type AuthSpec struct {
+	// SuperuserSecretRef references a Secret containing superuser credentials
+	// The secret must contain 'username' and 'password' keys
+	// +optional
+	SuperuserSecretRef *corev1.SecretReference `json:"superuserSecretRef,omitempty"`
+
+	// ManagedSuperuserSecret lets the operator generate a superuser password into the
+	// <name>-credentials Secret when no superuserSecretRef is given. When false, either
+	// superuserSecretRef is required or pgHBA must admit the superuser with trust or cert
+	// authentication, and the superuser is created without a password.
 	// +kubebuilder:default=true
-	PgSearch bool `json:"pgSearch,omitempty"`
+	// +optional
+	ManagedSuperuserSecret *bool `json:"managedSuperuserSecret,omitempty"`
+
+	// Database is the default database to create
+	// +kubebuilder:default="paradedb"
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Databases are additional application databases created at bootstrap and recreated
+	// by the operator if they go missing. Databases are never dropped.
+	// +optional
+	Databases []ApplicationDatabase `json:"databases,omitempty"`
+
+	// Users defines additional database users to create
+	// +optional
+	Users []DatabaseUser `json:"users,omitempty"`
+
+	// WorkloadRoles are managed login roles that keep full-text search and analytical
+	// queries apart, so that each can be given its own memory and time limits
+	// +optional
+	WorkloadRoles *WorkloadRolesSpec `json:"workloadRoles,omitempty"`
+
+	// ReportingUser creates a read-only login role for reporting and BI tools
+	// +optional
+	ReportingUser *ReportingUserSpec `json:"reportingUser,omitempty"`
+
+	// PgHBA are custom pg_hba.conf rules. They are placed before the managed rules, so
+	// they take precedence, and are reloaded without a restart when changed.
+	// +optional
+	PgHBA []string `json:"pgHBA,omitempty"`
+}
+
+// ApplicationDatabase defines an additional database on the instance
+type ApplicationDatabase struct {
+	// Name of the database
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	Name string `json:"name"`
+
+	// Owner is the role that owns the database. The role must exist, e.g. from auth.users.
+	// Defaults to the superuser.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// Extensions to create in the database
+	// +optional
+	Extensions []string `json:"extensions,omitempty"`
+
+	// SessionLimits apply to every connection to the database
+	SessionLimits `json:",inline"`
+}
+
+// SessionLimits are connection and timeout limits applied with ALTER DATABASE or ALTER
+// ROLE. Limits removed from the spec keep their last applied value.
+type SessionLimits struct {
+	// ConnectionLimit is the maximum number of concurrent connections, -1 for no limit
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	ConnectionLimit *int32 `json:"connectionLimit,omitempty"`
+
+	// StatementTimeout is the default statement_timeout, e.g. "30s"
+	// +optional
+	StatementTimeout string `json:"statementTimeout,omitempty"`
+
+	// IdleInTransactionSessionTimeout is the default idle_in_transaction_session_timeout,
+	// e.g. "5min"
+	// +optional
+	IdleInTransactionSessionTimeout string `json:"idleInTransactionSessionTimeout,omitempty"`
+}
+
+// DatabaseUser defines a database user
+type DatabaseUser struct {
+	// Name of the user
+	Name string `json:"name"`
+
+	// SecretRef references a Secret containing the user's password
+	SecretRef corev1.SecretReference `json:"secretRef"`
+
+	// Databases the user has access to
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// Privileges for the user
+	// +optional
+	Privileges []string `json:"privileges,omitempty"`
+
+	// SessionLimits apply to every connection of the user
+	SessionLimits `json:",inline"`
+}
+
+// WorkloadRolesSpec configures the search_service and analytics_service roles. Each
+// gets a generated password in a <name>-search-service or <name>-analytics-service Secret
+// and read access to the public schema of auth.database.
+type WorkloadRolesSpec struct {
+	// Search configures the search_service role for full-text search queries
+	// +optional
+	Search *WorkloadRoleSpec `json:"search,omitempty"`
+
+	// Analytics configures the analytics_service role for analytical queries
+	// +optional
+	Analytics *WorkloadRoleSpec `json:"analytics,omitempty"`
+}
+
+// WorkloadRoleSpec configures one workload role. Disabling a role keeps it and its Secret.
+type WorkloadRoleSpec struct {
+	// Enabled creates the role
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// WorkMem is the role's default work_mem, e.g. "64MB"
+	// +kubebuilder:validation:Pattern=`^[0-9]+(kB|MB|GB|TB)?$`
+	// +optional
+	WorkMem string `json:"workMem,omitempty"`
+
+	// SessionLimits apply to every connection of the role
+	SessionLimits `json:",inline"`
+}
+
+// ReportingUserSpec configures the read-only reporting role. Its generated password is
+// stored in the <name>-<role> Secret. Unset limits default to a statement_timeout of 5min
+// and 10 connections.
+type ReportingUserSpec struct {
+	// Enabled creates the role
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Name of the role
+	// +kubebuilder:default=reporting
+	// +kubebuilder:validation:Pattern=`^[a-z_][a-z0-9_]{0,62}$`
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// SessionLimits apply to every connection of the role
+	SessionLimits `json:",inline"`
+}
+
+// TLSSpec defines TLS configuration
+type TLSSpec struct {
+	// Enabled enables TLS for PostgreSQL connections
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// SecretRef references a Secret containing TLS certificates
+	// The secret must contain 'tls.crt', 'tls.key', and optionally 'ca.crt'
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+
+	// CertManager enables automatic certificate management via cert-manager
+	// +optional
+	CertManager *CertManagerSpec `json:"certManager,omitempty"`
+}
+
+// CertManagerSpec defines cert-manager integration
+type CertManagerSpec struct {
+	// Enabled enables cert-manager integration
+	Enabled bool `json:"enabled"`
+
+	// IssuerRef references a cert-manager Issuer or ClusterIssuer
+	// +optional
+	IssuerRef *CertIssuerRef `json:"issuerRef,omitempty"`
+}
+
+// CertIssuerRef references a cert-manager issuer
+type CertIssuerRef struct {
+	// Name of the issuer
+	Name string `json:"name"`
+
+	// Kind of the issuer (Issuer or ClusterIssuer)
+	// +kubebuilder:default="Issuer"
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind string `json:"kind"`
+}
+
+// ServiceMeshSpec defines how pods integrate with a service mesh
+type ServiceMeshSpec struct {
+	// Provider is the service mesh injecting sidecars into the namespace
+	// +kubebuilder:validation:Enum=Istio;Linkerd
+	Provider string `json:"provider"`
+
+	// Mode selects how database traffic interacts with the mesh.
+	// Bypass excludes the database port from sidecar interception; Mesh routes it
+	// through the sidecars and relies on mesh mTLS instead of PostgreSQL TLS.
+	// +kubebuilder:default="Bypass"
+	// +kubebuilder:validation:Enum=Bypass;Mesh
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// HoldUntilProxyReady delays container startup until the mesh proxy is ready
+	// +kubebuilder:default=true
+	// +optional
+	HoldUntilProxyReady *bool `json:"holdUntilProxyReady,omitempty"`
+}
+
+const (
+	// ServiceMeshIstio is the Istio service mesh
+	ServiceMeshIstio = "Istio"
+	// ServiceMeshLinkerd is the Linkerd service mesh
+	ServiceMeshLinkerd = "Linkerd"
+
+	// ServiceMeshModeBypass excludes the database port from sidecar interception
+	ServiceMeshModeBypass = "Bypass"
+	// ServiceMeshModeMesh routes database traffic through the mesh sidecars
+	ServiceMeshModeMesh = "Mesh"
+)
+
+// ConnectionPoolingSpec defines connection pooling configuration
+type ConnectionPoolingSpec struct {
+	// Enabled enables PgBouncer connection pooling
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Image is the PgBouncer container image. Defaults to the operator's default pooler
+	// image, or bitnami/pgbouncer:latest.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Replicas is the number of PgBouncer pods. With more than one, a PodDisruptionBudget
+	// and surge rollouts keep at least replicas-1 of them serving clients.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// PoolMode specifies the pool mode
+	// +kubebuilder:default="transaction"
+	// +kubebuilder:validation:Enum=session;transaction;statement
+	// +optional
+	PoolMode string `json:"poolMode,omitempty"`
+
+	// MaxClientConnections is the maximum number of client connections
+	// +kubebuilder:default=100
+	// +optional
+	MaxClientConnections int32 `json:"maxClientConnections,omitempty"`
+
+	// DefaultPoolSize is the default pool size per user/database pair
+	// +kubebuilder:default=20
+	// +optional
+	DefaultPoolSize int32 `json:"defaultPoolSize,omitempty"`
+
+	// MinPoolSize is the minimum pool size
+	// +kubebuilder:default=0
+	// +optional
+	MinPoolSize int32 `json:"minPoolSize,omitempty"`
+
+	// ReservePoolSize is the number of reserve connections
+	// +kubebuilder:default=5
+	// +optional
+	ReservePoolSize int32 `json:"reservePoolSize,omitempty"`
+
+	// Databases are routed through the pooler in addition to the application database,
+	// which always uses the pool settings above
+	// +optional
+	Databases []PoolerDatabaseSpec `json:"databases,omitempty"`
+
+	// Routes are virtual databases the pooler forwards to a database of another name or on
+	// another server, such as a replica cluster serving reporting traffic
+	// +optional
+	Routes []PoolerRouteSpec `json:"routes,omitempty"`
+
+	// Resources for the PgBouncer container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// PoolerDatabaseSpec defines a database entry in the PgBouncer configuration
+type PoolerDatabaseSpec struct {
+	// Name of the database
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// PoolSize overrides defaultPoolSize for this database
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PoolSize int32 `json:"poolSize,omitempty"`
+
+	// PoolMode overrides poolMode for this database
+	// +kubebuilder:validation:Enum=session;transaction;statement
+	// +optional
+	PoolMode string `json:"poolMode,omitempty"`
+}
+
+// PoolerRouteSpec defines a virtual database in the PgBouncer configuration. PgBouncer picks
+// the server by the database name a client connects to, so a route also separates traffic
+// by user when that user connects to it.
+type PoolerRouteSpec struct {
+	// Name is the database name clients connect to through the pooler
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Database on the target server. Defaults to name.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Host of the target server, e.g. the Service of a replica cluster. Defaults to the
+	// instance's primary Service.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Port of the target server. Defaults to the instance's port.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// PoolSize overrides defaultPoolSize for this route
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PoolSize int32 `json:"poolSize,omitempty"`
+
+	// PoolMode overrides poolMode for this route
+	// +kubebuilder:validation:Enum=session;transaction;statement
+	// +optional
+	PoolMode string `json:"poolMode,omitempty"`
+}
+
+// BackupSpec defines backup configuration
+type BackupSpec struct {
+	// Enabled enables automated backups
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Schedule is a cron expression for backup scheduling
+	// +kubebuilder:default="0 2 * * *"
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Timezone is the IANA time zone the schedules are interpreted in, e.g.
+	// "Europe/Berlin". Defaults to the time zone of the kube-controller-manager, usually UTC.
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// Jitter delays the start of each backup by up to this long. The delay is derived from
+	// the instance's namespace and name, so instances sharing a schedule start at different
+	// but predictable times.
+	// +optional
+	Jitter *metav1.Duration `json:"jitter,omitempty"`
+
+	// RetentionPolicy defines how long to keep backups
+	// +optional
+	RetentionPolicy *RetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// S3 configuration for storing backups in S3-compatible storage
+	// +optional
+	S3 *S3BackupSpec `json:"s3,omitempty"`
+
+	// PVC configuration for storing backups on PersistentVolumes
+	// +optional
+	PVC *PVCBackupSpec `json:"pvc,omitempty"`
+
+	// Logical configures per-database pg_dump backups to the same target, on their own schedule
+	// +optional
+	Logical *LogicalBackupSpec `json:"logical,omitempty"`
+
+	// Resources for the containers of backup Jobs, so that dumps do not starve the
+	// database on a shared node
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector for backup Job pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations for backup Job pods
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// ConcurrencyPolicy decides whether a backup starts while the previous one is still
+	// running: Forbid skips it, Replace cancels the running one, Allow runs both
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	// +kubebuilder:default=Forbid
+	// +optional
+	ConcurrencyPolicy batchv1.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// ActiveDeadlineSeconds fails a backup Job that runs longer, instead of letting a hung
+	// dump block the following backups
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// Compression configures how backups are compressed
+	// +optional
+	Compression *BackupCompressionSpec `json:"compression,omitempty"`
+
+	// Parallelism is the number of parallel workers used to dump databases and
+	// upload backups
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	Parallelism int32 `json:"parallelism,omitempty"`
+
+	// SuccessfulJobsHistoryLimit is the number of completed backup Jobs to keep
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3
+	// +optional
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+
+	// FailedJobsHistoryLimit is the number of failed backup Jobs to keep
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	// +optional
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+
+	// Suspend stops scheduling new backups, for example during maintenance, while
+	// keeping the configuration and the backups taken so far
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// BackupCompressionSpec defines backup compression
+type BackupCompressionSpec struct {
+	// Algorithm is the compression algorithm
+	// +kubebuilder:validation:Enum=gzip;lz4;zstd;none
+	// +kubebuilder:default=gzip
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Level is the compression level. Defaults to the algorithm's default level.
+	// gzip accepts 1-9, lz4 1-12 and zstd 1-22.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=22
+	// +optional
+	Level int32 `json:"level,omitempty"`
+}
+
+// LogicalBackupSpec defines per-database pg_dump backups
+type LogicalBackupSpec struct {
+	// Enabled enables logical backups
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Schedule is a cron expression for logical backups
+	// +kubebuilder:default="0 3 * * *"
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Databases to dump. Defaults to the application database.
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+}
+
+// RetentionPolicy defines backup retention
+type RetentionPolicy struct {
+	// KeepLast is the number of recent backups to keep
+	// +kubebuilder:default=7
+	// +optional
+	KeepLast int32 `json:"keepLast,omitempty"`
+
+	// KeepDaily is the number of daily backups to keep
+	// +kubebuilder:default=7
+	// +optional
+	KeepDaily int32 `json:"keepDaily,omitempty"`
+
+	// KeepWeekly is the number of weekly backups to keep
+	// +kubebuilder:default=4
+	// +optional
+	KeepWeekly int32 `json:"keepWeekly,omitempty"`
+}
+
+// S3BackupSpec defines S3-compatible backup storage
+type S3BackupSpec struct {
+	// Endpoint is the S3 endpoint URL
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the S3 bucket name
+	Bucket string `json:"bucket"`
+
+	// Region is the S3 region
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// SecretRef references a Secret containing S3 credentials
+	// The secret must contain 'accessKeyId' and 'secretAccessKey'
+	// Not needed with serviceAccountAuth.
+	// +optional
+	SecretRef corev1.SecretReference `json:"secretRef,omitempty"`
+
+	// ServiceAccountAuth authenticates backup jobs through the IAM role bound to their
+	// ServiceAccount (IRSA, GKE Workload Identity) instead of static access keys
+	// +optional
+	ServiceAccountAuth bool `json:"serviceAccountAuth,omitempty"`
+
+	// ServiceAccountName is an existing ServiceAccount for backup jobs. If unset with
+	// serviceAccountAuth, the operator manages a <name>-backup ServiceAccount.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// ServiceAccountAnnotations are set on the managed ServiceAccount, e.g.
+	// eks.amazonaws.com/role-arn or iam.gke.io/gcp-service-account
+	// +optional
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+
+	// Path prefix for backups in the bucket
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// PVCBackupSpec defines PVC-based backup storage
+type PVCBackupSpec struct {
+	// Size is the size of the backup PVC
+	// +kubebuilder:default="20Gi"
+	Size resource.Quantity `json:"size"`
+
+	// StorageClassName for the backup PVC
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// MonitoringSpec defines monitoring configuration
+type MonitoringSpec struct {
+	// Enabled enables Prometheus metrics exporter
+	// +kubebuilder:default=true
+	Enabled bool `json:"enabled"`
+
+	// Image is the postgres_exporter container image. Defaults to the operator's default
+	// exporter image, or quay.io/prometheuscommunity/postgres-exporter:latest.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Port for the metrics endpoint
+	// +kubebuilder:default=9187
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// Mode is where the exporter runs: as a sidecar of each database pod, or as a
+	// separate Deployment that connects through the primary Service, so that changing
+	// exporter settings or its image does not restart the database
+	// +kubebuilder:validation:Enum=sidecar;deployment
+	// +kubebuilder:default=sidecar
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Suspend stops the exporter while keeping the monitoring configuration, the metrics
+	// Service and the ServiceMonitor. In sidecar mode this restarts the database pods.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// Resources for the exporter container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ServiceMonitor enables creating a ServiceMonitor for Prometheus Operator
+	// +optional
+	ServiceMonitor *ServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+
+	// CustomQueries allows defining custom metrics queries
+	// +optional
+	CustomQueries map[string]string `json:"customQueries,omitempty"`
+
+	// Collectors enables or disables postgres_exporter collectors by name, e.g.
+	// stat_statements, long_running_transactions or locks. Collectors that are not
+	// listed keep the exporter's defaults.
+	// +optional
+	Collectors map[string]bool `json:"collectors,omitempty"`
+
+	// AutoDiscoverDatabases scrapes every database on the instance rather than only
+	// the application database
+	// +optional
+	AutoDiscoverDatabases bool `json:"autoDiscoverDatabases,omitempty"`
+
+	// ExtraArgs are appended to the exporter's command line
+	// +optional
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// PrometheusAnnotations configures the prometheus.io annotations on the pods and the
+	// metrics Service, which annotation-based Prometheus scrape configs discover targets by
+	// +optional
+	PrometheusAnnotations *PrometheusAnnotationsSpec `json:"prometheusAnnotations,omitempty"`
+
+	// SQLMetrics are metrics read from the application database with SQL queries, such as
+	// the number of documents indexed. They are served by an sql_exporter container next
+	// to postgres_exporter on the sql-metrics port.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	SQLMetrics []SQLMetricSpec `json:"sqlMetrics,omitempty"`
+
+	// SQLExporterImage is the sql_exporter container image. Defaults to
+	// burningalchemist/sql_exporter:0.17.0.
+	// +optional
+	SQLExporterImage string `json:"sqlExporterImage,omitempty"`
+}
+
+// SQLMetricSpec defines a metric read with a SQL query
+type SQLMetricSpec struct {
+	// Name of the metric
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_:][a-zA-Z0-9_:]*$`
+	// +required
+	Name string `json:"name"`
+
+	// Help describes the metric
+	// +optional
+	Help string `json:"help,omitempty"`
+
+	// Type of the metric
+	// +kubebuilder:validation:Enum=gauge;counter
+	// +kubebuilder:default=gauge
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Query returns one row per series. It runs in auth.database as the superuser on
+	// every scrape, so it should be read-only and cheap.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Query string `json:"query"`
+
+	// Labels are the columns whose values label the series
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// Value is the column holding the value of the series
+	// +kubebuilder:default=value
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// PrometheusAnnotationsSpec defines the prometheus.io scrape annotations
+type PrometheusAnnotationsSpec struct {
+	// Enabled adds the annotations. Disable it when metrics are only scraped through
+	// ServiceMonitors.
+	// +kubebuilder:default=true
+	Enabled bool `json:"enabled"`
+
+	// Path is the prometheus.io/path annotation, for scrape configs that do not default
+	// to /metrics
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// ServiceMonitorSpec defines ServiceMonitor configuration
+type ServiceMonitorSpec struct {
+	// Enabled enables ServiceMonitor creation
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Labels to add to the ServiceMonitor
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Interval for scraping metrics
+	// +kubebuilder:default="30s"
+	// +optional
+	Interval string `json:"interval,omitempty"`
+}
+
+// ReplicaOfSpec defines the primary server a standby instance streams from, or the
+// archive it restores from. Exactly one of host and s3Archive is set.
+type ReplicaOfSpec struct {
+	// Host of the primary server
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Port of the primary server
+	// +kubebuilder:default=5432
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+
+	// CredentialsSecretRef references a Secret with the 'username' and 'password' of a
+	// role with the REPLICATION attribute on the primary. Required with host.
+	// +optional
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// S3Archive restores the standby from a base backup and continuously archived WAL in
+	// S3-compatible storage, without a network path to the primary
+	// +optional
+	S3Archive *S3ArchiveSpec `json:"s3Archive,omitempty"`
+
+	// SSLMode of the replication connection
+	// +kubebuilder:validation:Enum=disable;allow;prefer;require;verify-ca;verify-full
+	// +kubebuilder:default=prefer
+	// +optional
+	SSLMode string `json:"sslMode,omitempty"`
+
+	// Promote stops streaming and turns the standby into an independent primary. The
+	// pods restart to apply it, and it cannot be undone.
+	// +optional
+	Promote bool `json:"promote,omitempty"`
+}
+
+// S3ArchiveSpec defines the object-store archive a standby restores from. The primary
+// writes a pg_basebackup tar to <path>/base/base.tar.gz and archives WAL segments to
+// <path>/wal/.
+type S3ArchiveSpec struct {
+	// Endpoint is the S3 endpoint URL
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the S3 bucket name
+	Bucket string `json:"bucket"`
+
+	// Path prefix of the archive in the bucket
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Region is the S3 region
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// SecretRef references a Secret containing 'accessKeyId' and 'secretAccessKey'. Without
+	// it, the pods authenticate through their ServiceAccount.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// BootstrapSpec defines how a new instance is populated
+type BootstrapSpec struct {
+	// InitDB configures how the data directory is initialized. It only applies when
+	// the data directory is first created and cannot be changed afterwards.
+	// +optional
+	InitDB *InitDBSpec `json:"initdb,omitempty"`
+
+	// FromExternal imports databases from an external PostgreSQL server
+	// +optional
+	FromExternal *ExternalBootstrapSpec `json:"fromExternal,omitempty"`
+
+	// FromInstance clones databases and roles from another ParadeDB in the same namespace
+	// +optional
+	FromInstance *InstanceBootstrapSpec `json:"fromInstance,omitempty"`
+
+	// Snapshot restores the data volume from a ParadeDBSnapshot in the same namespace. The
+	// instance keeps the roles and passwords of the snapshotted instance.
+	// +optional
+	Snapshot *corev1.LocalObjectReference `json:"snapshot,omitempty"`
+
+	// ImportData loads datasets from object storage into tables once the new instance
+	// is up, reading them through the spec.analytics servers with pg_analytics
+	// +optional
+	ImportData []DataImportSpec `json:"importData,omitempty"`
+}
+
+// DataImportSpec defines a dataset loaded into a table on first boot
+type DataImportSpec struct {
+	// Server is the spec.analytics server whose object store and credentials are used.
+	// The table is created in the server's database.
+	// +kubebuilder:validation:MinLength=1
+	Server string `json:"server"`
+
+	// Path of the objects, e.g. "s3://lake/trips/*.parquet"
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// Table is the optionally schema-qualified table created from the dataset. A table
+	// that already exists is left as is.
+	// +kubebuilder:validation:MinLength=1
+	Table string `json:"table"`
+
+	// Format of the objects. It must match the wrapper of the server.
+	// +kubebuilder:validation:Enum=csv;parquet
+	// +kubebuilder:default=parquet
+	// +optional
+	Format string `json:"format,omitempty"`
+}
+
+// InitDBSpec defines initdb options
+type InitDBSpec struct {
+	// Locale of the cluster, e.g. "en_US.UTF-8". It must be available in the image.
+	// +optional
+	Locale string `json:"locale,omitempty"`
+
+	// Encoding of the template databases, e.g. "UTF8"
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
+
+	// DataChecksums enables checksums on data pages to detect corruption
+	// +optional
+	DataChecksums bool `json:"dataChecksums,omitempty"`
+
+	// WALSegmentSize is the WAL segment size in megabytes
+	// +kubebuilder:validation:Enum=1;2;4;8;16;32;64;128;256;512;1024
+	// +optional
+	WALSegmentSize int32 `json:"walSegmentSize,omitempty"`
+
+	// Args are additional initdb arguments
+	// +optional
+	Args []string `json:"args,omitempty"`
+}
+
+// ExternalBootstrapSpec defines an import from an external PostgreSQL server
+type ExternalBootstrapSpec struct {
+	// ConnectionSecretRef selects a Secret key holding a libpq key/value connection
+	// string for the source server, without dbname (e.g. "host=pg.example.com user=app password=...")
+	ConnectionSecretRef corev1.SecretKeySelector `json:"connectionSecretRef"`
+
+	// Databases to import. Names must be lowercase letters, digits and underscores.
+	// +kubebuilder:validation:MinItems=1
+	Databases []string `json:"databases"`
+
+	// Method is how data is copied. dump restores a pg_dump once; logicalReplication
+	// copies the schema and then subscribes to the source until cutover.
+	// +kubebuilder:validation:Enum=dump;logicalReplication
+	// +kubebuilder:default=dump
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Cutover stops logical replication from the source, making the instance independent
+	// +optional
+	Cutover bool `json:"cutover,omitempty"`
+}
+
+// InstanceBootstrapSpec defines a clone of another ParadeDB in the same namespace
+type InstanceBootstrapSpec struct {
+	// Name of the ParadeDB to clone
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Databases to clone. Names must be lowercase letters, digits and underscores.
+	// +kubebuilder:validation:MinItems=1
+	Databases []string `json:"databases"`
+
+	// SchemaOnly clones the roles, schemas and DDL, including BM25 and vector index
+	// definitions, without copying any rows
+	// +optional
+	SchemaOnly bool `json:"schemaOnly,omitempty"`
+}
+
+// LogicalRestoreSpec defines a pg_restore of selected databases and tables from a
+// logical backup in the spec.backup target
+type LogicalRestoreSpec struct {
+	// Source selects the logical backup to restore from
+	// +optional
+	Source LogicalRestoreSourceSpec `json:"source,omitempty"`
+
+	// Target selects what to restore
+	Target LogicalRestoreTargetSpec `json:"target"`
+}
+
+// LogicalRestoreSourceSpec selects a logical backup
+type LogicalRestoreSourceSpec struct {
+	// Instance is the name of the ParadeDB whose logical backups are read from the
+	// spec.backup S3 bucket. Defaults to this instance; backups on a PVC can only be
+	// restored into the instance that wrote them.
+	// +optional
+	Instance string `json:"instance,omitempty"`
+
+	// Backup is the timestamp of the backup, e.g. "20260101T030000Z". Defaults to the
+	// most recent backup.
+	// +kubebuilder:validation:Pattern=`^[0-9]{8}T[0-9]{6}Z$`
+	// +optional
+	Backup string `json:"backup,omitempty"`
+}
+
+// LogicalRestoreTargetSpec selects the databases and tables to restore
+type LogicalRestoreTargetSpec struct {
+	// Databases are restored as a whole, replacing the objects in the backup. Missing
+	// databases are created.
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// Tables are restored with their data into the existing database. A table that still
+	// exists fails the restore, so drop or rename it first.
+	// +optional
+	Tables []LogicalRestoreTableSpec `json:"tables,omitempty"`
+}
+
+// LogicalRestoreTableSpec selects one table in a logical backup
+type LogicalRestoreTableSpec struct {
+	// Database the table is in
+	Database string `json:"database"`
+
+	// Schema of the table
+	// +kubebuilder:default="public"
+	// +optional
+	Schema string `json:"schema,omitempty"`
+
+	// Name of the table
+	Name string `json:"name"`
+}
+
+// CDCSpec defines change data capture configuration. Enabling it sets wal_level=logical
+// and provisions a replication role whose credentials are stored in the <name>-cdc Secret.
+type CDCSpec struct {
+	// Enabled turns on change data capture
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Username of the replication role, which can read all tables in the public schema
+	// +kubebuilder:default="cdc"
+	// +optional
+	Username string `json:"username,omitempty"`
+
+	// Publication is the name of a publication covering all tables, created for
+	// connectors using the pgoutput plugin
+	// +optional
+	Publication string `json:"publication,omitempty"`
+
+	// Slots are logical replication slots kept in the application database
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Slots []CDCSlotSpec `json:"slots,omitempty"`
+}
+
+// CDCSlotSpec defines a logical replication slot
+type CDCSlotSpec struct {
+	// Name of the replication slot
+	// +kubebuilder:validation:Pattern=`^[a-z0-9_]{1,63}$`
+	Name string `json:"name"`
+
+	// Plugin is the logical decoding output plugin
+	// +kubebuilder:default="pgoutput"
+	// +optional
+	Plugin string `json:"plugin,omitempty"`
+}
+
+// ExtensionsSpec defines ParadeDB extensions configuration
+type ExtensionsSpec struct {
+	// PgSearch enables the pg_search extension (full-text search)
+	// +kubebuilder:default=true
+	PgSearch bool `json:"pgSearch,omitempty"`
+
+	// PgAnalytics enables the pg_analytics extension (DuckDB integration)
+	// +kubebuilder:default=true
+	PgAnalytics bool `json:"pgAnalytics,omitempty"`
+
+	// PgVector enables the pgvector extension (vector similarity search)
+	// +kubebuilder:default=false
+	// +optional
+	PgVector bool `json:"pgVector,omitempty"`
+
+	// Additional is a list of additional PostgreSQL extensions to enable
+	// +optional
+	Additional []string `json:"additional,omitempty"`
+}
+
+// AnalyticsSpec defines the pg_analytics foreign servers the operator manages
+type AnalyticsSpec struct {
+	// Servers are created along with their foreign data wrapper. Their user mappings are
+	// kept in sync with the credentials Secret, so rotated keys take effect without
+	// running ALTER USER MAPPING by hand.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Servers []AnalyticsServerSpec `json:"servers,omitempty"`
+}
+
+// AnalyticsServerSpec defines a pg_analytics foreign server for an object store
+type AnalyticsServerSpec struct {
+	// Name of the foreign server
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Name string `json:"name"`
+
+	// Database the server is created in. Defaults to auth.database.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Wrapper is the pg_analytics foreign data wrapper the server uses
+	// +kubebuilder:validation:Enum=parquet;delta;iceberg;csv;json;spatial
+	// +kubebuilder:default=parquet
+	// +optional
+	Wrapper string `json:"wrapper,omitempty"`
+
+	// Type of the object store
+	// +kubebuilder:validation:Enum=S3;GCS;R2
+	// +kubebuilder:default=S3
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// User the mapping is created for
+	// +kubebuilder:default=public
+	// +optional
+	User string `json:"user,omitempty"`
+
+	// CredentialsSecretRef references a Secret with 'accessKeyId' and 'secretAccessKey',
+	// and optionally 'sessionToken'
+	// +required
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Options are additional user mapping options, e.g. region, endpoint or url_style
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// VectorSpec defines how pgvector indexes are built and maintained
+type VectorSpec struct {
+	// MaintenanceWorkMem is the maintenance_work_mem of index builds and rebuilds, e.g.
+	// "2GB". HNSW builds are much faster when the graph fits into it.
+	// +optional
+	MaintenanceWorkMem string `json:"maintenanceWorkMem,omitempty"`
+
+	// MaxParallelMaintenanceWorkers is the max_parallel_maintenance_workers of index
+	// builds and rebuilds
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxParallelMaintenanceWorkers *int32 `json:"maxParallelMaintenanceWorkers,omitempty"`
+
+	// Indexes the operator builds in Jobs. Changing an index rebuilds it under a
+	// temporary name and swaps it in; removing it from the list does not drop it.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Indexes []VectorIndexSpec `json:"indexes,omitempty"`
+}
+
+// VectorIndexSpec defines a pgvector index
+type VectorIndexSpec struct {
+	// Name of the index. It is built as <name>_new before being swapped in.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=59
+	// +required
+	Name string `json:"name"`
+
+	// Database the table is in. Defaults to auth.database.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Table to index, optionally schema-qualified
+	// +required
+	Table string `json:"table"`
+
+	// Column holding the vectors
+	// +required
+	Column string `json:"column"`
+
+	// Method is the index access method
+	// +kubebuilder:validation:Enum=hnsw;ivfflat
+	// +kubebuilder:default=hnsw
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// OperatorClass selects the distance function, e.g. vector_cosine_ops
+	// +kubebuilder:default=vector_l2_ops
+	// +optional
+	OperatorClass string `json:"operatorClass,omitempty"`
+
+	// M is the maximum number of connections per layer of an HNSW index
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	M *int32 `json:"m,omitempty"`
+
+	// EfConstruction is the candidate list size while building an HNSW index
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=1000
+	// +optional
+	EfConstruction *int32 `json:"efConstruction,omitempty"`
+
+	// Lists is the number of inverted lists of an IVFFlat index
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32768
+	// +optional
+	Lists *int32 `json:"lists,omitempty"`
+
+	// RebuildSchedule is a cron expression on which the index is rebuilt with REINDEX
+	// CONCURRENTLY, e.g. to rebalance IVFFlat lists after the data has shifted
+	// +optional
+	RebuildSchedule string `json:"rebuildSchedule,omitempty"`
+}
+
+// Architecture is a CPU architecture as reported in the kubernetes.io/arch node label
+// +kubebuilder:validation:Enum=amd64;arm64
+type Architecture string
+
+// ParadeDBPhase represents the current phase of the ParadeDB instance
+// +kubebuilder:validation:Enum=Pending;Creating;Running;Updating;Failed;Deleting
+type ParadeDBPhase string
+
+const (
+	ParadeDBPhasePending  ParadeDBPhase = "Pending"
+	ParadeDBPhaseCreating ParadeDBPhase = "Creating"
+	ParadeDBPhaseRunning  ParadeDBPhase = "Running"
+	ParadeDBPhaseUpdating ParadeDBPhase = "Updating"
+	ParadeDBPhaseFailed   ParadeDBPhase = "Failed"
+	ParadeDBPhaseDeleting ParadeDBPhase = "Deleting"
+)
+
+// ExtensionStatus describes an extension installed in a database
+type ExtensionStatus struct {
+	// Name of the extension
+	Name string `json:"name"`
+
+	// Version of the extension
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Database the extension is installed in
+	// +optional
+	Database string `json:"database,omitempty"`
+}
+
+// ImportPhase is the progress of an import from an external server
+type ImportPhase string
+
+const (
+	ImportPhaseCopying     ImportPhase = "Copying"
+	ImportPhaseReplicating ImportPhase = "Replicating"
+	ImportPhaseCompleted   ImportPhase = "Completed"
+	ImportPhaseFailed      ImportPhase = "Failed"
+)
+
+// ImportStatus reports the progress of an import from an external server
+type ImportStatus struct {
+	// Phase of the import
+	// +optional
+	Phase ImportPhase `json:"phase,omitempty"`
+
+	// Message provides additional information about the import
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Databases reports the sync progress of each database while replicating
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Databases []DatabaseImportStatus `json:"databases,omitempty"`
+
+	// StartTime is when the import started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the import completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// DatabaseImportStatus reports the sync progress of one imported database
+type DatabaseImportStatus struct {
+	// Name of the database
+	Name string `json:"name"`
+
+	// Tables is the number of tables being replicated
+	// +optional
+	Tables int32 `json:"tables,omitempty"`
+
+	// TablesSynced is the number of tables whose initial copy has finished
+	// +optional
+	TablesSynced int32 `json:"tablesSynced,omitempty"`
+}
+
+// BackupPhase is the phase of a backup run
+type BackupPhase string
+
+const (
+	BackupPhaseDumping   BackupPhase = "Dumping"
+	BackupPhaseUploading BackupPhase = "Uploading"
+	BackupPhaseCompleted BackupPhase = "Completed"
+	BackupPhaseFailed    BackupPhase = "Failed"
+)
+
+// BackupStatus reports the progress of the most recent backup run
+type BackupStatus struct {
+	// JobName is the Job running the backup
+	JobName string `json:"jobName"`
+
+	// Phase of the backup
+	// +optional
+	Phase BackupPhase `json:"phase,omitempty"`
+
+	// Message provides additional information about the backup
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// SizeBytes is the size of the backup once it has been written
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+
+	// StartTime is when the backup started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the backup completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ReplicationState summarizes the health of replication
+type ReplicationState string
+
+const (
+	// ReplicationStreaming means a standby receives WAL from its primary, or a primary
+	// streams to all of its replication slots
+	ReplicationStreaming ReplicationState = "Streaming"
+	// ReplicationArchiveReplay means a standby replays WAL fetched from the archive
+	ReplicationArchiveReplay ReplicationState = "ArchiveReplay"
+	// ReplicationDisconnected means a standby is not receiving WAL
+	ReplicationDisconnected ReplicationState = "Disconnected"
+	// ReplicationInactiveSlots means a primary retains WAL for slots nobody consumes
+	ReplicationInactiveSlots ReplicationState = "InactiveSlots"
+)
+
+// ReplicationStatus reports streaming replication as seen in pg_stat_wal_receiver,
+// pg_stat_replication and pg_replication_slots
+type ReplicationStatus struct {
+	// Role is Primary or Standby
+	Role string `json:"role"`
+
+	// State summarizes the health of replication
+	State ReplicationState `json:"state"`
+
+	// Senders is the number of WAL senders streaming to standbys and subscribers
+	// +optional
+	Senders int32 `json:"senders,omitempty"`
+
+	// InactiveSlots is the number of replication slots without a consumer
+	// +optional
+	InactiveSlots int32 `json:"inactiveSlots,omitempty"`
+
+	// LagSeconds is how far WAL replay on a standby is behind
+	// +optional
+	LagSeconds int32 `json:"lagSeconds,omitempty"`
+}
+
+// WALArchiveStatus reports continuous archiving as seen in pg_stat_archiver
+type WALArchiveStatus struct {
+	// LastArchivedWAL is the name of the most recently archived WAL segment
+	// +optional
+	LastArchivedWAL string `json:"lastArchivedWAL,omitempty"`
+
+	// LastArchivedTime is when the most recent WAL segment was archived
+	// +optional
+	LastArchivedTime *metav1.Time `json:"lastArchivedTime,omitempty"`
+
+	// LastFailedWAL is the name of the WAL segment of the most recent failed attempt
+	// +optional
+	LastFailedWAL string `json:"lastFailedWAL,omitempty"`
+
+	// LastFailedTime is when archiving most recently failed
+	// +optional
+	LastFailedTime *metav1.Time `json:"lastFailedTime,omitempty"`
+
+	// Failing is true if the most recent archiving attempt failed
+	Failing bool `json:"failing"`
+}
+
+// RestorePhase is the progress of a logical restore
+type RestorePhase string
+
+const (
+	RestorePhaseRunning   RestorePhase = "Running"
+	RestorePhaseCompleted RestorePhase = "Completed"
+	RestorePhaseFailed    RestorePhase = "Failed"
+)
+
+// RestoreStatus reports the progress of the most recent logical restore
+type RestoreStatus struct {
+	// JobName is the Job running the restore
+	JobName string `json:"jobName"`
+
+	// Phase of the restore
+	// +optional
+	Phase RestorePhase `json:"phase,omitempty"`
+
+	// Message provides additional information about the restore
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when the restore started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the restore completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// CDCSlotStatus describes a logical replication slot
+type CDCSlotStatus struct {
+	// Name of the replication slot
+	Name string `json:"name"`
+
+	// Active is true while a consumer is connected to the slot
+	// +optional
+	Active bool `json:"active,omitempty"`
+
+	// RetainedWALBytes is the amount of WAL the slot keeps from being removed
+	// +optional
+	RetainedWALBytes int64 `json:"retainedWALBytes,omitempty"`
+}
+
+// ComponentStatus reports the readiness of a managed component
+type ComponentStatus struct {
+	// Name of the component: database, pooler, exporter or backups
+	Name string `json:"name"`
+
+	// Ready is true when the component is fully available
+	Ready bool `json:"ready"`
+
+	// Replicas is the desired number of instances of the component
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is the number of ready instances of the component
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Message describes the state of the component
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// SelfTestStatus reports the most recent self-test requested with the
+// database.paradedb.io/self-test annotation
+type SelfTestStatus struct {
+	// Request is the annotation value the self-test ran for
+	Request string `json:"request"`
+
+	// Time is when the self-test ran
+	Time metav1.Time `json:"time"`
+
+	// Passed is true if every check passed
+	Passed bool `json:"passed"`
+
+	// Checks are the results of the individual checks, in the order they ran
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Checks []SelfTestCheck `json:"checks,omitempty"`
+}
+
+// SelfTestCheck is the result of one check of a self-test
+type SelfTestCheck struct {
+	// Name of the check: service, tls, readWrite, bm25Search, vectorSearch or pooler
+	Name string `json:"name"`
+
+	// Passed is true if the check succeeded
+	Passed bool `json:"passed"`
+
+	// Message describes the failure, or what was verified
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PoolerStatus summarizes the PgBouncer pools across the ready pooler pods, as reported
+// by SHOW POOLS on the admin console
+type PoolerStatus struct {
+	// ActiveClients is the number of client connections paired with a server connection
+	ActiveClients int32 `json:"activeClients"`
+
+	// WaitingClients is the number of client connections waiting for a server connection.
+	// Clients that keep waiting mean the pools are too small.
+	WaitingClients int32 `json:"waitingClients"`
+
+	// ServerConnections is the number of connections open to the database
+	ServerConnections int32 `json:"serverConnections"`
+
+	// PoolSize is the number of server connections the pools may open in total
+	PoolSize int32 `json:"poolSize"`
+
+	// UtilizationPercent is the share of PoolSize used by active server connections
+	UtilizationPercent int32 `json:"utilizationPercent"`
+
+	// MaxWaitSeconds is how long the oldest waiting client has been waiting
+	// +optional
+	MaxWaitSeconds int32 `json:"maxWaitSeconds,omitempty"`
+
+	// Saturated is true while clients are waiting for a server connection
+	Saturated bool `json:"saturated"`
+
+	// LastUpdateTime is when the pools were last queried
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// OperationType is the kind of operation recorded in the operations history
+type OperationType string
+
+const (
+	// OperationUpgrade rolls the pods onto a new image
+	OperationUpgrade OperationType = "Upgrade"
+	// OperationRestart rolls the pods for the restart annotation
+	OperationRestart OperationType = "Restart"
+	// OperationPromotion promotes a replica cluster to primary
+	OperationPromotion OperationType = "Promotion"
+	// OperationRollout rolls the pods for any other pod template change
+	OperationRollout OperationType = "Rollout"
+	// OperationRestore initializes the data volume from a snapshot, or restores databases
+	// and tables from a logical backup
+	OperationRestore OperationType = "Restore"
+	// OperationImport copies data from an external server
+	OperationImport OperationType = "Import"
+	// OperationConfigReload reloads the configuration files into the running pods
+	OperationConfigReload OperationType = "ConfigReload"
+	// OperationBackup takes a logical backup
+	OperationBackup OperationType = "Backup"
+	// OperationRemediation deletes a stuck or crash-looping pod
+	OperationRemediation OperationType = "Remediation"
+)
+
+// OperationOutcome is the result of a recorded operation
+type OperationOutcome string
+
+const (
+	OperationRunning   OperationOutcome = "Running"
+	OperationSucceeded OperationOutcome = "Succeeded"
+	OperationFailed    OperationOutcome = "Failed"
+)
+
+// OperationRecord is an entry in the operations history
+type OperationRecord struct {
+	// Type of the operation
+	Type OperationType `json:"type"`
+
+	// Outcome of the operation, Running until it completes
+	Outcome OperationOutcome `json:"outcome"`
+
+	// Message describes the operation
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when the operation started
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is when the operation succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ParadeDBStatus defines the observed state of ParadeDB
+type ParadeDBStatus struct {
+	// Phase represents the current phase of the ParadeDB instance
+	// +optional
+	Phase ParadeDBPhase `json:"phase,omitempty"`
+
+	// ReadyReplicas is the number of ready replicas
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Selector is the label selector for the ParadeDB pods, used by the scale subresource
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// CurrentVersion is the image the pods run, pinned by digest when the operator could
+	// resolve the tag of the image in the spec
+	// +optional
+	CurrentVersion string `json:"currentVersion,omitempty"`
+
+	// PostgresVersion is the postgresVersion the pods were last rolled out with
+	// +optional
+	PostgresVersion string `json:"postgresVersion,omitempty"`
+
+	// PrimaryPod is the pod running the primary. It is empty for a standby cluster.
+	// +optional
+	PrimaryPod string `json:"primaryPod,omitempty"`
+
+	// Image is the image from the spec, class or version catalog that currentVersion was
+	// resolved from
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// CatalogVersion is the catalog release the instance runs when spec.updatePolicy is set
+	// +optional
+	CatalogVersion string `json:"catalogVersion,omitempty"`
+
+	// ConfigRevision is the revision of the rendered postgresql.conf in the configuration
+	// history ConfigMap
+	// +optional
+	ConfigRevision int64 `json:"configRevision,omitempty"`
+
+	// ActiveSchedule is the entry of spec.schedules currently in effect
+	// +optional
+	ActiveSchedule string `json:"activeSchedule,omitempty"`
+
+	// Endpoint is the connection endpoint for the database
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// PoolerEndpoint is the connection endpoint for the connection pooler
+	// +optional
+	PoolerEndpoint string `json:"poolerEndpoint,omitempty"`
+
+	// Pooler summarizes the saturation of the PgBouncer pools
+	// +optional
+	Pooler *PoolerStatus `json:"pooler,omitempty"`
+
+	// LastBackup is the timestamp of the last successful backup
+	// +optional
+	LastBackup *metav1.Time `json:"lastBackup,omitempty"`
+
+	// LastBackupSize is the size of the last backup
+	// +optional
+	LastBackupSize string `json:"lastBackupSize,omitempty"`
+
+	// Replication summarizes streaming replication as seen by the instance, unset on a
+	// primary without standbys or replication slots
+	// +optional
+	Replication *ReplicationStatus `json:"replication,omitempty"`
+
+	// DatabaseSizeBytes is the total size of all databases on the primary
+	// +optional
+	DatabaseSizeBytes int64 `json:"databaseSizeBytes,omitempty"`
+
+	// DataVolumeUsedPercent estimates how full the data volume is from the size of the
+	// databases and WAL relative to the requested storage size
+	// +optional
+	DataVolumeUsedPercent int32 `json:"dataVolumeUsedPercent,omitempty"`
+
+	// CurrentConnections is the number of client connections to the primary, not
+	// counting the operator's own
+	// +optional
+	CurrentConnections int32 `json:"currentConnections,omitempty"`
+
+	// StatsUpdatedTime is when databaseSizeBytes, dataVolumeUsedPercent and
+	// currentConnections were last refreshed
+	// +optional
+	StatsUpdatedTime *metav1.Time `json:"statsUpdatedTime,omitempty"`
 
-	// PgAnalytics enables the pg_analytics extension (DuckDB integration)
-	// +kubebuilder:default=true
-	PgAnalytics bool `json:"pgAnalytics,omitempty"`
+	// CredentialsSecretCreated is true once the operator has generated the superuser
+	// password and created the <name>-credentials Secret. Only then does a deleted Secret
+	// mean that the operator has to reset the password in the database.
+	// +optional
+	CredentialsSecretCreated bool `json:"credentialsSecretCreated,omitempty"`
 
-	// PgVector enables the pgvector extension (vector similarity search)
-	// +kubebuilder:default=false
+	// WALArchive reports continuous archiving while archive_mode is on
 	// +optional
-	PgVector bool `json:"pgVector,omitempty"`
+	WALArchive *WALArchiveStatus `json:"walArchive,omitempty"`
 
-	// Additional is a list of additional PostgreSQL extensions to enable
+	// Extensions lists the extensions installed in the application database
+	// +listType=map
+	// +listMapKey=name
 	// +optional
-	Additional []string `json:"additional,omitempty"`
-}
+	Extensions []ExtensionStatus `json:"extensions,omitempty"`
 
-// ParadeDBPhase represents the current phase of the ParadeDB instance
-// +kubebuilder:validation:Enum=Pending;Creating;Running;Updating;Failed;Deleting
-type ParadeDBPhase string
+	// Import reports the progress of bootstrapping from an external server
+	// +optional
+	Import *ImportStatus `json:"import,omitempty"`
 
-const (
-	ParadeDBPhasePending  ParadeDBPhase = "Pending"
-	ParadeDBPhaseCreating ParadeDBPhase = "Creating"
-	ParadeDBPhaseRunning  ParadeDBPhase = "Running"
-	ParadeDBPhaseUpdating ParadeDBPhase = "Updating"
-	ParadeDBPhaseFailed   ParadeDBPhase = "Failed"
-	ParadeDBPhaseDeleting ParadeDBPhase = "Deleting"
-)
+	// ConfigHash is the hash of the pg_hba.conf and configuration fragments last
+	// reloaded into every ready pod
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
 
-// ParadeDBStatus defines the observed state of ParadeDB
-type ParadeDBStatus struct {
-	// Phase represents the current phase of the ParadeDB instance
+	// PendingRestartParameters are reloaded settings that only take effect once the pods
+	// restart, so the running configuration differs from the spec until then
 	// +optional
-	Phase ParadeDBPhase `json:"phase,omitempty"`
+	PendingRestartParameters []string `json:"pendingRestartParameters,omitempty"`
 
-	// ReadyReplicas is the number of ready replicas
+	// DriftedParameters are settings changed with ALTER SYSTEM that are in effect, or
+	// waiting for a restart, in place of the configuration from the spec
 	// +optional
-	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	DriftedParameters []string `json:"driftedParameters,omitempty"`
+
+	// Components reports the readiness of each managed component, so that a degraded
+	// pooler or exporter can be told apart from a database outage
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Components []ComponentStatus `json:"components,omitempty"`
 
-	// CurrentVersion is the current ParadeDB version running
+	// OperationsHistory records the most recent operations, oldest first, since Events
+	// expire long before a post-incident review
 	// +optional
-	CurrentVersion string `json:"currentVersion,omitempty"`
+	OperationsHistory []OperationRecord `json:"operationsHistory,omitempty"`
 
-	// Endpoint is the connection endpoint for the database
+	// LogicalBackup reports the most recent logical backup
 	// +optional
-	Endpoint string `json:"endpoint,omitempty"`
+	LogicalBackup *BackupStatus `json:"logicalBackup,omitempty"`
 
-	// PoolerEndpoint is the connection endpoint for the connection pooler
+	// Restore reports the most recent restore from a logical backup
 	// +optional
-	PoolerEndpoint string `json:"poolerEndpoint,omitempty"`
+	Restore *RestoreStatus `json:"restore,omitempty"`
 
-	// LastBackup is the timestamp of the last successful backup
+	// SelfTest reports the most recent on-demand self-test
 	// +optional
-	LastBackup *metav1.Time `json:"lastBackup,omitempty"`
+	SelfTest *SelfTestStatus `json:"selfTest,omitempty"`
 
-	// LastBackupSize is the size of the last backup
+	// CDCSlots reports the managed logical replication slots
+	// +listType=map
+	// +listMapKey=name
 	// +optional
-	LastBackupSize string `json:"lastBackupSize,omitempty"`
+	CDCSlots []CDCSlotStatus `json:"cdcSlots,omitempty"`
+
+	// CDCRoleHash is the hash of the replication role's credentials and the publication
+	// last applied to the database, so that they are only applied again once changed
+	// +optional
+	CDCRoleHash string `json:"cdcRoleHash,omitempty"`
 
 	// Conditions represent the current state of the ParadeDB resource
 	// +listType=map
@@ -440,6 +2455,15 @@ type ParadeDBStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// FailureCount is the number of consecutive failed reconciliations. It drives the
+	// retry backoff and is reset once a reconciliation succeeds.
+	// +optional
+	FailureCount int32 `json:"failureCount,omitempty"`
+
+	// LastFailureTime is when reconciliation last failed
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
 	// ObservedGeneration is the most recent generation observed
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -451,10 +2475,18 @@ type ParadeDBStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.readyReplicas,selectorpath=.status.selector
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyReplicas`
 // +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.currentVersion`
 // +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.status.endpoint`
+// +kubebuilder:printcolumn:name="Disk%",type=integer,JSONPath=`.status.dataVolumeUsedPercent`
+// +kubebuilder:printcolumn:name="Connections",type=integer,JSONPath=`.status.currentConnections`
+// +kubebuilder:printcolumn:name="DB Size",type=integer,JSONPath=`.status.databaseSizeBytes`,priority=1
+// +kubebuilder:printcolumn:name="Pooler",type=string,JSONPath=`.status.poolerEndpoint`,priority=1
+// +kubebuilder:printcolumn:name="Last Backup",type=date,JSONPath=`.status.lastBackup`,priority=1
+// +kubebuilder:printcolumn:name="Replication",type=string,JSONPath=`.status.replication.state`,priority=1
+// +kubebuilder:printcolumn:name="Primary",type=string,JSONPath=`.status.primaryPod`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +kubebuilder:resource:shortName=pdb
 
@@ -491,24 +2523,435 @@ func (p *ParadeDB) GetReplicas() int32 {
 	return *p.Spec.Replicas
 }
 
+// IsReplicationConfigured returns true if spec.replication opts in to more than one replica
+func (p *ParadeDB) IsReplicationConfigured() bool {
+	return p.Spec.Replication != nil && p.Spec.Replication.Mode != ""
+}
+
 // IsConnectionPoolingEnabled returns true if connection pooling is enabled
 func (p *ParadeDB) IsConnectionPoolingEnabled() bool {
 	return p.Spec.ConnectionPooling != nil && p.Spec.ConnectionPooling.Enabled
 }
 
+// GetPoolerRoutes returns the virtual databases the pooler forwards elsewhere
+func (p *ParadeDB) GetPoolerRoutes() []PoolerRouteSpec {
+	if p.Spec.ConnectionPooling == nil {
+		return nil
+	}
+	return p.Spec.ConnectionPooling.Routes
+}
+
+// GetPoolerDatabases returns the additional databases routed through the pooler, followed
+// by the auth.databases not listed there, without the application database and duplicates
+func (p *ParadeDB) GetPoolerDatabases() []PoolerDatabaseSpec {
+	if p.Spec.ConnectionPooling == nil {
+		return nil
+	}
+	seen := map[string]bool{p.Spec.Auth.Database: true}
+	var databases []PoolerDatabaseSpec
+	for _, database := range p.Spec.ConnectionPooling.Databases {
+		if seen[database.Name] {
+			continue
+		}
+		seen[database.Name] = true
+		databases = append(databases, database)
+	}
+	for _, database := range p.Spec.Auth.Databases {
+		if seen[database.Name] {
+			continue
+		}
+		seen[database.Name] = true
+		databases = append(databases, PoolerDatabaseSpec{Name: database.Name})
+	}
+	return databases
+}
+
+// GetUpdateChannel returns the version catalog channel the instance follows
+func (p *ParadeDB) GetUpdateChannel() string {
+	if p.Spec.UpdatePolicy == nil || p.Spec.UpdatePolicy.Channel == "" {
+		return "stable"
+	}
+	return p.Spec.UpdatePolicy.Channel
+}
+
+// GetAutoUpdate returns the largest version change applied automatically
+func (p *ParadeDB) GetAutoUpdate() string {
+	if p.Spec.UpdatePolicy == nil || p.Spec.UpdatePolicy.Auto == "" {
+		return "patch"
+	}
+	return p.Spec.UpdatePolicy.Auto
+}
+
+// IsGatewayAPIEnabled returns true if a Gateway API route should be created
+func (p *ParadeDB) IsGatewayAPIEnabled() bool {
+	return p.Spec.Expose != nil && p.Spec.Expose.GatewayAPI != nil
+}
+
+// IsSuperuserPasswordless returns true if no Secret holds superuser credentials, as the
+// managed Secret is disabled and no superuserSecretRef is given
+func (p *ParadeDB) IsSuperuserPasswordless() bool {
+	managed := p.Spec.Auth.ManagedSuperuserSecret == nil || *p.Spec.Auth.ManagedSuperuserSecret
+	return !managed && p.Spec.Auth.SuperuserSecretRef == nil
+}
+
+// GetSuperuserSecretName returns the name of the Secret holding superuser credentials, or
+// an empty string if the superuser has no password
+func (p *ParadeDB) GetSuperuserSecretName() string {
+	if p.Spec.Auth.SuperuserSecretRef != nil {
+		return p.Spec.Auth.SuperuserSecretRef.Name
+	}
+	if p.IsSuperuserPasswordless() {
+		return ""
+	}
+	return p.Name + "-credentials"
+}
+
 // IsTLSEnabled returns true if TLS is enabled
 func (p *ParadeDB) IsTLSEnabled() bool {
 	return p.Spec.TLS != nil && p.Spec.TLS.Enabled
 }
 
+// IsMeshEncrypted returns true if database traffic is routed through a service mesh
+// that provides mTLS, making PostgreSQL TLS redundant
+func (p *ParadeDB) IsMeshEncrypted() bool {
+	return p.Spec.ServiceMesh != nil && p.Spec.ServiceMesh.Mode == ServiceMeshModeMesh
+}
+
+// ShouldHoldUntilProxyReady returns true if containers should wait for the mesh proxy
+func (p *ParadeDB) ShouldHoldUntilProxyReady() bool {
+	if p.Spec.ServiceMesh == nil {
+		return false
+	}
+	if p.Spec.ServiceMesh.HoldUntilProxyReady == nil {
+		return true
+	}
+	return *p.Spec.ServiceMesh.HoldUntilProxyReady
+}
+
 // IsBackupEnabled returns true if backup is enabled
 func (p *ParadeDB) IsBackupEnabled() bool {
 	return p.Spec.Backup != nil && p.Spec.Backup.Enabled
 }
 
-// IsMonitoringEnabled returns true if monitoring is enabled
+// IsLogicalBackupEnabled returns true if logical backups are enabled
+func (p *ParadeDB) IsLogicalBackupEnabled() bool {
+	return p.Spec.Backup != nil && p.Spec.Backup.Logical != nil && p.Spec.Backup.Logical.Enabled
+}
+
+// GetLogicalBackupDatabases returns the databases included in logical backups
+func (p *ParadeDB) GetLogicalBackupDatabases() []string {
+	if p.Spec.Backup == nil || p.Spec.Backup.Logical == nil || len(p.Spec.Backup.Logical.Databases) == 0 {
+		return []string{p.Spec.Auth.Database}
+	}
+	return p.Spec.Backup.Logical.Databases
+}
+
+// GetBackupParallelism returns the number of parallel backup workers
+func (p *ParadeDB) GetBackupParallelism() int32 {
+	if p.Spec.Backup == nil || p.Spec.Backup.Parallelism < 1 {
+		return 1
+	}
+	return p.Spec.Backup.Parallelism
+}
+
+// GetLogicalBackupCronJobName returns the name of the logical backup CronJob
+func (p *ParadeDB) GetLogicalBackupCronJobName() string {
+	return p.Name + "-logical-backup"
+}
+
+// GetMaintenanceCronJobName returns the name of the table maintenance CronJob
+func (p *ParadeDB) GetMaintenanceCronJobName() string {
+	return p.Name + "-maintenance"
+}
+
+// GetAutovacuumProfile returns the autovacuum preset, "default" unless set
+func (p *ParadeDB) GetAutovacuumProfile() string {
+	if p.Spec.Maintenance == nil || p.Spec.Maintenance.AutovacuumProfile == "" {
+		return "default"
+	}
+	return p.Spec.Maintenance.AutovacuumProfile
+}
+
+// GetMaintenanceTables returns the tables maintained on a schedule
+func (p *ParadeDB) GetMaintenanceTables() []MaintenanceTableSpec {
+	if p.Spec.Maintenance == nil {
+		return nil
+	}
+	return p.Spec.Maintenance.Tables
+}
+
+// GetServiceAccountName returns the ServiceAccount the instance's pods run as
+func (p *ParadeDB) GetServiceAccountName() string {
+	if p.Spec.ServiceAccount != nil && p.Spec.ServiceAccount.Name != "" {
+		return p.Spec.ServiceAccount.Name
+	}
+	return p.Name
+}
+
+// IsServiceAccountManaged returns true if the operator creates the instance's ServiceAccount
+func (p *ParadeDB) IsServiceAccountManaged() bool {
+	return p.Spec.ServiceAccount == nil || p.Spec.ServiceAccount.Name == "" || p.Spec.ServiceAccount.Create
+}
+
+// GetAutomountServiceAccountToken returns whether the ServiceAccount token is mounted into pods
+func (p *ParadeDB) GetAutomountServiceAccountToken() bool {
+	if p.Spec.ServiceAccount == nil || p.Spec.ServiceAccount.AutomountToken == nil {
+		return false
+	}
+	return *p.Spec.ServiceAccount.AutomountToken
+}
+
+// IsS3ServiceAccountAuthEnabled returns true if backups authenticate to S3 through their ServiceAccount
+func (p *ParadeDB) IsS3ServiceAccountAuthEnabled() bool {
+	return p.Spec.Backup != nil && p.Spec.Backup.S3 != nil && p.Spec.Backup.S3.ServiceAccountAuth
+}
+
+// GetBackupServiceAccountName returns the ServiceAccount backup jobs run as
+func (p *ParadeDB) GetBackupServiceAccountName() string {
+	if p.Spec.Backup != nil && p.Spec.Backup.S3 != nil && p.Spec.Backup.S3.ServiceAccountName != "" {
+		return p.Spec.Backup.S3.ServiceAccountName
+	}
+	return p.Name + "-backup"
+}
+
+// GetBackupPVCName returns the name of the PVC backups are written to
+func (p *ParadeDB) GetBackupPVCName() string {
+	return p.Name + "-backup"
+}
+
+// GetZone returns the zone the data volume of the given replica is pinned to, or an empty
+// string if its placement is left to the storage class
+func (p *ParadeDB) GetZone(ordinal int32) string {
+	if p.Spec.HighAvailability == nil || len(p.Spec.HighAvailability.Zones) == 0 {
+		return ""
+	}
+	zones := p.Spec.HighAvailability.Zones
+	return zones[int(ordinal)%len(zones)]
+}
+
+// IsSpreadAcrossZonesEnabled returns true if replicas should be spread across nodes and zones
+func (p *ParadeDB) IsSpreadAcrossZonesEnabled() bool {
+	return p.Spec.HighAvailability != nil && p.Spec.HighAvailability.SpreadAcrossZones
+}
+
+// IsMonitoringEnabled returns true if monitoring is enabled. An unset Monitoring means
+// disabled here; the operator fills it in from its default before reconciling.
 func (p *ParadeDB) IsMonitoringEnabled() bool {
-	return p.Spec.Monitoring == nil || p.Spec.Monitoring.Enabled
+	return p.Spec.Monitoring != nil && p.Spec.Monitoring.Enabled
+}
+
+// IsMonitoringSuspended returns true if monitoring is enabled but the exporter is stopped
+func (p *ParadeDB) IsMonitoringSuspended() bool {
+	return p.IsMonitoringEnabled() && p.Spec.Monitoring != nil && p.Spec.Monitoring.Suspend
+}
+
+// HasSQLMetrics returns true if the sql_exporter container runs next to the exporter
+func (p *ParadeDB) HasSQLMetrics() bool {
+	return p.IsMonitoringEnabled() && len(p.Spec.Monitoring.SQLMetrics) > 0
+}
+
+// IsExporterDeployment returns true if the exporter runs as a separate Deployment
+func (p *ParadeDB) IsExporterDeployment() bool {
+	return p.IsMonitoringEnabled() && p.Spec.Monitoring != nil && p.Spec.Monitoring.Mode == "deployment"
+}
+
+// GetExporterDeploymentName returns the name of the standalone exporter Deployment
+func (p *ParadeDB) GetExporterDeploymentName() string {
+	return p.Name + "-exporter"
+}
+
+// GetMetricsPort returns the port of the metrics endpoint
+func (p *ParadeDB) GetMetricsPort() int32 {
+	if p.Spec.Monitoring == nil || p.Spec.Monitoring.Port == 0 {
+		return 9187
+	}
+	return p.Spec.Monitoring.Port
+}
+
+// IsServiceMonitorEnabled returns true if a Prometheus Operator ServiceMonitor is created
+func (p *ParadeDB) IsServiceMonitorEnabled() bool {
+	return p.IsMonitoringEnabled() && p.Spec.Monitoring != nil &&
+		p.Spec.Monitoring.ServiceMonitor != nil && p.Spec.Monitoring.ServiceMonitor.Enabled
+}
+
+// IsCertManagerEnabled returns true if cert-manager issues the instance's certificates
+func (p *ParadeDB) IsCertManagerEnabled() bool {
+	return p.IsTLSEnabled() && p.Spec.TLS.CertManager != nil && p.Spec.TLS.CertManager.Enabled
+}
+
+// GetMetricsTLSSecretName returns the Secret holding the certificate the exporter serves
+// metrics with, or "" if metrics are served in plaintext. With cert-manager the exporter
+// gets a certificate of its own; otherwise it reuses tls.secretRef.
+func (p *ParadeDB) GetMetricsTLSSecretName() string {
+	if !p.IsMonitoringEnabled() || !p.IsTLSEnabled() {
+		return ""
+	}
+	if p.IsCertManagerEnabled() {
+		return p.Name + "-metrics-tls"
+	}
+	if p.Spec.TLS.SecretRef != nil {
+		return p.Spec.TLS.SecretRef.Name
+	}
+	return ""
+}
+
+// UsesHugePages returns true if the resources request huge pages of any size
+func (p *ParadeDB) UsesHugePages() bool {
+	for _, resources := range []corev1.ResourceList{p.Spec.Resources.Limits, p.Spec.Resources.Requests} {
+		for name := range resources {
+			if strings.HasPrefix(string(name), corev1.ResourceHugePagesPrefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetMaxConnections returns the max_connections setting
+func (p *ParadeDB) GetMaxConnections() int32 {
+	if p.Spec.MaxConnections == nil {
+		return 100
+	}
+	return *p.Spec.MaxConnections
+}
+
+// HasSessionLimits returns true if any database or user in auth sets session limits
+func (p *ParadeDB) HasSessionLimits() bool {
+	for _, database := range p.Spec.Auth.Databases {
+		if !database.SessionLimits.IsEmpty() {
+			return true
+		}
+	}
+	for _, user := range p.Spec.Auth.Users {
+		if !user.SessionLimits.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsEmpty returns true if no limit is set
+func (l SessionLimits) IsEmpty() bool {
+	return l.ConnectionLimit == nil && l.StatementTimeout == "" && l.IdleInTransactionSessionTimeout == ""
+}
+
+// IsVolumeCheckEnabled returns true if the data volume is checked before PostgreSQL starts
+func (p *ParadeDB) IsVolumeCheckEnabled() bool {
+	return p.Spec.Storage.VolumeCheck == nil || *p.Spec.Storage.VolumeCheck
+}
+
+// IsLocalCacheEnabled returns true if temporary files go to a node-local volume
+func (p *ParadeDB) IsLocalCacheEnabled() bool {
+	return p.Spec.Storage.LocalCache != nil && p.Spec.Storage.LocalCache.Enabled
+}
+
+// IsRemediationEnabled returns true if stuck pods should be remediated automatically
+func (p *ParadeDB) IsRemediationEnabled() bool {
+	return p.Spec.Remediation == nil || p.Spec.Remediation.Enabled
+}
+
+// GetCrashLoopRestartThreshold returns the restart count at which a crash-looping pod is deleted
+func (p *ParadeDB) GetCrashLoopRestartThreshold() int32 {
+	if p.Spec.Remediation == nil || p.Spec.Remediation.CrashLoopRestartThreshold == 0 {
+		return 10
+	}
+	return p.Spec.Remediation.CrashLoopRestartThreshold
+}
+
+// GetStuckPodTimeout returns how long a pod may be stuck before it is force deleted
+func (p *ParadeDB) GetStuckPodTimeout() time.Duration {
+	if p.Spec.Remediation == nil || p.Spec.Remediation.StuckPodTimeout == nil {
+		return 5 * time.Minute
+	}
+	return p.Spec.Remediation.StuckPodTimeout.Duration
+}
+
+// GetDNSDeregistrationDelay returns how long deletion waits for external-dns to remove
+// the instance's records
+func (p *ParadeDB) GetDNSDeregistrationDelay() time.Duration {
+	if p.Spec.CleanupPolicy == nil || p.Spec.CleanupPolicy.DNSDeregistrationDelay == nil {
+		return 2 * time.Minute
+	}
+	return p.Spec.CleanupPolicy.DNSDeregistrationDelay.Duration
+}
+
+// GetBackupCleanupJobName returns the name of the Job that deletes S3 backups on deletion
+func (p *ParadeDB) GetBackupCleanupJobName() string {
+	return p.Name + "-cleanup-backups"
+}
+
+// GetVaultCleanupJobName returns the name of the Job that revokes Vault credentials on deletion
+func (p *ParadeDB) GetVaultCleanupJobName() string {
+	return p.Name + "-cleanup-vault"
+}
+
+// IsVolumePreservationEnabled returns true if no PersistentVolumeClaim is deleted with the instance
+func (p *ParadeDB) IsVolumePreservationEnabled() bool {
+	return p.Spec.Safeguards != nil && p.Spec.Safeguards.PreserveVolumes
+}
+
+// GetDisruptionBudget returns the number of rollouts allowed per period, or zero for no limit
+func (p *ParadeDB) GetDisruptionBudget() (int32, time.Duration) {
+	if p.Spec.Safeguards == nil || p.Spec.Safeguards.DisruptionBudget == nil {
+		return 0, 0
+	}
+	budget := p.Spec.Safeguards.DisruptionBudget
+	maxOperations, period := budget.MaxOperations, budget.Period.Duration
+	if maxOperations == 0 {
+		maxOperations = 1
+	}
+	if period == 0 {
+		period = 24 * time.Hour
+	}
+	return maxOperations, period
+}
+
+// GetTerminationGracePeriodSeconds returns the termination grace period for ParadeDB pods
+func (p *ParadeDB) GetTerminationGracePeriodSeconds() int64 {
+	if p.Spec.TerminationGracePeriodSeconds == nil {
+		return 60
+	}
+	return *p.Spec.TerminationGracePeriodSeconds
+}
+
+// GetReadinessQuery returns the SQL statement used by the readiness check
+func (p *ParadeDB) GetReadinessQuery() string {
+	if p.Spec.Probes == nil || p.Spec.Probes.ReadinessQuery == "" {
+		return "SELECT 1"
+	}
+	return p.Spec.Probes.ReadinessQuery
+}
+
+// GetReplicaReadinessQuery returns the SQL statement that must succeed for a replica to be ready
+func (p *ParadeDB) GetReplicaReadinessQuery() string {
+	if p.Spec.Probes == nil || p.Spec.Probes.ReplicaReadinessQuery == "" {
+		return p.GetReadinessQuery()
+	}
+	return p.Spec.Probes.ReplicaReadinessQuery
+}
+
+// GetMaxReplicationLagSeconds returns the replay lag above which a replica is not ready
+func (p *ParadeDB) GetMaxReplicationLagSeconds() int32 {
+	if p.Spec.Probes == nil || p.Spec.Probes.MaxReplicationLagSeconds == nil {
+		return 30
+	}
+	return *p.Spec.Probes.MaxReplicationLagSeconds
+}
+
+// GetPort returns the PostgreSQL port
+func (p *ParadeDB) GetPort() int32 {
+	if p.Spec.Port == 0 {
+		return 5432
+	}
+	return p.Spec.Port
+}
+
+// GetHost returns the host clients should connect to
+func (p *ParadeDB) GetHost() string {
+	if p.Spec.Expose != nil && p.Spec.Expose.Hostname != "" {
+		return p.Spec.Expose.Hostname
+	}
+	return p.GetServiceName() + "." + p.Namespace + ".svc.cluster.local"
 }
 
 // GetImage returns the ParadeDB image to use
@@ -519,6 +2962,12 @@ func (p *ParadeDB) GetImage() string {
 	return p.Spec.Image
 }
 
+// GetConfigHistoryConfigMapName returns the name of the ConfigMap holding the revisions of
+// the rendered postgresql.conf
+func (p *ParadeDB) GetConfigHistoryConfigMapName() string {
+	return p.Name + "-config-history"
+}
+
 // GetServiceName returns the service name for the ParadeDB instance
 func (p *ParadeDB) GetServiceName() string {
 	return p.Name
@@ -534,11 +2983,163 @@ func (p *ParadeDB) GetPoolerServiceName() string {
 	return p.Name + "-pooler"
 }
 
+// GetPoolerReplicas returns the number of PgBouncer pods, 1 unless set
+func (p *ParadeDB) GetPoolerReplicas() int32 {
+	if p.Spec.ConnectionPooling == nil || p.Spec.ConnectionPooling.Replicas == nil {
+		return 1
+	}
+	return *p.Spec.ConnectionPooling.Replicas
+}
+
+// GetPoolerPDBName returns the name of the pooler's PodDisruptionBudget
+func (p *ParadeDB) GetPoolerPDBName() string {
+	return p.Name + "-pooler"
+}
+
 // GetPoolerDeploymentName returns the pooler deployment name
 func (p *ParadeDB) GetPoolerDeploymentName() string {
 	return p.Name + "-pooler"
 }
 
+// GetBackupCronJobName returns the name of the backup CronJob
+func (p *ParadeDB) GetBackupCronJobName() string {
+	return p.Name + "-backup"
+}
+
+// IsStandby returns true if the instance streams or restores WAL from another primary
+func (p *ParadeDB) IsStandby() bool {
+	return p.Spec.ReplicaOf != nil && !p.Spec.ReplicaOf.Promote
+}
+
+// GetVectorIndexes returns the pgvector indexes to build
+func (p *ParadeDB) GetVectorIndexes() []VectorIndexSpec {
+	if p.Spec.Vector == nil {
+		return nil
+	}
+	return p.Spec.Vector.Indexes
+}
+
+// GetAnalyticsServers returns the pg_analytics foreign servers to manage
+func (p *ParadeDB) GetAnalyticsServers() []AnalyticsServerSpec {
+	if p.Spec.Analytics == nil {
+		return nil
+	}
+	return p.Spec.Analytics.Servers
+}
+
+// IsArchiveStandby returns true if the instance is a standby restoring from an S3 archive
+func (p *ParadeDB) IsArchiveStandby() bool {
+	return p.IsStandby() && p.Spec.ReplicaOf.S3Archive != nil
+}
+
+// IsArchiveReplica returns true if the instance is a replica cluster of an S3 archive,
+// whether promoted or not
+func (p *ParadeDB) IsArchiveReplica() bool {
+	return p.Spec.ReplicaOf != nil && p.Spec.ReplicaOf.S3Archive != nil
+}
+
+// GetExternalBootstrap returns the external import configuration, if any
+func (p *ParadeDB) GetExternalBootstrap() *ExternalBootstrapSpec {
+	if p.Spec.Bootstrap == nil {
+		return nil
+	}
+	return p.Spec.Bootstrap.FromExternal
+}
+
+// GetInstanceBootstrap returns the configuration of a clone of another instance, if any
+func (p *ParadeDB) GetInstanceBootstrap() *InstanceBootstrapSpec {
+	if p.Spec.Bootstrap == nil {
+		return nil
+	}
+	return p.Spec.Bootstrap.FromInstance
+}
+
+// GetImportDatabases returns the databases imported from an external server or cloned
+// from another instance
+func (p *ParadeDB) GetImportDatabases() []string {
+	if external := p.GetExternalBootstrap(); external != nil {
+		return external.Databases
+	}
+	if instance := p.GetInstanceBootstrap(); instance != nil {
+		return instance.Databases
+	}
+	return nil
+}
+
+// GetBootstrapSnapshot returns the name of the ParadeDBSnapshot the data volume is restored
+// from, if any
+func (p *ParadeDB) GetBootstrapSnapshot() string {
+	if p.Spec.Bootstrap == nil || p.Spec.Bootstrap.Snapshot == nil {
+		return ""
+	}
+	return p.Spec.Bootstrap.Snapshot.Name
+}
+
+// GetImportJobName returns the name of the Job that copies data from an external server
+func (p *ParadeDB) GetImportJobName() string {
+	return p.Name + "-import"
+}
+
+// GetDataImports returns the datasets loaded from object storage on first boot
+func (p *ParadeDB) GetDataImports() []DataImportSpec {
+	if p.Spec.Bootstrap == nil {
+		return nil
+	}
+	return p.Spec.Bootstrap.ImportData
+}
+
+// GetDataImportJobName returns the name of the Job that loads datasets from object storage
+func (p *ParadeDB) GetDataImportJobName() string {
+	return p.Name + "-import-data"
+}
+
+// GetRestoreSourceInstance returns the name of the instance whose logical backups are restored
+func (p *ParadeDB) GetRestoreSourceInstance() string {
+	if p.Spec.Restore == nil || p.Spec.Restore.Source.Instance == "" {
+		return p.Name
+	}
+	return p.Spec.Restore.Source.Instance
+}
+
+// HasWorkloadRoles returns true if the search or analytics workload role, or the
+// reporting user, is enabled
+func (p *ParadeDB) HasWorkloadRoles() bool {
+	roles := p.Spec.Auth.WorkloadRoles
+	return (roles != nil && ((roles.Search != nil && roles.Search.Enabled) || (roles.Analytics != nil && roles.Analytics.Enabled))) ||
+		p.IsReportingUserEnabled()
+}
+
+// IsReportingUserEnabled returns true if the read-only reporting role is enabled
+func (p *ParadeDB) IsReportingUserEnabled() bool {
+	return p.Spec.Auth.ReportingUser != nil && p.Spec.Auth.ReportingUser.Enabled
+}
+
+// GetReportingUsername returns the name of the read-only reporting role
+func (p *ParadeDB) GetReportingUsername() string {
+	if p.Spec.Auth.ReportingUser == nil || p.Spec.Auth.ReportingUser.Name == "" {
+		return "reporting"
+	}
+	return p.Spec.Auth.ReportingUser.Name
+}
+
+// IsCDCEnabled returns true if change data capture is enabled
+func (p *ParadeDB) IsCDCEnabled() bool {
+	return p.Spec.CDC != nil && p.Spec.CDC.Enabled
+}
+
+// GetCDCUsername returns the name of the replication role used for change data capture
+func (p *ParadeDB) GetCDCUsername() string {
+	if p.Spec.CDC == nil || p.Spec.CDC.Username == "" {
+		return "cdc"
+	}
+	return p.Spec.CDC.Username
+}
+
+// GetCDCSecretName returns the name of the Secret holding the replication role's credentials
+func (p *ParadeDB) GetCDCSecretName() string {
+	return p.Name + "-cdc"
+}
+
 // GetMetricsServiceName returns the metrics service name
 func (p *ParadeDB) GetMetricsServiceName() string {
 	return p.Name + "-metrics"
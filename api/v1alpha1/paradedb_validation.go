@@ -0,0 +1,131 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Built-in defaults of the memory settings, in bytes, used when spec.postgresConfig does
+// not override them
+const (
+	defaultSharedBuffersBytes = 128 << 20
+	defaultWorkMemBytes       = 4 << 20
+)
+
+// ValidateReplicas rejects more than one replica, in the spec or a schedule, unless
+// spec.replication opts in. Without replication between the pods, each initializes and
+// serves its own independent database behind the same Service.
+func (p *ParadeDB) ValidateReplicas() error {
+	if p.IsReplicationConfigured() {
+		return nil
+	}
+	if replicas := p.GetReplicas(); replicas > 1 {
+		return fmt.Errorf("spec.replicas is %d, but the operator does not manage replication between pods yet "+
+			"and each pod would run an independent database; set spec.replicas to 1, or "+
+			"spec.replication.mode to Independent to accept that", replicas)
+	}
+	for _, schedule := range p.Spec.Schedules {
+		if schedule.Replicas != nil && *schedule.Replicas > 1 {
+			return fmt.Errorf("schedule %q sets %d replicas, but the operator does not manage replication "+
+				"between pods yet; set spec.replication.mode to Independent to accept that", schedule.Name, *schedule.Replicas)
+		}
+	}
+	return nil
+}
+
+// ValidateMemorySettings checks shared_buffers and work_mem against
+// spec.resources.limits.memory. It rejects a shared_buffers that alone does not fit, which
+// would OOMKill the pod as soon as the buffers are touched, and returns a warning when
+// every connection using one work_mem on top of shared_buffers would exceed the limit.
+func (p *ParadeDB) ValidateMemorySettings() (string, error) {
+	sharedBuffers, err := p.getMemorySetting("shared_buffers", 8<<10, defaultSharedBuffersBytes)
+	if err != nil {
+		return "", err
+	}
+	workMem, err := p.getMemorySetting("work_mem", 1<<10, defaultWorkMemBytes)
+	if err != nil {
+		return "", err
+	}
+
+	limit, ok := p.Spec.Resources.Limits[corev1.ResourceMemory]
+	if !ok || limit.IsZero() {
+		return "", nil
+	}
+	limitBytes := limit.Value()
+
+	// On huge pages the buffers are charged to the hugepages limit instead
+	if p.UsesHugePages() {
+		sharedBuffers = 0
+	}
+
+	if sharedBuffers >= limitBytes {
+		return "", fmt.Errorf("shared_buffers (%dMB) does not fit within spec.resources.limits.memory (%s)",
+			sharedBuffers>>20, limit.String())
+	}
+	if worstCase := sharedBuffers + int64(p.GetMaxConnections())*workMem; worstCase > limitBytes {
+		return fmt.Sprintf("shared_buffers (%dMB) plus work_mem (%dMB) for each of %d connections exceeds "+
+			"spec.resources.limits.memory (%s); busy periods may OOMKill the pod",
+			sharedBuffers>>20, workMem>>20, p.GetMaxConnections(), limit.String()), nil
+	}
+	return "", nil
+}
+
+// getMemorySetting returns the setting from spec.postgresConfig in bytes, or the default
+func (p *ParadeDB) getMemorySetting(name string, unitBytes, defaultBytes int64) (int64, error) {
+	value, ok := p.Spec.PostgresConfig[name]
+	if !ok {
+		return defaultBytes, nil
+	}
+	bytes, err := parsePostgresMemory(value, unitBytes)
+	if err != nil {
+		return 0, fmt.Errorf("spec.postgresConfig.%s: %w", name, err)
+	}
+	return bytes, nil
+}
+
+// parsePostgresMemory parses a PostgreSQL memory setting such as 256MB or '1GB'. A value
+// without a unit is a count of unitBytes, which is 8kB for shared_buffers and 1kB for
+// work_mem.
+func parsePostgresMemory(value string, unitBytes int64) (int64, error) {
+	value = strings.Trim(strings.TrimSpace(value), "'")
+	units := []struct {
+		suffix string
+		bytes  int64
+	}{
+		{"kB", 1 << 10}, {"MB", 1 << 20}, {"GB", 1 << 30}, {"TB", 1 << 40}, {"B", 1},
+	}
+
+	multiplier := unitBytes
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.bytes
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid memory value %q", value)
+	}
+	return n * multiplier, nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParadeDBClassSpec defines the defaults applied to ParadeDB instances referencing the class.
+// Fields set on a ParadeDB always take precedence over the class.
+type ParadeDBClassSpec struct {
+	// Description of the tier, shown to tenants choosing a class
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Image is the default ParadeDB container image
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources are the default CPU and memory resources for ParadeDB pods
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// StorageClassName is the default StorageClass for data volumes
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Backup is the default backup configuration
+	// +optional
+	Backup *BackupSpec `json:"backup,omitempty"`
+
+	// Monitoring is the default monitoring configuration
+	// +optional
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="Description",type=string,JSONPath=`.spec.description`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ParadeDBClass is the Schema for the paradedbclasses API. It lets platform teams
+// define standardized tiers that ParadeDB instances select via spec.className.
+type ParadeDBClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec ParadeDBClassSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ParadeDBClassList contains a list of ParadeDBClass
+type ParadeDBClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ParadeDBClass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ParadeDBClass{}, &ParadeDBClassList{})
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParadeDBMigrationJobSpec defines a schema migration to run against a ParadeDB instance.
+// Exactly one of SQL and Image must be set.
+type ParadeDBMigrationJobSpec struct {
+	// ParadeDBRef is the ParadeDB instance in the same namespace to migrate
+	ParadeDBRef corev1.LocalObjectReference `json:"paradedbRef"`
+
+	// Database to migrate. Defaults to auth.database.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// SQL is run with psql in a single transaction, stopping at the first error
+	// +optional
+	SQL string `json:"sql,omitempty"`
+
+	// Image runs a migration tool instead of SQL. The container gets the libpq
+	// environment (PGHOST, PGUSER, PGPASSWORD, PGDATABASE, ...) pointing at the primary.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Command overrides the entrypoint of Image
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are passed to the entrypoint of Image
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env adds environment variables to the migration container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// LockKey is the PostgreSQL advisory lock held for the duration of the migration.
+	// Defaults to a key derived from the instance and database, so that the migrations
+	// of a database never overlap. Migration tools outside the operator can take the
+	// same lock, which is recorded in status.lockKey.
+	// +optional
+	LockKey *int64 `json:"lockKey,omitempty"`
+
+	// Backup takes a logical backup from the instance's backup CronJob before the
+	// migration runs. The instance must have logical backups enabled.
+	// +optional
+	Backup bool `json:"backup,omitempty"`
+
+	// MaintenanceWindow restricts when the migration starts. Once started, it runs to
+	// completion.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowSpec `json:"maintenanceWindow,omitempty"`
+}
+
+// MigrationPhase represents the progress of a migration
+type MigrationPhase string
+
+const (
+	// MigrationPhasePending means the migration is waiting for its instance, its
+	// maintenance window or another migration of the instance
+	MigrationPhasePending MigrationPhase = "Pending"
+	// MigrationPhaseBackingUp means the pre-migration backup is being taken
+	MigrationPhaseBackingUp MigrationPhase = "BackingUp"
+	// MigrationPhaseRunning means the migration Job is running
+	MigrationPhaseRunning MigrationPhase = "Running"
+	// MigrationPhaseSucceeded means the migration Job completed
+	MigrationPhaseSucceeded MigrationPhase = "Succeeded"
+	// MigrationPhaseFailed means the backup or the migration failed. It is not retried.
+	MigrationPhaseFailed MigrationPhase = "Failed"
+)
+
+// ParadeDBMigrationJobStatus defines the observed state of ParadeDBMigrationJob
+type ParadeDBMigrationJobStatus struct {
+	// Phase of the migration
+	// +optional
+	Phase MigrationPhase `json:"phase,omitempty"`
+
+	// LockKey is the advisory lock the migration holds
+	// +optional
+	LockKey *int64 `json:"lockKey,omitempty"`
+
+	// BackupJob is the Job that took the pre-migration backup
+	// +optional
+	BackupJob string `json:"backupJob,omitempty"`
+
+	// Job is the Job that runs the migration
+	// +optional
+	Job string `json:"job,omitempty"`
+
+	// StartTime is when the migration started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the migration succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message provides additional status information
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ParadeDB",type=string,JSONPath=`.spec.paradedbRef.name`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Job",type=string,JSONPath=`.status.job`,priority=1
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:resource:shortName=pdbmig
+
+// ParadeDBMigrationJob is the Schema for the paradedbmigrationjobs API. It runs a schema
+// migration once, serialized with the other migrations of the database by an advisory lock.
+type ParadeDBMigrationJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec ParadeDBMigrationJobSpec `json:"spec"`
+
+	// +optional
+	Status ParadeDBMigrationJobStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ParadeDBMigrationJobList contains a list of ParadeDBMigrationJob
+type ParadeDBMigrationJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ParadeDBMigrationJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ParadeDBMigrationJob{}, &ParadeDBMigrationJobList{})
+}
+
+// GetDatabase returns the database to migrate, auth.database of the instance by default
+func (m *ParadeDBMigrationJob) GetDatabase(paradedb *ParadeDB) string {
+	if m.Spec.Database == "" {
+		return paradedb.Spec.Auth.Database
+	}
+	return m.Spec.Database
+}
+
+// GetJobName returns the name of the Job that runs the migration
+func (m *ParadeDBMigrationJob) GetJobName() string {
+	return m.Name + "-migration"
+}
+
+// GetBackupJobName returns the name of the Job that takes the pre-migration backup
+func (m *ParadeDBMigrationJob) GetBackupJobName() string {
+	return m.Name + "-backup"
+}
+
+// IsActive returns true while the migration is backing up or running
+func (m *ParadeDBMigrationJob) IsActive() bool {
+	return m.Status.Phase == MigrationPhaseBackingUp || m.Status.Phase == MigrationPhaseRunning
+}
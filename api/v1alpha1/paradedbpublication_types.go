@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParadeDBPublicationSpec defines a logical replication publication on a ParadeDB instance.
+// Exactly one of allTables and tables must be set.
+type ParadeDBPublicationSpec struct {
+	// ParadeDBRef is the ParadeDB instance in the same namespace that publishes the tables
+	ParadeDBRef corev1.LocalObjectReference `json:"paradedbRef"`
+
+	// Name of the publication in PostgreSQL. Defaults to the resource name.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Database the publication is created in. Defaults to the instance's application database.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// AllTables publishes every table in the database, including tables created later
+	// +optional
+	AllTables bool `json:"allTables,omitempty"`
+
+	// Tables to publish, optionally schema-qualified (e.g. "public.orders")
+	// +optional
+	Tables []string `json:"tables,omitempty"`
+}
+
+// ParadeDBPublicationStatus defines the observed state of ParadeDBPublication
+type ParadeDBPublicationStatus struct {
+	// Applied is true once the publication in the database matches the spec
+	// +optional
+	Applied bool `json:"applied,omitempty"`
+
+	// Message provides additional status information
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the most recent generation applied
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ParadeDB",type=string,JSONPath=`.spec.paradedbRef.name`
+// +kubebuilder:printcolumn:name="Applied",type=boolean,JSONPath=`.status.applied`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ParadeDBPublication is the Schema for the paradedbpublications API. It manages a
+// PostgreSQL publication that other clusters can subscribe to.
+type ParadeDBPublication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec ParadeDBPublicationSpec `json:"spec"`
+
+	// +optional
+	Status ParadeDBPublicationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ParadeDBPublicationList contains a list of ParadeDBPublication
+type ParadeDBPublicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ParadeDBPublication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ParadeDBPublication{}, &ParadeDBPublicationList{})
+}
+
+// GetPublicationName returns the name of the publication in PostgreSQL
+func (p *ParadeDBPublication) GetPublicationName() string {
+	if p.Spec.Name != "" {
+		return p.Spec.Name
+	}
+	return p.Name
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParadeDBSnapshotSpec defines a VolumeSnapshot of the data volume of a ParadeDB instance
+type ParadeDBSnapshotSpec struct {
+	// ParadeDBRef is the ParadeDB instance in the same namespace to snapshot
+	ParadeDBRef corev1.LocalObjectReference `json:"paradedbRef"`
+
+	// VolumeSnapshotClassName is the VolumeSnapshotClass to use. Defaults to the default
+	// class for the volume's CSI driver.
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+
+	// Hooks run SQL on the primary around the snapshot
+	// +optional
+	Hooks *SnapshotHooksSpec `json:"hooks,omitempty"`
+}
+
+// SnapshotHooksSpec defines SQL run around a snapshot to quiesce the application. The
+// hooks share one session, which stays open until the volume snapshot has been cut, so
+// session-level locks taken before the snapshot are held until after it.
+type SnapshotHooksSpec struct {
+	// Pre statements run after a CHECKPOINT, before the snapshot is taken
+	// +optional
+	Pre []string `json:"pre,omitempty"`
+
+	// Post statements run once the snapshot has been cut, or has failed
+	// +optional
+	Post []string `json:"post,omitempty"`
+
+	// Database the hooks run in. Defaults to the instance's application database.
+	// +optional
+	Database string `json:"database,omitempty"`
+}
+
+// SnapshotPhase represents the progress of a snapshot
+type SnapshotPhase string
+
+const (
+	// SnapshotPhasePending means the snapshot has not been taken yet
+	SnapshotPhasePending SnapshotPhase = "Pending"
+	// SnapshotPhaseRunning means the volume snapshot has been cut and is being made ready
+	SnapshotPhaseRunning SnapshotPhase = "Running"
+	// SnapshotPhaseReady means the snapshot can be restored from
+	SnapshotPhaseReady SnapshotPhase = "Ready"
+	// SnapshotPhaseFailed means the volume snapshot failed and will not be retried
+	SnapshotPhaseFailed SnapshotPhase = "Failed"
+)
+
+// ParadeDBSnapshotStatus defines the observed state of ParadeDBSnapshot
+type ParadeDBSnapshotStatus struct {
+	// Phase of the snapshot
+	// +optional
+	Phase SnapshotPhase `json:"phase,omitempty"`
+
+	// VolumeSnapshotName is the VolumeSnapshot of the data volume
+	// +optional
+	VolumeSnapshotName string `json:"volumeSnapshotName,omitempty"`
+
+	// Image the instance ran when the snapshot was taken. Restores need the same
+	// PostgreSQL major version.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// CreationTime is when the volume snapshot was cut
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// RestoreSize is the minimum size of a volume restored from the snapshot
+	// +optional
+	RestoreSize *resource.Quantity `json:"restoreSize,omitempty"`
+
+	// Message provides additional status information
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ParadeDB",type=string,JSONPath=`.spec.paradedbRef.name`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Restore Size",type=string,JSONPath=`.status.restoreSize`
+// +kubebuilder:printcolumn:name="Taken",type=date,JSONPath=`.status.creationTime`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:resource:shortName=pdbsnap
+
+// ParadeDBSnapshot is the Schema for the paradedbsnapshots API. It takes a VolumeSnapshot of
+// an instance's data volume, which new instances can bootstrap from.
+type ParadeDBSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec ParadeDBSnapshotSpec `json:"spec"`
+
+	// +optional
+	Status ParadeDBSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ParadeDBSnapshotList contains a list of ParadeDBSnapshot
+type ParadeDBSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ParadeDBSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ParadeDBSnapshot{}, &ParadeDBSnapshotList{})
+}
+
+// GetCredentialsSecretName returns the Secret holding the superuser credentials of the
+// snapshotted instance, which instances restored from the snapshot need to log in
+func (s *ParadeDBSnapshot) GetCredentialsSecretName() string {
+	return s.Name + "-credentials"
+}
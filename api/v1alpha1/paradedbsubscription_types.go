@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParadeDBSubscriptionSpec defines a logical replication subscription on a ParadeDB instance
+type ParadeDBSubscriptionSpec struct {
+	// ParadeDBRef is the ParadeDB instance in the same namespace that receives the changes
+	ParadeDBRef corev1.LocalObjectReference `json:"paradedbRef"`
+
+	// Name of the subscription in PostgreSQL. Defaults to the resource name.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Database the subscription is created in. Defaults to the instance's application database.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// Publications on the source to subscribe to
+	// +kubebuilder:validation:MinItems=1
+	Publications []string `json:"publications"`
+
+	// Source is the server that publishes the changes
+	Source SubscriptionSource `json:"source"`
+
+	// Enabled controls whether changes are being applied
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// SubscriptionSource identifies the publishing server. Exactly one of paradedbRef and
+// connectionSecretRef must be set.
+type SubscriptionSource struct {
+	// ParadeDBRef is a ParadeDB instance in the same namespace to subscribe to
+	// +optional
+	ParadeDBRef *corev1.LocalObjectReference `json:"paradedbRef,omitempty"`
+
+	// Database on the source ParadeDB instance. Defaults to its application database.
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// ConnectionSecretRef selects a Secret key holding a libpq connection string for
+	// an external PostgreSQL server
+	// +optional
+	ConnectionSecretRef *corev1.SecretKeySelector `json:"connectionSecretRef,omitempty"`
+}
+
+// ParadeDBSubscriptionStatus defines the observed state of ParadeDBSubscription
+type ParadeDBSubscriptionStatus struct {
+	// Applied is true once the subscription in the database matches the spec
+	// +optional
+	Applied bool `json:"applied,omitempty"`
+
+	// Message provides additional status information
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the most recent generation applied
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ParadeDB",type=string,JSONPath=`.spec.paradedbRef.name`
+// +kubebuilder:printcolumn:name="Applied",type=boolean,JSONPath=`.status.applied`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ParadeDBSubscription is the Schema for the paradedbsubscriptions API. It manages a
+// PostgreSQL subscription that replicates tables from another cluster.
+type ParadeDBSubscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec ParadeDBSubscriptionSpec `json:"spec"`
+
+	// +optional
+	Status ParadeDBSubscriptionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ParadeDBSubscriptionList contains a list of ParadeDBSubscription
+type ParadeDBSubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ParadeDBSubscription `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ParadeDBSubscription{}, &ParadeDBSubscriptionList{})
+}
+
+// GetSubscriptionName returns the name of the subscription in PostgreSQL
+func (s *ParadeDBSubscription) GetSubscriptionName() string {
+	if s.Spec.Name != "" {
+		return s.Spec.Name
+	}
+	return s.Name
+}
+
+// IsEnabled returns true if the subscription should apply changes
+func (s *ParadeDBSubscription) IsEnabled() bool {
+	return s.Spec.Enabled == nil || *s.Spec.Enabled
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ParadeDBUpgradeSpec defines an upgrade of a ParadeDB instance to a new image
+type ParadeDBUpgradeSpec struct {
+	// ParadeDBRef is the ParadeDB instance in the same namespace to upgrade
+	ParadeDBRef corev1.LocalObjectReference `json:"paradedbRef"`
+
+	// Image is the ParadeDB image to upgrade to. It must be built for the instance's
+	// PostgreSQL major version.
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	Image string `json:"image"`
+
+	// Strategy is how the pods are moved onto the image. Staged updates the replicas
+	// first and only updates the primary once they are ready, overriding the instance's
+	// updateStrategy for the duration of the upgrade. Rolling applies the image at once
+	// and follows the instance's updateStrategy.
+	// +kubebuilder:validation:Enum=Staged;Rolling
+	// +kubebuilder:default=Staged
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// MaintenanceWindow restricts when the upgrade starts. Once started, it runs to
+	// completion.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowSpec `json:"maintenanceWindow,omitempty"`
+}
+
+// UpgradePhase represents the progress of an upgrade
+type UpgradePhase string
+
+const (
+	// UpgradePhasePending means the upgrade is waiting for its instance, its maintenance
+	// window or another upgrade of the instance
+	UpgradePhasePending UpgradePhase = "Pending"
+	// UpgradePhaseRunning means the upgrade steps are being carried out
+	UpgradePhaseRunning UpgradePhase = "Running"
+	// UpgradePhaseSucceeded means every step succeeded or was skipped
+	UpgradePhaseSucceeded UpgradePhase = "Succeeded"
+	// UpgradePhaseFailed means a step failed and the upgrade will not be retried
+	UpgradePhaseFailed UpgradePhase = "Failed"
+)
+
+// UpgradeStepName names a step of an upgrade
+type UpgradeStepName string
+
+const (
+	// UpgradeStepBackup takes a logical backup before anything changes
+	UpgradeStepBackup UpgradeStepName = "Backup"
+	// UpgradeStepReplicas moves the replicas onto the new image
+	UpgradeStepReplicas UpgradeStepName = "Replicas"
+	// UpgradeStepPrimary moves the primary onto the new image
+	UpgradeStepPrimary UpgradeStepName = "Primary"
+	// UpgradeStepExtensions updates the installed extensions to the versions in the image
+	UpgradeStepExtensions UpgradeStepName = "Extensions"
+	// UpgradeStepVerification checks that the instance serves queries on the new image
+	UpgradeStepVerification UpgradeStepName = "Verification"
+)
+
+// UpgradeStepPhase represents the progress of an upgrade step
+type UpgradeStepPhase string
+
+const (
+	// UpgradeStepPending means the step has not started
+	UpgradeStepPending UpgradeStepPhase = "Pending"
+	// UpgradeStepRunning means the step has started
+	UpgradeStepRunning UpgradeStepPhase = "Running"
+	// UpgradeStepSucceeded means the step has completed
+	UpgradeStepSucceeded UpgradeStepPhase = "Succeeded"
+	// UpgradeStepSkipped means the step did not apply to the instance
+	UpgradeStepSkipped UpgradeStepPhase = "Skipped"
+	// UpgradeStepFailed means the step failed
+	UpgradeStepFailed UpgradeStepPhase = "Failed"
+)
+
+// UpgradeStepStatus is the progress of one upgrade step
+type UpgradeStepStatus struct {
+	// Name of the step
+	Name UpgradeStepName `json:"name"`
+
+	// Phase of the step
+	Phase UpgradeStepPhase `json:"phase"`
+
+	// Message describes the outcome of the step or what it is waiting for
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when the step started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the step succeeded, was skipped or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ParadeDBUpgradeStatus defines the observed state of ParadeDBUpgrade
+type ParadeDBUpgradeStatus struct {
+	// Phase of the upgrade
+	// +optional
+	Phase UpgradePhase `json:"phase,omitempty"`
+
+	// CurrentStep is the step in progress
+	// +optional
+	CurrentStep UpgradeStepName `json:"currentStep,omitempty"`
+
+	// Steps lists every step of the upgrade in order
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Steps []UpgradeStepStatus `json:"steps,omitempty"`
+
+	// FromImage is the image the instance ran when the upgrade started
+	// +optional
+	FromImage string `json:"fromImage,omitempty"`
+
+	// PreviousUpdateStrategy is the instance's updateStrategy before a staged upgrade
+	// replaced it, restored when the primary is updated
+	// +optional
+	PreviousUpdateStrategy *appsv1.StatefulSetUpdateStrategy `json:"previousUpdateStrategy,omitempty"`
+
+	// StartTime is when the upgrade started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the upgrade succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message provides additional status information
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="ParadeDB",type=string,JSONPath=`.spec.paradedbRef.name`
+// +kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.image`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Step",type=string,JSONPath=`.status.currentStep`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:resource:shortName=pdbup
+
+// ParadeDBUpgrade is the Schema for the paradedbupgrades API. It moves an instance onto a
+// new image in recorded steps, resuming from the last completed step if interrupted.
+type ParadeDBUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +required
+	Spec ParadeDBUpgradeSpec `json:"spec"`
+
+	// +optional
+	Status ParadeDBUpgradeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ParadeDBUpgradeList contains a list of ParadeDBUpgrade
+type ParadeDBUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ParadeDBUpgrade `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ParadeDBUpgrade{}, &ParadeDBUpgradeList{})
+}
+
+// GetStrategy returns the upgrade strategy, Staged by default
+func (u *ParadeDBUpgrade) GetStrategy() string {
+	if u.Spec.Strategy == "" {
+		return "Staged"
+	}
+	return u.Spec.Strategy
+}
+
+// GetStep returns the status of the named step, or nil if it has not been recorded
+func (u *ParadeDBUpgrade) GetStep(name UpgradeStepName) *UpgradeStepStatus {
+	for i := range u.Status.Steps {
+		if u.Status.Steps[i].Name == name {
+			return &u.Status.Steps[i]
+		}
+	}
+	return nil
+}
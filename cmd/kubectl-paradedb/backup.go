@@ -0,0 +1,75 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newBackupCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "backup <name>",
+		Short: "Start an on-demand logical backup from the instance's logical backup CronJob",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, paradedb, err := o.getParadeDB(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			if !paradedb.IsLogicalBackupEnabled() {
+				return fmt.Errorf("ParadeDB %s does not have logical backups enabled; set spec.backup.logical.enabled first",
+					paradedb.Name)
+			}
+
+			cronJob := &batchv1.CronJob{}
+			err = c.Get(cmd.Context(), client.ObjectKey{Name: paradedb.GetLogicalBackupCronJobName(), Namespace: paradedb.Namespace}, cronJob)
+			if errors.IsNotFound(err) {
+				return fmt.Errorf("ParadeDB %s has no logical backup CronJob yet; wait for the operator to create it",
+					paradedb.Name)
+			} else if err != nil {
+				return err
+			}
+
+			// Mirror `kubectl create job --from=cronjob/...`
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        fmt.Sprintf("%s-manual-%d", cronJob.Name, time.Now().Unix()),
+					Namespace:   cronJob.Namespace,
+					Labels:      cronJob.Spec.JobTemplate.Labels,
+					Annotations: map[string]string{"cronjob.kubernetes.io/instantiate": "manual"},
+					OwnerReferences: []metav1.OwnerReference{
+						*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+					},
+				},
+				Spec: cronJob.Spec.JobTemplate.Spec,
+			}
+			if err := c.Create(cmd.Context(), job); err != nil {
+				return fmt.Errorf("failed to create backup job: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "job.batch/%s created\n", job.Name)
+			return nil
+		},
+	}
+}
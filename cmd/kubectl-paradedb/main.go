@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-paradedb is a kubectl plugin for inspecting and operating ParadeDB instances.
+// Install it on the PATH and run it as `kubectl paradedb <command>`.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(databasev1alpha1.AddToScheme(scheme))
+}
+
+// options holds the connection flags shared by all commands
+type options struct {
+	kubeconfig  string
+	kubeContext string
+	namespace   string
+
+	// c, if set, is used instead of a client built from the kubeconfig
+	c client.Client
+}
+
+// clientConfig returns the kubeconfig loader honoring the global flags
+func (o *options) clientConfig() clientcmd.ClientConfig {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = o.kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: o.kubeContext}
+	overrides.Context.Namespace = o.namespace
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+}
+
+// client returns a client for the current context along with the namespace to use
+func (o *options) client() (client.Client, string, error) {
+	if o.c != nil {
+		return o.c, o.namespace, nil
+	}
+	config := o.clientConfig()
+
+	namespace, _, err := config.Namespace()
+	if err != nil {
+		return nil, "", err
+	}
+
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, "", err
+	}
+	return c, namespace, nil
+}
+
+// getParadeDB fetches the named ParadeDB from the current namespace
+func (o *options) getParadeDB(ctx context.Context, name string) (client.Client, *databasev1alpha1.ParadeDB, error) {
+	c, namespace, err := o.client()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paradedb := &databasev1alpha1.ParadeDB{}
+	if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, paradedb); err != nil {
+		return nil, nil, fmt.Errorf("failed to get ParadeDB %s/%s: %w", namespace, name, err)
+	}
+	return c, paradedb, nil
+}
+
+func newRootCommand() *cobra.Command {
+	return newRootCommandWithOptions(&options{})
+}
+
+// newRootCommandWithOptions builds the command tree around the given options
+func newRootCommandWithOptions(o *options) *cobra.Command {
+
+	cmd := &cobra.Command{
+		Use:           "kubectl-paradedb",
+		Short:         "Inspect and operate ParadeDB instances",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	cmd.PersistentFlags().StringVar(&o.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file")
+	cmd.PersistentFlags().StringVar(&o.kubeContext, "context", "", "The kubeconfig context to use")
+	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "", "The namespace of the ParadeDB instance")
+
+	cmd.AddCommand(
+		newStatusCommand(o),
+		newPsqlCommand(o),
+		newBackupCommand(o),
+		newPromoteCommand(o),
+		newPreviewCommand(o),
+		newSelfTestCommand(o),
+	)
+	return cmd
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// run executes the plugin with the given arguments against a fake client
+func run(c client.Client, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := newRootCommandWithOptions(&options{c: c})
+	cmd.SetOut(out)
+	cmd.SetArgs(append([]string{"--namespace", "default"}, args...))
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+var _ = Describe("kubectl-paradedb", func() {
+	var paradedb *databasev1alpha1.ParadeDB
+
+	BeforeEach(func() {
+		paradedb = &databasev1alpha1.ParadeDB{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-paradedb", Namespace: "default"},
+			Status: databasev1alpha1.ParadeDBStatus{
+				Phase:      databasev1alpha1.ParadeDBPhaseRunning,
+				Endpoint:   "my-paradedb.default.svc.cluster.local:5432",
+				PrimaryPod: "my-paradedb-0",
+				Conditions: []metav1.Condition{{
+					Type: "Ready", Status: metav1.ConditionTrue, Reason: "AllReplicasReady", Message: "All replicas are ready",
+				}},
+			},
+		}
+	})
+
+	It("should summarize an instance and its pods", func() {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-paradedb-0", Namespace: "default", Labels: map[string]string{
+				"app.kubernetes.io/name":     "paradedb",
+				"app.kubernetes.io/instance": "my-paradedb",
+				"database.paradedb.io/role":  "primary",
+			}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+		other := pod.DeepCopy()
+		other.Name = "other-0"
+		other.Labels["app.kubernetes.io/instance"] = "other"
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(paradedb, pod, other).Build()
+
+		out, err := run(c, "status", "my-paradedb")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(ContainSubstring("Phase:"))
+		Expect(out).To(ContainSubstring("Running"))
+		Expect(out).To(MatchRegexp(`my-paradedb-0\s+primary\s+Running\s+true`))
+		Expect(out).NotTo(ContainSubstring("other-0"))
+		Expect(out).To(MatchRegexp(`Ready\s+True\s+AllReplicasReady`))
+
+		_, err = run(c, "status", "missing")
+		Expect(err).To(MatchError(ContainSubstring("failed to get ParadeDB default/missing")))
+	})
+
+	It("should start a Job from the logical backup CronJob", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(paradedb).Build()
+		_, err := run(c, "backup", "my-paradedb")
+		Expect(err).To(MatchError(ContainSubstring("does not have logical backups enabled")))
+
+		paradedb.ResourceVersion = ""
+		paradedb.Spec.Backup = &databasev1alpha1.BackupSpec{Logical: &databasev1alpha1.LogicalBackupSpec{Enabled: true}}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(paradedb).Build()
+		_, err = run(c, "backup", "my-paradedb")
+		Expect(err).To(MatchError(ContainSubstring("has no logical backup CronJob yet")))
+
+		cronJob := &batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-paradedb-logical-backup", Namespace: "default", UID: "cronjob-uid"},
+			Spec: batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app.kubernetes.io/instance": "my-paradedb"}},
+			}},
+		}
+		Expect(c.Create(context.Background(), cronJob)).To(Succeed())
+		out, err := run(c, "backup", "my-paradedb")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(HavePrefix("job.batch/my-paradedb-logical-backup-manual-"))
+
+		jobs := &batchv1.JobList{}
+		Expect(c.List(context.Background(), jobs, client.InNamespace("default"))).To(Succeed())
+		Expect(jobs.Items).To(HaveLen(1))
+		Expect(jobs.Items[0].Labels).To(HaveKeyWithValue("app.kubernetes.io/instance", "my-paradedb"))
+		Expect(jobs.Items[0].Annotations).To(HaveKeyWithValue("cronjob.kubernetes.io/instantiate", "manual"))
+		Expect(metav1.IsControlledBy(&jobs.Items[0], cronJob)).To(BeTrue())
+	})
+
+	It("should promote a replica cluster only", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(paradedb).Build()
+		_, err := run(c, "promote", "my-paradedb")
+		Expect(err).To(MatchError(ContainSubstring("is not a replica cluster")))
+		_, err = run(c, "promote", "my-paradedb", "my-paradedb-0")
+		Expect(err).To(HaveOccurred())
+
+		paradedb.ResourceVersion = ""
+		paradedb.Spec.ReplicaOf = &databasev1alpha1.ReplicaOfSpec{Host: "primary.example.com"}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(paradedb).Build()
+		out, err := run(c, "promote", "my-paradedb")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(Equal("paradedb/my-paradedb promoted\n"))
+
+		promoted := &databasev1alpha1.ParadeDB{}
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(paradedb), promoted)).To(Succeed())
+		Expect(promoted.Spec.ReplicaOf.Promote).To(BeTrue())
+
+		_, err = run(c, "promote", "my-paradedb")
+		Expect(err).To(MatchError(ContainSubstring("is already promoted")))
+	})
+})
@@ -0,0 +1,148 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+	"github.com/paradedb/paradedb-operator/internal/controller"
+)
+
+func newPreviewCommand(o *options) *cobra.Command {
+	var (
+		filename string
+		// The operator's settings that decide the spec it runs, under the same flag names
+		defaultsConfig             string
+		imageRegistryOverride      string
+		imageTagPolicy             string
+		monitoringEnabledByDefault bool
+		resolveImageDigests        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "preview (<name> | -f <file>)",
+		Short: "Print the manifests the operator would create for a ParadeDB, without applying them",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if filename == "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return cobra.NoArgs(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				c        client.Client
+				paradedb *databasev1alpha1.ParadeDB
+				err      error
+			)
+			if filename != "" {
+				c, paradedb, err = o.loadParadeDB(cmd.Context(), filename)
+			} else {
+				c, paradedb, err = o.getParadeDB(cmd.Context(), args[0])
+			}
+			if err != nil {
+				return err
+			}
+
+			reconciler := &controller.ParadeDBReconciler{
+				Client:                     c,
+				Scheme:                     scheme,
+				ResolveImageDigests:        resolveImageDigests,
+				MonitoringEnabledByDefault: monitoringEnabledByDefault,
+				ImageRegistryOverride:      imageRegistryOverride,
+				ImageTagPolicy:             imageTagPolicy,
+			}
+			if defaultsConfig != "" {
+				if reconciler.Defaults, err = controller.LoadOperatorDefaults(defaultsConfig); err != nil {
+					return fmt.Errorf("failed to load %s: %w", defaultsConfig, err)
+				}
+			}
+
+			objects, err := reconciler.RenderManifests(cmd.Context(), paradedb)
+			if err != nil {
+				return fmt.Errorf("failed to render ParadeDB %s: %w", paradedb.Name, err)
+			}
+			return printManifests(cmd.OutOrStdout(), objects)
+		},
+	}
+	cmd.Flags().StringVarP(&filename, "filename", "f", "", "ParadeDB manifest to preview instead of an existing instance")
+	cmd.Flags().StringVar(&defaultsConfig, "defaults-config", "", "The operator's --defaults-config file")
+	cmd.Flags().StringVar(&imageRegistryOverride, "image-registry-override", "", "The operator's --image-registry-override")
+	cmd.Flags().StringVar(&imageTagPolicy, "image-tag-policy", "", "The operator's --image-tag-policy")
+	cmd.Flags().BoolVar(&monitoringEnabledByDefault, "monitoring-enabled-by-default", false,
+		"The operator's --monitoring-enabled-by-default")
+	cmd.Flags().BoolVar(&resolveImageDigests, "resolve-image-digests", true, "The operator's --resolve-image-digests")
+	return cmd
+}
+
+// loadParadeDB reads a ParadeDB manifest and lets the API server fill in its defaults
+// with a dry-run request, so the preview matches what the operator would see
+func (o *options) loadParadeDB(ctx context.Context, filename string) (client.Client, *databasev1alpha1.ParadeDB, error) {
+	c, namespace, err := o.client()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	paradedb := &databasev1alpha1.ParadeDB{}
+	if err := yaml.UnmarshalStrict(data, paradedb); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	if paradedb.Namespace == "" {
+		paradedb.Namespace = namespace
+	}
+
+	desired := paradedb.DeepCopy()
+	err = c.Create(ctx, paradedb, client.DryRunAll)
+	if errors.IsAlreadyExists(err) {
+		// Preview a change to an existing instance as an update of its spec
+		if err := c.Get(ctx, client.ObjectKeyFromObject(desired), paradedb); err != nil {
+			return nil, nil, err
+		}
+		paradedb.Spec = desired.Spec
+		err = c.Update(ctx, paradedb, client.DryRunAll)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to validate ParadeDB %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+	return c, paradedb, nil
+}
+
+// printManifests writes the objects as a multi-document YAML stream
+func printManifests(w io.Writer, objects []client.Object) error {
+	for _, object := range objects {
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newPromoteCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "promote <name>",
+		Short: "Promote a replica cluster to primary for disaster-recovery failover",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, paradedb, err := o.getParadeDB(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			// A replica cluster is promoted as a whole
+			if paradedb.Spec.ReplicaOf == nil {
+				return fmt.Errorf("ParadeDB %s is not a replica cluster", paradedb.Name)
+			}
+			if paradedb.Spec.ReplicaOf.Promote {
+				return fmt.Errorf("ParadeDB %s is already promoted", paradedb.Name)
+			}
+			patch := client.MergeFrom(paradedb.DeepCopy())
+			paradedb.Spec.ReplicaOf.Promote = true
+			if err := c.Patch(cmd.Context(), paradedb, patch); err != nil {
+				return fmt.Errorf("failed to promote ParadeDB %s: %w", paradedb.Name, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "paradedb/%s promoted\n", paradedb.Name)
+			return nil
+		},
+	}
+}
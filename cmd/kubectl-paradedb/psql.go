@@ -0,0 +1,78 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newPsqlCommand(o *options) *cobra.Command {
+	var pod string
+
+	cmd := &cobra.Command{
+		Use:   "psql <name> [-- psql args...]",
+		Short: "Open a psql session in a ParadeDB pod as the managed superuser",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, paradedb, err := o.getParadeDB(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			// Read the superuser name from the managed (or user-provided) credentials secret
+			username := ""
+			if secretName := paradedb.GetSuperuserSecretName(); secretName != "" {
+				secret := &corev1.Secret{}
+				if err := c.Get(cmd.Context(), client.ObjectKey{Name: secretName, Namespace: paradedb.Namespace}, secret); err != nil {
+					return fmt.Errorf("failed to get credentials secret %s: %w", secretName, err)
+				}
+				username = string(secret.Data["username"])
+			}
+			if username == "" {
+				username = "postgres"
+			}
+
+			if pod == "" {
+				pod = paradedb.GetStatefulSetName() + "-0"
+			}
+
+			kubectlArgs := []string{"exec", "-it", "-n", paradedb.Namespace, pod, "-c", "paradedb", "--",
+				"psql", "-U", username, "-d", paradedb.Spec.Auth.Database}
+			kubectlArgs = append(kubectlArgs, args[1:]...)
+			if o.kubeconfig != "" {
+				kubectlArgs = append([]string{"--kubeconfig", o.kubeconfig}, kubectlArgs...)
+			}
+			if o.kubeContext != "" {
+				kubectlArgs = append([]string{"--context", o.kubeContext}, kubectlArgs...)
+			}
+
+			kubectl := exec.CommandContext(cmd.Context(), "kubectl", kubectlArgs...)
+			kubectl.Stdin = os.Stdin
+			kubectl.Stdout = os.Stdout
+			kubectl.Stderr = os.Stderr
+			return kubectl.Run()
+		},
+	}
+	cmd.Flags().StringVar(&pod, "pod", "", "Pod to connect to (defaults to the first pod)")
+	return cmd
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+func newSelfTestCommand(o *options) *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "selftest <name>",
+		Short: "Have the operator smoke-test an instance through its Service and pooler, and show the result",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, paradedb, err := o.getParadeDB(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			request := time.Now().UTC().Format(time.RFC3339)
+			patch := client.MergeFrom(paradedb.DeepCopy())
+			if paradedb.Annotations == nil {
+				paradedb.Annotations = map[string]string{}
+			}
+			paradedb.Annotations["database.paradedb.io/self-test"] = request
+			if err := c.Patch(cmd.Context(), paradedb, patch); err != nil {
+				return fmt.Errorf("failed to request self-test of ParadeDB %s: %w", paradedb.Name, err)
+			}
+			if timeout == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "paradedb/%s self-test requested\n", paradedb.Name)
+				return nil
+			}
+
+			err = wait.PollUntilContextTimeout(cmd.Context(), 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+				if err := c.Get(ctx, client.ObjectKeyFromObject(paradedb), paradedb); err != nil {
+					return false, err
+				}
+				return paradedb.Status.SelfTest != nil && paradedb.Status.SelfTest.Request == request, nil
+			})
+			if err != nil {
+				return fmt.Errorf("self-test of ParadeDB %s did not complete: %w", paradedb.Name, err)
+			}
+
+			printSelfTest(cmd.OutOrStdout(), paradedb.Status.SelfTest)
+			if !paradedb.Status.SelfTest.Passed {
+				return fmt.Errorf("self-test of ParadeDB %s failed", paradedb.Name)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "How long to wait for the result, or 0 to only request the self-test")
+	return cmd
+}
+
+// printSelfTest writes the result of each self-test check
+func printSelfTest(out io.Writer, selfTest *databasev1alpha1.SelfTestStatus) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHECK\tRESULT\tMESSAGE")
+	for _, check := range selfTest.Checks {
+		result := "passed"
+		if !check.Passed {
+			result = "FAILED"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name, result, check.Message)
+	}
+	_ = w.Flush()
+}
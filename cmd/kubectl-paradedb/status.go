@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+func newStatusCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <name>",
+		Short: "Show a summary of a ParadeDB instance, its pods and conditions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, paradedb, err := o.getParadeDB(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			pods := &corev1.PodList{}
+			if err := c.List(cmd.Context(), pods,
+				client.InNamespace(paradedb.Namespace),
+				client.MatchingLabels{
+					"app.kubernetes.io/name":     "paradedb",
+					"app.kubernetes.io/instance": paradedb.Name,
+				},
+			); err != nil {
+				return fmt.Errorf("failed to list pods: %w", err)
+			}
+
+			printStatus(cmd.OutOrStdout(), paradedb, pods.Items)
+			return nil
+		},
+	}
+}
+
+// printStatus writes the human-readable summary of a ParadeDB instance
+func printStatus(out io.Writer, paradedb *databasev1alpha1.ParadeDB, pods []corev1.Pod) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", paradedb.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", paradedb.Namespace)
+	fmt.Fprintf(w, "Phase:\t%s\n", paradedb.Status.Phase)
+	fmt.Fprintf(w, "Message:\t%s\n", paradedb.Status.Message)
+	fmt.Fprintf(w, "Image:\t%s\n", paradedb.Status.CurrentVersion)
+	fmt.Fprintf(w, "Replicas:\t%d/%d ready\n", paradedb.Status.ReadyReplicas, paradedb.GetReplicas())
+	fmt.Fprintf(w, "Endpoint:\t%s\n", paradedb.Status.Endpoint)
+	if paradedb.Status.PrimaryPod != "" {
+		fmt.Fprintf(w, "Primary:\t%s\n", paradedb.Status.PrimaryPod)
+	}
+	if paradedb.Status.PoolerEndpoint != "" {
+		fmt.Fprintf(w, "Pooler Endpoint:\t%s\n", paradedb.Status.PoolerEndpoint)
+	}
+	fmt.Fprintf(w, "Database Size:\t%s\n", resource.NewQuantity(paradedb.Status.DatabaseSizeBytes, resource.BinarySI))
+	fmt.Fprintf(w, "Data Volume Used:\t%d%%\n", paradedb.Status.DataVolumeUsedPercent)
+	fmt.Fprintf(w, "Connections:\t%d\n", paradedb.Status.CurrentConnections)
+	if paradedb.Status.LastBackup != nil {
+		fmt.Fprintf(w, "Last Backup:\t%s\n", paradedb.Status.LastBackup.Time.Format("2006-01-02 15:04:05 MST"))
+	}
+	if backup := paradedb.Status.LogicalBackup; backup != nil {
+		fmt.Fprintf(w, "Logical Backup:\t%s %s (%s)\n", backup.JobName, backup.Phase, resource.NewQuantity(backup.SizeBytes, resource.BinarySI))
+	}
+
+	fmt.Fprintln(w, "\nPods:")
+	fmt.Fprintln(w, "  NAME\tROLE\tPHASE\tREADY\tNODE")
+	for _, pod := range pods {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%t\t%s\n", pod.Name, pod.Labels["database.paradedb.io/role"], pod.Status.Phase,
+			isPodReady(&pod), pod.Spec.NodeName)
+	}
+
+	if len(paradedb.Status.Extensions) > 0 {
+		fmt.Fprintln(w, "\nExtensions:")
+		fmt.Fprintln(w, "  NAME\tVERSION\tDATABASE")
+		for _, extension := range paradedb.Status.Extensions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", extension.Name, extension.Version, extension.Database)
+		}
+	}
+
+	fmt.Fprintln(w, "\nConditions:")
+	fmt.Fprintln(w, "  TYPE\tSTATUS\tREASON\tMESSAGE")
+	for _, condition := range paradedb.Status.Conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+	}
+
+	_ = w.Flush()
+}
+
+// isPodReady returns true if the pod's Ready condition is true
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
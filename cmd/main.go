@@ -17,9 +17,15 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -29,6 +35,7 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -37,6 +44,7 @@ import (
 
 	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
 	"github.com/paradedb/paradedb-operator/internal/controller"
+	webhookv1alpha1 "github.com/paradedb/paradedb-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -58,9 +66,20 @@ func main() {
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
+	var leaderElectionReleaseOnCancel bool
+	var leaseDuration, renewDeadline, retryPeriod time.Duration
+	var gracefulShutdownTimeout time.Duration
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var watchNamespaces string
+	var resolveImageDigests bool
+	var monitoringEnabledByDefault bool
+	var defaultsConfig string
+	var imageRegistryOverride string
+	var imageTagPolicy string
+	var quotaConfig string
+	var adminAddr, adminCertPath, adminCertName, adminCertKey string
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -68,6 +87,17 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"How long standby managers wait before taking over leadership from a leader that stopped renewing.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"How long the leader keeps retrying to renew leadership before giving it up.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"How often managers try to acquire or renew leadership.")
+	flag.BoolVar(&leaderElectionReleaseOnCancel, "leader-elect-release-on-cancel", true,
+		"If set, the leader steps down when the manager stops, so a standby can take over without "+
+			"waiting for the lease to expire.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 30*time.Second,
+		"How long to wait for in-flight reconciliations to finish on shutdown.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
@@ -79,6 +109,32 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", os.Getenv("WATCH_NAMESPACES"),
+		"Comma-separated list of namespaces to watch. Defaults to the WATCH_NAMESPACES environment variable, "+
+			"or all namespaces if neither is set.")
+	flag.BoolVar(&resolveImageDigests, "resolve-image-digests", true,
+		"If set, image tags are resolved to digests in the registry so that all pods of an instance run the same image. "+
+			"Disable in air-gapped clusters where the operator cannot reach the registry.")
+	flag.BoolVar(&monitoringEnabledByDefault, "monitoring-enabled-by-default", false,
+		"If set, the metrics exporter runs for every ParadeDB that leaves spec.monitoring unset. "+
+			"Instances can always opt in or out with spec.monitoring.enabled.")
+	flag.StringVar(&defaultsConfig, "defaults-config", "",
+		"Path to a YAML file of fleet-wide defaults, such as images, storage class, backup and security contexts, "+
+			"applied to instances that leave them unset.")
+	flag.StringVar(&imageRegistryOverride, "image-registry-override", "",
+		"Registry, such as an air-gapped mirror, to pull the operator's built-in default images from. "+
+			"Images set in a ParadeDB, its class or the defaults config are used as given.")
+	flag.StringVar(&quotaConfig, "quota-config", "",
+		"Path to a YAML file of per-namespace limits on the number of instances, their total storage and "+
+			"their replicas. Instances over quota are held back with a QuotaExceeded condition.")
+	flag.StringVar(&imageTagPolicy, "image-tag-policy", "",
+		"How to handle database, pooler, exporter and backup containers on the latest tag: Warn reports them "+
+			"in a condition and event, Enforce refuses to reconcile the instance. Empty allows them.")
+	flag.StringVar(&adminAddr, "admin-bind-address", "0",
+		"The address the admin API binds to, such as :9444, or 0 to disable it. Requires --admin-cert-path.")
+	flag.StringVar(&adminCertPath, "admin-cert-path", "", "The directory that contains the admin API certificate.")
+	flag.StringVar(&adminCertName, "admin-cert-name", "tls.crt", "The name of the admin API certificate file.")
+	flag.StringVar(&adminCertKey, "admin-cert-key", "tls.key", "The name of the admin API key file.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -129,6 +185,11 @@ func main() {
 		TLSOpts:       tlsOpts,
 	}
 
+	if !secureMetrics && metricsAddr != "0" {
+		setupLog.Info("WARNING: the metrics endpoint is served over HTTP without authentication; " +
+			"use --metrics-secure outside of development")
+	}
+
 	if secureMetrics {
 		// FilterProvider is used to protect the metrics endpoint with authn/authz.
 		// These configurations ensure that only authorized users and service accounts
@@ -154,40 +215,144 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
+	// Restrict the cache to the watched namespaces so the operator can run with namespaced RBAC
+	cacheOptions := cache.Options{}
+	if namespaces := parseNamespaces(watchNamespaces); len(namespaces) > 0 {
+		setupLog.Info("Watching namespaces", "namespaces", namespaces)
+		cacheOptions.DefaultNamespaces = make(map[string]cache.Config, len(namespaces))
+		for _, namespace := range namespaces {
+			cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "708762fc.paradedb.io",
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		// Manager is stopped, otherwise, this setting is unsafe. The program ends
+		// immediately after the manager stops, so it is enabled by default.
+		LeaderElectionReleaseOnCancel: leaderElectionReleaseOnCancel,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	switch imageTagPolicy {
+	case "", controller.ImageTagPolicyWarn, controller.ImageTagPolicyEnforce:
+	default:
+		setupLog.Error(errors.New("must be Warn or Enforce"), "invalid --image-tag-policy", "value", imageTagPolicy)
+		os.Exit(1)
+	}
+
+	var operatorDefaults *controller.OperatorDefaults
+	if defaultsConfig != "" {
+		operatorDefaults, err = controller.LoadOperatorDefaults(defaultsConfig)
+		if err != nil {
+			setupLog.Error(err, "unable to load operator defaults", "path", defaultsConfig)
+			os.Exit(1)
+		}
+	}
+
+	var operatorQuotas *controller.OperatorQuotas
+	if quotaConfig != "" {
+		operatorQuotas, err = controller.LoadOperatorQuotas(quotaConfig)
+		if err != nil {
+			setupLog.Error(err, "unable to load namespace quotas", "path", quotaConfig)
+			os.Exit(1)
+		}
+	}
+
 	if err := (&controller.ParadeDBReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
 		Recorder: mgr.GetEventRecorderFor("paradedb-controller"), //nolint:staticcheck // TODO: migrate to GetEventRecorder
+
+		ResolveImageDigests:        resolveImageDigests,
+		MonitoringEnabledByDefault: monitoringEnabledByDefault,
+		Defaults:                   operatorDefaults,
+		ImageRegistryOverride:      imageRegistryOverride,
+		ImageTagPolicy:             imageTagPolicy,
+		Quotas:                     operatorQuotas,
+		RESTConfig:                 mgr.GetConfig(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ParadeDB")
 		os.Exit(1)
 	}
+	if err := (&controller.ParadeDBPublicationReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ParadeDBPublication")
+		os.Exit(1)
+	}
+	if err := (&controller.ParadeDBSubscriptionReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ParadeDBSubscription")
+		os.Exit(1)
+	}
+	if err := (&controller.ParadeDBSnapshotReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ParadeDBSnapshot")
+		os.Exit(1)
+	}
+	if err := (&controller.ParadeDBUpgradeReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("paradedbupgrade-controller"), //nolint:staticcheck // TODO: migrate to GetEventRecorder
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ParadeDBUpgrade")
+		os.Exit(1)
+	}
+	if err := (&controller.ParadeDBMigrationJobReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		Recorder:       mgr.GetEventRecorderFor("paradedbmigrationjob-controller"), //nolint:staticcheck // TODO: migrate to GetEventRecorder
+		ImageTagPolicy: imageTagPolicy,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ParadeDBMigrationJob")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1alpha1.SetupParadeDBWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ParadeDB")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	if adminAddr != "0" {
+		if adminCertPath == "" {
+			setupLog.Error(errors.New("--admin-cert-path is required"), "unable to set up admin API")
+			os.Exit(1)
+		}
+		if err := mgr.Add(&controller.AdminServer{
+			Client:      mgr.GetClient(),
+			BindAddress: adminAddr,
+			CertFile:    filepath.Join(adminCertPath, adminCertName),
+			KeyFile:     filepath.Join(adminCertPath, adminCertKey),
+			TLSOpts:     tlsOpts,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up admin API")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -196,6 +361,17 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("informers", cacheSyncCheck(mgr.GetCache())); err != nil {
+		setupLog.Error(err, "unable to set up informer sync check")
+		os.Exit(1)
+	}
+	// The webhook server is only started when certificates are provided for it
+	if len(webhookCertPath) > 0 {
+		if err := mgr.AddReadyzCheck("webhook", mgr.GetWebhookServer().StartedChecker()); err != nil {
+			setupLog.Error(err, "unable to set up webhook check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -203,3 +379,27 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// cacheSyncCheck reports ready once the informer caches have synced, so the operator does
+// not receive traffic while it would act on an incomplete view of the cluster
+func cacheSyncCheck(c cache.Cache) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), time.Second)
+		defer cancel()
+		if !c.WaitForCacheSync(ctx) {
+			return errors.New("informer caches have not synced")
+		}
+		return nil
+	}
+}
+
+// parseNamespaces splits a comma-separated namespace list, ignoring empty entries
+func parseNamespaces(value string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(value, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// AdminServer serves a JSON API over HTTPS to list and describe ParadeDB instances,
+// trigger backups and promote replica clusters, for portals that cannot manage the
+// resources directly. Callers authenticate with a bearer token and each request is
+// authorized with a SubjectAccessReview for the equivalent Kubernetes request, so the API
+// allows nothing the caller's RBAC does not.
+type AdminServer struct {
+	Client client.Client
+
+	// BindAddress is the address to listen on, such as :9444
+	BindAddress string
+
+	// CertFile and KeyFile hold the serving certificate, reloaded when they change
+	CertFile string
+	KeyFile  string
+
+	// TLSOpts are applied to the TLS configuration
+	TLSOpts []func(*tls.Config)
+}
+
+// adminInstance is the summary of an instance returned by the list endpoints
+type adminInstance struct {
+	Name           string                         `json:"name"`
+	Namespace      string                         `json:"namespace"`
+	Phase          databasev1alpha1.ParadeDBPhase `json:"phase,omitempty"`
+	Replicas       int32                          `json:"replicas"`
+	ReadyReplicas  int32                          `json:"readyReplicas"`
+	Image          string                         `json:"image,omitempty"`
+	Endpoint       string                         `json:"endpoint,omitempty"`
+	PoolerEndpoint string                         `json:"poolerEndpoint,omitempty"`
+	Standby        bool                           `json:"standby"`
+}
+
+// NeedLeaderElection lets every replica of the operator serve the API
+func (s *AdminServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the API until the context is cancelled
+func (s *AdminServer) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("admin-api")
+
+	watcher, err := certwatcher.New(s.CertFile, s.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the admin API certificate: %w", err)
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			log.Error(err, "Certificate watcher stopped")
+		}
+	}()
+
+	tlsConfig := &tls.Config{GetCertificate: watcher.GetCertificate, MinVersion: tls.VersionTLS12}
+	for _, opt := range s.TLSOpts {
+		opt(tlsConfig)
+	}
+	server := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           s.Handler(),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Info("Serving the admin API", "address", s.BindAddress)
+	if err := server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Handler returns the API routes
+func (s *AdminServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/paradedbs", s.listInstances)
+	mux.HandleFunc("GET /api/v1/namespaces/{namespace}/paradedbs", s.listInstances)
+	mux.HandleFunc("GET /api/v1/namespaces/{namespace}/paradedbs/{name}", s.describeInstance)
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/paradedbs/{name}/backup", s.triggerBackup)
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/paradedbs/{name}/switchover", s.switchover)
+	return mux
+}
+
+// listInstances returns a summary of the instances in a namespace, or in all of them
+func (s *AdminServer) listInstances(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	if !s.allow(w, r, authorizationv1.ResourceAttributes{
+		Namespace: namespace, Verb: "list", Group: databasev1alpha1.GroupVersion.Group, Resource: "paradedbs",
+	}) {
+		return
+	}
+
+	list := &databasev1alpha1.ParadeDBList{}
+	if err := s.Client.List(r.Context(), list, client.InNamespace(namespace)); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	instances := make([]adminInstance, 0, len(list.Items))
+	for i := range list.Items {
+		instances = append(instances, summarizeInstance(&list.Items[i]))
+	}
+	writeAdminJSON(w, http.StatusOK, instances)
+}
+
+// describeInstance returns the ParadeDB with its spec and status
+func (s *AdminServer) describeInstance(w http.ResponseWriter, r *http.Request) {
+	paradedb, ok := s.getInstance(w, r, "get")
+	if !ok {
+		return
+	}
+	writeAdminJSON(w, http.StatusOK, paradedb)
+}
+
+// triggerBackup starts a backup Job from the instance's logical backup CronJob, like the
+// kubectl plugin's backup command. Logical backups are the only kind the operator schedules,
+// so ?type= may be omitted or set to logical.
+func (s *AdminServer) triggerBackup(w http.ResponseWriter, r *http.Request) {
+	if !s.allow(w, r, authorizationv1.ResourceAttributes{
+		Namespace: r.PathValue("namespace"), Verb: "create", Group: batchv1.GroupName, Resource: "jobs",
+	}) {
+		return
+	}
+	if backupType := r.URL.Query().Get("type"); backupType != "" && backupType != "logical" {
+		writeAdminJSON(w, http.StatusBadRequest, map[string]string{"error": fmt.Sprintf(
+			"unsupported backup type %q; only logical backups can be started", backupType)})
+		return
+	}
+	paradedb, ok := s.getInstance(w, r, "get")
+	if !ok {
+		return
+	}
+	if !paradedb.IsLogicalBackupEnabled() {
+		writeAdminJSON(w, http.StatusConflict, map[string]string{"error": fmt.Sprintf(
+			"ParadeDB %s does not have logical backups enabled", paradedb.Name)})
+		return
+	}
+
+	cronJob := &batchv1.CronJob{}
+	err := s.Client.Get(r.Context(), client.ObjectKey{Name: paradedb.GetLogicalBackupCronJobName(), Namespace: paradedb.Namespace}, cronJob)
+	if apierrors.IsNotFound(err) {
+		writeAdminJSON(w, http.StatusConflict, map[string]string{"error": fmt.Sprintf(
+			"ParadeDB %s has no logical backup CronJob yet", paradedb.Name)})
+		return
+	} else if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        fmt.Sprintf("%s-manual-%d", cronJob.Name, time.Now().Unix()),
+			Namespace:   cronJob.Namespace,
+			Labels:      cronJob.Spec.JobTemplate.Labels,
+			Annotations: map[string]string{"cronjob.kubernetes.io/instantiate": "manual"},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+	if err := s.Client.Create(r.Context(), job); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeAdminJSON(w, http.StatusCreated, map[string]string{"job": job.Name})
+}
+
+// switchover promotes a replica cluster for disaster-recovery failover
+func (s *AdminServer) switchover(w http.ResponseWriter, r *http.Request) {
+	paradedb, ok := s.getInstance(w, r, "patch")
+	if !ok {
+		return
+	}
+
+	switch {
+	case paradedb.Spec.ReplicaOf == nil:
+		writeAdminJSON(w, http.StatusConflict, map[string]string{"error": fmt.Sprintf(
+			"ParadeDB %s is not a replica cluster", paradedb.Name)})
+	case paradedb.Spec.ReplicaOf.Promote:
+		writeAdminJSON(w, http.StatusConflict, map[string]string{"error": fmt.Sprintf("ParadeDB %s is already promoted", paradedb.Name)})
+	default:
+		patch := client.MergeFrom(paradedb.DeepCopy())
+		paradedb.Spec.ReplicaOf.Promote = true
+		if err := s.Client.Patch(r.Context(), paradedb, patch); err != nil {
+			writeAdminError(w, err)
+			return
+		}
+		writeAdminJSON(w, http.StatusOK, summarizeInstance(paradedb))
+	}
+}
+
+// getInstance authorizes the verb on the ParadeDB named in the path and reads it
+func (s *AdminServer) getInstance(w http.ResponseWriter, r *http.Request, verb string) (*databasev1alpha1.ParadeDB, bool) {
+	key := client.ObjectKey{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	if !s.allow(w, r, authorizationv1.ResourceAttributes{
+		Namespace: key.Namespace, Name: key.Name, Verb: verb, Group: databasev1alpha1.GroupVersion.Group, Resource: "paradedbs",
+	}) {
+		return nil, false
+	}
+
+	paradedb := &databasev1alpha1.ParadeDB{}
+	if err := s.Client.Get(r.Context(), key, paradedb); err != nil {
+		writeAdminError(w, err)
+		return nil, false
+	}
+	return paradedb, true
+}
+
+// allow authenticates the bearer token of the request with a TokenReview and checks with
+// a SubjectAccessReview that its user may make the given request. Otherwise it writes the
+// error response and returns false.
+func (s *AdminServer) allow(w http.ResponseWriter, r *http.Request, attributes authorizationv1.ResourceAttributes) bool {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		writeAdminJSON(w, http.StatusUnauthorized, map[string]string{"error": "a bearer token is required"})
+		return false
+	}
+
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := s.Client.Create(r.Context(), review); err != nil {
+		writeAdminError(w, err)
+		return false
+	}
+	if !review.Status.Authenticated {
+		writeAdminJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid bearer token"})
+		return false
+	}
+
+	user := review.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	access := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		ResourceAttributes: &attributes,
+		User:               user.Username,
+		UID:                user.UID,
+		Groups:             user.Groups,
+		Extra:              extra,
+	}}
+	if err := s.Client.Create(r.Context(), access); err != nil {
+		writeAdminError(w, err)
+		return false
+	}
+	if !access.Status.Allowed {
+		writeAdminJSON(w, http.StatusForbidden, map[string]string{"error": fmt.Sprintf("%s may not %s %s in namespace %q",
+			user.Username, attributes.Verb, attributes.Resource, attributes.Namespace)})
+		return false
+	}
+	return true
+}
+
+// summarizeInstance returns the list entry of an instance
+func summarizeInstance(paradedb *databasev1alpha1.ParadeDB) adminInstance {
+	return adminInstance{
+		Name:           paradedb.Name,
+		Namespace:      paradedb.Namespace,
+		Phase:          paradedb.Status.Phase,
+		Replicas:       paradedb.GetReplicas(),
+		ReadyReplicas:  paradedb.Status.ReadyReplicas,
+		Image:          paradedb.Status.CurrentVersion,
+		Endpoint:       paradedb.Status.Endpoint,
+		PoolerEndpoint: paradedb.Status.PoolerEndpoint,
+		Standby:        paradedb.IsStandby(),
+	}
+}
+
+// writeAdminError writes a Kubernetes API error with its HTTP status code
+func writeAdminError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code != 0 {
+		code = int(status.Status().Code)
+	}
+	writeAdminJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+// writeAdminJSON writes a JSON response
+func writeAdminJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// adoptAnnotation on an existing StatefulSet or Service names the ParadeDB that may take
+// ownership of it, e.g. when migrating an instance installed by Helm onto the operator
+const adoptAnnotation = "database.paradedb.io/adopt"
+
+// claimObject makes sure an existing object is controlled by the ParadeDB before it is
+// updated. Unowned objects are adopted if they carry the adopt annotation naming the
+// ParadeDB; the caller's update persists the new owner reference.
+func (r *ParadeDBReconciler) claimObject(paradedb *databasev1alpha1.ParadeDB, kind string, object client.Object) error {
+	if metav1.IsControlledBy(object, paradedb) {
+		return nil
+	}
+	if owner := metav1.GetControllerOf(object); owner != nil {
+		return fmt.Errorf("%s %s is controlled by %s %s", kind, object.GetName(), owner.Kind, owner.Name)
+	}
+	if object.GetAnnotations()[adoptAnnotation] != paradedb.Name {
+		return fmt.Errorf("%s %s already exists and is not managed by the operator; annotate it with %s=%s to adopt it",
+			kind, object.GetName(), adoptAnnotation, paradedb.Name)
+	}
+
+	if err := controllerutil.SetControllerReference(paradedb, object, r.Scheme); err != nil {
+		return err
+	}
+	r.Recorder.Event(paradedb, corev1.EventTypeNormal, "Adopted", fmt.Sprintf("Adopted existing %s %s", kind, object.GetName()))
+	return nil
+}
+
+// claimStatefulSet adopts an existing StatefulSet, which must keep its data on a volume
+// claim template named "data" for the existing volumes to be reused
+func (r *ParadeDBReconciler) claimStatefulSet(paradedb *databasev1alpha1.ParadeDB, statefulSet *appsv1.StatefulSet) error {
+	if !metav1.IsControlledBy(statefulSet, paradedb) && !slices.ContainsFunc(statefulSet.Spec.VolumeClaimTemplates,
+		func(pvc corev1.PersistentVolumeClaim) bool { return pvc.Name == "data" }) {
+		return fmt.Errorf("StatefulSet %s cannot be adopted: it has no volume claim template named \"data\"", statefulSet.Name)
+	}
+	return r.claimObject(paradedb, "StatefulSet", statefulSet)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/lib/pq"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// ConditionTypeAnalyticsReady reports whether the pg_analytics foreign servers and their
+// user mappings match the spec and credentials
+const ConditionTypeAnalyticsReady = "AnalyticsReady"
+
+// setAnalyticsReadyCondition brings the analytics servers in line with the spec and their
+// credentials Secrets and reports the outcome
+func (r *ParadeDBReconciler) setAnalyticsReadyCondition(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+		setCondition(paradedb, ConditionTypeAnalyticsReady, metav1.ConditionUnknown, "DatabaseUnreachable",
+			"Waiting for the database to accept connections")
+		return
+	}
+
+	if err := r.reconcileAnalyticsServers(ctx, paradedb); err != nil {
+		setCondition(paradedb, ConditionTypeAnalyticsReady, metav1.ConditionFalse, "ProvisioningFailed",
+			fmt.Sprintf("Failed to provision analytics servers: %v", err))
+		return
+	}
+	setCondition(paradedb, ConditionTypeAnalyticsReady, metav1.ConditionTrue, "Provisioned",
+		fmt.Sprintf("%d analytics servers are provisioned", len(paradedb.GetAnalyticsServers())))
+}
+
+// reconcileAnalyticsServers creates the foreign data wrappers and servers and recreates
+// user mappings whose options no longer match the credentials
+func (r *ParadeDBReconciler) reconcileAnalyticsServers(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	if !paradedb.Spec.Extensions.PgAnalytics {
+		return fmt.Errorf("spec.analytics requires spec.extensions.pgAnalytics")
+	}
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	for _, server := range paradedb.GetAnalyticsServers() {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: server.CredentialsSecretRef.Name, Namespace: paradedb.Namespace}, secret); err != nil {
+			return fmt.Errorf("server %s: failed to read credentials: %w", server.Name, err)
+		}
+		options := buildUserMappingOptions(server, secret)
+
+		database := server.Database
+		if database == "" {
+			database = paradedb.Spec.Auth.Database
+		}
+		var updated bool
+		err := withDatabase(ctx, buildDatabaseURL(paradedb, username, password, database), func(ctx context.Context, db *sql.DB) error {
+			updated, err = applyAnalyticsServer(ctx, db, server, options)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("server %s: %w", server.Name, err)
+		}
+		if updated {
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "AnalyticsUserMappingUpdated",
+				fmt.Sprintf("Updated the user mapping of analytics server %s from Secret %s", server.Name, secret.Name))
+		}
+	}
+	return nil
+}
+
+// buildUserMappingOptions returns the user mapping options for the server's object store
+// and credentials. The credentials take precedence over options of the same name.
+func buildUserMappingOptions(server databasev1alpha1.AnalyticsServerSpec, secret *corev1.Secret) map[string]string {
+	options := maps.Clone(server.Options)
+	if options == nil {
+		options = map[string]string{}
+	}
+	options["type"] = server.Type
+	if options["type"] == "" {
+		options["type"] = "S3"
+	}
+	options["key_id"] = string(secret.Data["accessKeyId"])
+	options["secret"] = string(secret.Data["secretAccessKey"])
+	if token, ok := secret.Data["sessionToken"]; ok {
+		options["session_token"] = string(token)
+	}
+	return options
+}
+
+// applyAnalyticsServer creates the server and its wrapper if missing and recreates the user
+// mapping if its options differ. It returns true if an existing user mapping was replaced.
+func applyAnalyticsServer(ctx context.Context, db *sql.DB, server databasev1alpha1.AnalyticsServerSpec,
+	options map[string]string) (bool, error) {
+	wrapper := server.Wrapper
+	if wrapper == "" {
+		wrapper = "parquet"
+	}
+	user := server.User
+	if user == "" {
+		user = "public"
+	}
+	mappingUser := "PUBLIC"
+	if user != "public" {
+		mappingUser = pq.QuoteIdentifier(user)
+	}
+
+	if _, err := db.ExecContext(ctx, "CREATE EXTENSION IF NOT EXISTS pg_analytics"); err != nil {
+		return false, err
+	}
+	var exists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT FROM pg_foreign_data_wrapper WHERE fdwname = $1)",
+		wrapper+"_wrapper").Scan(&exists); err != nil {
+		return false, err
+	}
+	if !exists {
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE FOREIGN DATA WRAPPER %s HANDLER %s VALIDATOR %s",
+			pq.QuoteIdentifier(wrapper+"_wrapper"), pq.QuoteIdentifier(wrapper+"_fdw_handler"),
+			pq.QuoteIdentifier(wrapper+"_fdw_validator"))); err != nil {
+			return false, err
+		}
+	}
+	if _, err := db.ExecContext(ctx, fmt.Sprintf("CREATE SERVER IF NOT EXISTS %s FOREIGN DATA WRAPPER %s",
+		pq.QuoteIdentifier(server.Name), pq.QuoteIdentifier(wrapper+"_wrapper"))); err != nil {
+		return false, err
+	}
+
+	var current pq.StringArray
+	err := db.QueryRowContext(ctx, "SELECT umoptions FROM pg_user_mappings WHERE srvname = $1 AND usename = $2",
+		server.Name, user).Scan(&current)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	found := err == nil
+
+	desired := make([]string, 0, len(options))
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		desired = append(desired, key+"="+options[key])
+	}
+	slices.Sort(current)
+	if found && slices.Equal(current, desired) {
+		return false, nil
+	}
+
+	// Replace the mapping in one transaction so queries never see it missing
+	quoted := make([]string, 0, len(options))
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		quoted = append(quoted, pq.QuoteIdentifier(key)+" "+pq.QuoteLiteral(options[key]))
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP USER MAPPING IF EXISTS FOR %s SERVER %s",
+		mappingUser, pq.QuoteIdentifier(server.Name))); err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE USER MAPPING FOR %s SERVER %s OPTIONS (%s)",
+		mappingUser, pq.QuoteIdentifier(server.Name), strings.Join(quoted, ", "))); err != nil {
+		return false, err
+	}
+	return found, tx.Commit()
+}
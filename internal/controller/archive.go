@@ -0,0 +1,178 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// walArchiveMountPath is where a standby restoring from an S3 archive finds the WAL
+	// segments fetched for it
+	walArchiveMountPath = "/var/lib/postgresql/wal-archive"
+
+	// baseBackupPath is where the base backup is downloaded to on the data volume
+	baseBackupPath = "/var/lib/postgresql/data/base.tar.gz"
+
+	// walFetchIntervalSeconds is how often the archive is checked for new WAL segments
+	walFetchIntervalSeconds = 10
+)
+
+// validateReplicaOf rejects a replicaOf that sets neither or both of host and s3Archive,
+// or more than one replica. Promotion runs pg_promote on every pod, which would turn each
+// replica into a primary of its own.
+func validateReplicaOf(paradedb *databasev1alpha1.ParadeDB) error {
+	replicaOf := paradedb.Spec.ReplicaOf
+	if replicaOf == nil {
+		return nil
+	}
+	if (replicaOf.Host == "") == (replicaOf.S3Archive == nil) {
+		return fmt.Errorf("spec.replicaOf must set exactly one of host and s3Archive")
+	}
+	if replicaOf.Host != "" && replicaOf.CredentialsSecretRef.Name == "" {
+		return fmt.Errorf("spec.replicaOf.credentialsSecretRef is required with host")
+	}
+	if replicas := paradedb.GetReplicas(); replicas > 1 {
+		return fmt.Errorf("spec.replicaOf supports a single replica, but spec.replicas is %d", replicas)
+	}
+	for _, schedule := range paradedb.Spec.Schedules {
+		if schedule.Replicas != nil && *schedule.Replicas > 1 {
+			return fmt.Errorf("spec.replicaOf supports a single replica, but schedule %q sets %d", schedule.Name, *schedule.Replicas)
+		}
+	}
+	return nil
+}
+
+// getArchiveURL returns the s3:// URL of the archive's base backup and WAL directories
+func getArchiveURL(archive *databasev1alpha1.S3ArchiveSpec) string {
+	return strings.TrimSuffix(fmt.Sprintf("s3://%s/%s", archive.Bucket, strings.Trim(archive.Path, "/")), "/")
+}
+
+// buildArchiveEnv returns the AWS CLI environment for the archive
+func buildArchiveEnv(archive *databasev1alpha1.S3ArchiveSpec) []corev1.EnvVar {
+	secretName := ""
+	if archive.SecretRef != nil {
+		secretName = archive.SecretRef.Name
+	}
+	return append(buildAWSEnv(archive.Region, secretName),
+		corev1.EnvVar{Name: "S3_ENDPOINT", Value: archive.Endpoint},
+		corev1.EnvVar{Name: "ARCHIVE_URL", Value: getArchiveURL(archive)},
+	)
+}
+
+// buildBaseBackupDownloadContainer returns the init container that downloads the base
+// backup onto the data volume while the data directory is still empty
+func (r *ParadeDBReconciler) buildBaseBackupDownloadContainer(paradedb *databasev1alpha1.ParadeDB) corev1.Container {
+	script := `set -eu
+if [ ! -s "$PGDATA/PG_VERSION" ] && [ ! -s "$BASE_BACKUP" ]; then
+  aws s3 cp --only-show-errors --endpoint-url "$S3_ENDPOINT" "$ARCHIVE_URL/base/base.tar.gz" "$BASE_BACKUP.partial"
+  mv "$BASE_BACKUP.partial" "$BASE_BACKUP"
+fi`
+
+	return corev1.Container{
+		Name:    "base-backup-download",
+		Image:   r.getBackupImage(),
+		Command: []string{"/bin/sh", "-c", script},
+		Env: append(buildArchiveEnv(paradedb.Spec.ReplicaOf.S3Archive),
+			corev1.EnvVar{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+			corev1.EnvVar{Name: "BASE_BACKUP", Value: baseBackupPath},
+		),
+		VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/postgresql/data"}},
+	}
+}
+
+// buildArchiveBootstrapContainer returns the init container that extracts the downloaded
+// base backup into an empty data directory as a standby. Promotion happens on the running
+// pod, see promoteReplicaCluster.
+func buildArchiveBootstrapContainer(paradedb *databasev1alpha1.ParadeDB) corev1.Container {
+	script := `set -eu
+if [ ! -s "$PGDATA/PG_VERSION" ]; then
+  mkdir -p "$PGDATA"
+  tar -xzf "$BASE_BACKUP" -C "$PGDATA"
+  touch "$PGDATA/standby.signal"
+fi
+rm -f "$BASE_BACKUP"
+if [ "$(id -u)" = "0" ]; then
+  chown -R postgres:postgres "$PGDATA"
+fi
+chmod 0700 "$PGDATA"`
+
+	return corev1.Container{
+		Name:            "replica-bootstrap",
+		Image:           paradedb.GetImage(),
+		ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", script},
+		Env: []corev1.EnvVar{
+			{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+			{Name: "BASE_BACKUP", Value: baseBackupPath},
+		},
+		VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/postgresql/data"}},
+		Resources:    paradedb.Spec.Resources,
+	}
+}
+
+// buildWALFetchContainer returns the sidecar that copies new WAL segments from the archive
+// for restore_command. Segments before the last restartpoint, which archive_cleanup_command
+// has removed, are not fetched again. Once promotion has removed standby.signal, it drops
+// the fetched segments and stops fetching.
+func (r *ParadeDBReconciler) buildWALFetchContainer(paradedb *databasev1alpha1.ParadeDB) corev1.Container {
+	script := fmt.Sprintf(`set -u
+while true; do
+  if [ ! -e "$PGDATA/standby.signal" ]; then
+    rm -f "$WAL_ARCHIVE"/0*
+    sleep %[1]d
+    continue
+  fi
+  start=$(cat "$WAL_ARCHIVE/.restartpoint" 2>/dev/null || true)
+  aws s3 ls --endpoint-url "$S3_ENDPOINT" "$ARCHIVE_URL/wal/" | awk '{print $4}' | while read -r name; do
+    if [ -n "$name" ] && [ ! -e "$WAL_ARCHIVE/$name" ] && [[ ! "$name" < "$start" ]]; then
+      aws s3 cp --only-show-errors --endpoint-url "$S3_ENDPOINT" "$ARCHIVE_URL/wal/$name" "$WAL_ARCHIVE/.$name.partial" &&
+        mv "$WAL_ARCHIVE/.$name.partial" "$WAL_ARCHIVE/$name"
+    fi
+  done
+  sleep %[1]d
+done`, walFetchIntervalSeconds)
+
+	return corev1.Container{
+		Name:    "wal-fetch",
+		Image:   r.getBackupImage(),
+		Command: []string{"/bin/bash", "-c", script},
+		Env: append(buildArchiveEnv(paradedb.Spec.ReplicaOf.S3Archive),
+			corev1.EnvVar{Name: "WAL_ARCHIVE", Value: walArchiveMountPath},
+			corev1.EnvVar{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+		),
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "wal-archive", MountPath: walArchiveMountPath},
+			{Name: "data", MountPath: "/var/lib/postgresql/data", ReadOnly: true},
+		},
+	}
+}
+
+// buildArchiveRestoreArgs returns the postgres arguments that replay WAL fetched by the
+// wal-fetch sidecar and remove segments that are no longer needed
+func buildArchiveRestoreArgs() []string {
+	return []string{
+		"-c", fmt.Sprintf("restore_command=cp %s/%%f %%p", walArchiveMountPath),
+		"-c", fmt.Sprintf("archive_cleanup_command=pg_archivecleanup %s %%r && echo %%r > %s/.restartpoint",
+			walArchiveMountPath, walArchiveMountPath),
+	}
+}
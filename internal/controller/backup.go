@@ -0,0 +1,541 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// awsCLIImage uploads backups to S3-compatible storage
+	awsCLIImage = "amazon/aws-cli:2.27.0"
+
+	// backupMountPath is where backup Jobs write their files
+	backupMountPath = "/backup"
+)
+
+// reconcileLogicalBackup creates or updates the CronJob that dumps each database with pg_dump
+func (r *ParadeDBReconciler) reconcileLogicalBackup(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	if paradedb.Spec.Backup.PVC != nil {
+		if err := r.reconcileBackupPVC(ctx, paradedb); err != nil {
+			return err
+		}
+	}
+	if paradedb.IsS3ServiceAccountAuthEnabled() && paradedb.Spec.Backup.S3.ServiceAccountName == "" {
+		if err := r.reconcileBackupServiceAccount(ctx, paradedb); err != nil {
+			return err
+		}
+	}
+
+	desired, err := r.buildLogicalBackupCronJob(paradedb)
+	if err != nil {
+		return err
+	}
+
+	cronJob := &batchv1.CronJob{}
+	err = r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: paradedb.Namespace}, cronJob)
+	if err != nil && apierrors.IsNotFound(err) {
+		log.Info("Creating logical backup CronJob", "name", desired.Name)
+
+		if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
+			return err
+		}
+
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "CronJobCreated", "Logical backup CronJob created")
+	} else if err != nil {
+		return err
+	} else {
+		cronJob.Spec = desired.Spec
+		if err := r.Update(ctx, cronJob); err != nil {
+			return err
+		}
+	}
+
+	return r.updateLogicalBackupStatus(ctx, paradedb)
+}
+
+// updateLogicalBackupStatus reports the progress of the most recent logical backup Job
+// and emits an Event when it moves to a new phase
+func (r *ParadeDBReconciler) updateLogicalBackupStatus(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	jobs := &batchv1.JobList{}
+	if err := r.List(ctx, jobs, client.InNamespace(paradedb.Namespace), client.MatchingLabels(r.getLabels(paradedb))); err != nil {
+		return err
+	}
+	var job *batchv1.Job
+	for i := range jobs.Items {
+		owner := metav1.GetControllerOf(&jobs.Items[i])
+		if owner == nil || owner.Kind != "CronJob" || owner.Name != paradedb.GetLogicalBackupCronJobName() {
+			continue
+		}
+		if job == nil || jobs.Items[i].CreationTimestamp.After(job.CreationTimestamp.Time) {
+			job = &jobs.Items[i]
+		}
+	}
+	if job == nil {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(paradedb.Namespace), client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return err
+	}
+
+	status := &databasev1alpha1.BackupStatus{
+		JobName:        job.Name,
+		Phase:          databasev1alpha1.BackupPhaseDumping,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: job.Status.CompletionTime,
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobFailed:
+			status.Phase = databasev1alpha1.BackupPhaseFailed
+			status.Message = condition.Message
+		case batchv1.JobComplete:
+			status.Phase = databasev1alpha1.BackupPhaseCompleted
+		}
+	}
+	for i := range pods.Items {
+		dump, uploading := getLogicalBackupPodProgress(&pods.Items[i])
+		if dump != nil && dump.ExitCode == 0 {
+			// The dump container reports the size of what it wrote as its termination message
+			if size, err := strconv.ParseInt(strings.TrimSpace(dump.Message), 10, 64); err == nil {
+				status.SizeBytes = size
+			}
+		}
+		if uploading && status.Phase == databasev1alpha1.BackupPhaseDumping {
+			status.Phase = databasev1alpha1.BackupPhaseUploading
+		}
+	}
+
+	previous := paradedb.Status.LogicalBackup
+	if previous == nil || previous.JobName != status.JobName || previous.Phase != status.Phase {
+		switch status.Phase {
+		case databasev1alpha1.BackupPhaseCompleted:
+			message := fmt.Sprintf("Logical backup %s completed (%d bytes)", job.Name, status.SizeBytes)
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "BackupCompleted", message)
+			recordOperation(paradedb, databasev1alpha1.OperationBackup, databasev1alpha1.OperationSucceeded, message)
+		case databasev1alpha1.BackupPhaseFailed:
+			message := fmt.Sprintf("Logical backup %s failed: %s", job.Name, status.Message)
+			r.Recorder.Event(paradedb, corev1.EventTypeWarning, "BackupFailed", message)
+			recordOperation(paradedb, databasev1alpha1.OperationBackup, databasev1alpha1.OperationFailed, message)
+		case databasev1alpha1.BackupPhaseUploading:
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "BackupUploading",
+				fmt.Sprintf("Logical backup %s dumped %d bytes, uploading to S3", job.Name, status.SizeBytes))
+		default:
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "BackupStarted", fmt.Sprintf("Logical backup %s started", job.Name))
+		}
+	}
+	paradedb.Status.LogicalBackup = status
+
+	switch status.Phase {
+	case databasev1alpha1.BackupPhaseFailed:
+		setCondition(paradedb, ConditionTypeBackupFailed, metav1.ConditionTrue, "JobFailed",
+			fmt.Sprintf("Logical backup %s failed: %s", job.Name, status.Message))
+	case databasev1alpha1.BackupPhaseCompleted:
+		setCondition(paradedb, ConditionTypeBackupFailed, metav1.ConditionFalse, "JobCompleted",
+			fmt.Sprintf("Logical backup %s completed", job.Name))
+		if status.CompletionTime != nil {
+			paradedb.Status.LastBackup = status.CompletionTime
+			paradedb.Status.LastBackupSize = resource.NewQuantity(status.SizeBytes, resource.BinarySI).String()
+		}
+	}
+	return nil
+}
+
+// getLogicalBackupPodProgress returns the terminated state of the pod's pg-dump container,
+// if it has finished, and whether its upload container is running
+func getLogicalBackupPodProgress(pod *corev1.Pod) (*corev1.ContainerStateTerminated, bool) {
+	var dump *corev1.ContainerStateTerminated
+	uploading := false
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		switch status.Name {
+		case "pg-dump":
+			dump = status.State.Terminated
+		case "upload":
+			uploading = status.State.Running != nil
+		}
+	}
+	return dump, uploading
+}
+
+// reconcileBackupPVC creates the PVC backups are written to. It is owned by the ParadeDB
+// unless safeguards preserve volumes, in which case an existing owner reference is removed.
+func (r *ParadeDBReconciler) reconcileBackupPVC(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetBackupPVCName(), Namespace: paradedb.Namespace}, pvc)
+	if err == nil {
+		if !paradedb.IsVolumePreservationEnabled() || !metav1.IsControlledBy(pvc, paradedb) {
+			return nil
+		}
+		if err := controllerutil.RemoveOwnerReference(paradedb, pvc, r.Scheme); err != nil {
+			return err
+		}
+		logf.FromContext(ctx).Info("Released backup PVC from the ParadeDB", "name", pvc.Name)
+		return r.Update(ctx, pvc)
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	pvc = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      paradedb.GetBackupPVCName(),
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: paradedb.Spec.Backup.PVC.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: paradedb.Spec.Backup.PVC.Size,
+				},
+			},
+		},
+	}
+
+	if !paradedb.IsVolumePreservationEnabled() {
+		if err := controllerutil.SetControllerReference(paradedb, pvc, r.Scheme); err != nil {
+			return err
+		}
+	}
+	return r.Create(ctx, pvc)
+}
+
+// reconcileBackupServiceAccount creates or updates the ServiceAccount backup jobs use to
+// assume a cloud IAM role
+func (r *ParadeDBReconciler) reconcileBackupServiceAccount(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	return r.reconcileManagedServiceAccount(ctx, paradedb, paradedb.GetBackupServiceAccountName(),
+		paradedb.Spec.Backup.S3.ServiceAccountAnnotations)
+}
+
+// buildLogicalBackupCronJob creates the logical backup CronJob spec. Dumps are written to
+// the backup PVC directly, or to a scratch volume that is then uploaded to S3.
+func (r *ParadeDBReconciler) buildLogicalBackupCronJob(paradedb *databasev1alpha1.ParadeDB) (*batchv1.CronJob, error) {
+	backup := paradedb.Spec.Backup
+	if backup.S3 == nil && backup.PVC == nil {
+		return nil, errors.New("logical backups need spec.backup.s3 or spec.backup.pvc")
+	}
+	if backup.S3 != nil && !backup.S3.ServiceAccountAuth && backup.S3.SecretRef.Name == "" {
+		return nil, errors.New("spec.backup.s3 needs a secretRef or serviceAccountAuth")
+	}
+	if err := validateBackupCompression(backup.Compression); err != nil {
+		return nil, err
+	}
+
+	schedule := backup.Logical.Schedule
+	if schedule == "" {
+		schedule = "0 3 * * *"
+	}
+	concurrencyPolicy := backup.ConcurrencyPolicy
+	if concurrencyPolicy == "" {
+		concurrencyPolicy = batchv1.ForbidConcurrent
+	}
+
+	dump := corev1.Container{
+		Name:            "pg-dump",
+		Image:           paradedb.GetImage(),
+		ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+		Command:         []string{"bash", "-c", buildLogicalBackupScript(paradedb)},
+		Env:             buildClientEnv(paradedb),
+		VolumeMounts:    []corev1.VolumeMount{{Name: "backup", MountPath: backupMountPath}},
+	}
+	if backup.Resources != nil {
+		dump.Resources = *backup.Resources
+	}
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy:    corev1.RestartPolicyOnFailure,
+		ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+		NodeSelector:     backup.NodeSelector,
+		Tolerations:      backup.Tolerations,
+		Affinity:         withArchitectureAffinity(paradedb, nil),
+	}
+	applyServiceAccount(paradedb, &podSpec)
+	applyDNS(paradedb, &podSpec)
+	if paradedb.IsS3ServiceAccountAuthEnabled() {
+		podSpec.ServiceAccountName = paradedb.GetBackupServiceAccountName()
+	}
+	if backup.PVC != nil {
+		podSpec.Containers = []corev1.Container{dump}
+		podSpec.Volumes = []corev1.Volume{{
+			Name: "backup",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: paradedb.GetBackupPVCName()},
+			},
+		}}
+	} else {
+		podSpec.InitContainers = []corev1.Container{dump}
+		upload := r.buildS3UploadContainer(paradedb, "logical")
+		upload.Resources = dump.Resources
+		podSpec.Containers = []corev1.Container{upload}
+		podSpec.Volumes = []corev1.Volume{{
+			Name:         "backup",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}}
+	}
+
+	suspend := backup.Suspend
+	var timeZone *string
+	if backup.Timezone != "" {
+		timeZone = &backup.Timezone
+	}
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      paradedb.GetLogicalBackupCronJobName(),
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   schedule,
+			TimeZone:                   timeZone,
+			ConcurrencyPolicy:          concurrencyPolicy,
+			SuccessfulJobsHistoryLimit: backup.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     backup.FailedJobsHistoryLimit,
+			Suspend:                    &suspend,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: r.getLabels(paradedb)},
+				Spec: batchv1.JobSpec{
+					ActiveDeadlineSeconds: backup.ActiveDeadlineSeconds,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels:      map[string]string{"app.kubernetes.io/component": "logical-backup"},
+							Annotations: buildJobServiceMeshAnnotations(paradedb),
+						},
+						Spec: podSpec,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// buildAWSEnv returns the AWS CLI environment for the region and, unless secretName is
+// empty, the static access keys in that Secret
+func buildAWSEnv(region, secretName string) []corev1.EnvVar {
+	env := []corev1.EnvVar{{Name: "AWS_DEFAULT_REGION", Value: region}}
+	if secretName == "" {
+		// The AWS CLI picks up credentials injected for the pod's ServiceAccount
+		return env
+	}
+	return append(env,
+		corev1.EnvVar{
+			Name: "AWS_ACCESS_KEY_ID",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  "accessKeyId",
+				},
+			},
+		},
+		corev1.EnvVar{
+			Name: "AWS_SECRET_ACCESS_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  "secretAccessKey",
+				},
+			},
+		},
+	)
+}
+
+// getBackupPrefix returns the s3:// URL under which the backups of the named instance in the
+// ParadeDB's namespace are kept. The namespace keeps same-named instances in different
+// namespaces apart when they share a bucket and path.
+func getBackupPrefix(paradedb *databasev1alpha1.ParadeDB, instance string) string {
+	s3 := paradedb.Spec.Backup.S3
+	return fmt.Sprintf("s3://%s/%s", s3.Bucket, strings.Trim(strings.Join([]string{s3.Path, paradedb.Namespace, instance}, "/"), "/"))
+}
+
+// buildS3UploadContainer returns a container that copies the backup directory of the given
+// kind to the configured bucket
+func (r *ParadeDBReconciler) buildS3UploadContainer(paradedb *databasev1alpha1.ParadeDB, kind string) corev1.Container {
+	s3 := paradedb.Spec.Backup.S3
+	destination := getBackupPrefix(paradedb, paradedb.Name) + "/" + kind
+
+	secretName := s3.SecretRef.Name
+	if s3.ServiceAccountAuth {
+		secretName = ""
+	}
+	env := buildAWSEnv(s3.Region, secretName)
+
+	command := []string{"aws", "s3", "cp", "--recursive", "--endpoint-url", s3.Endpoint, backupMountPath + "/" + kind, destination}
+	if parallelism := paradedb.GetBackupParallelism(); parallelism > 1 {
+		// The AWS CLI only takes its transfer concurrency from its config file
+		quoted := make([]string, len(command))
+		for i, arg := range command {
+			quoted[i] = shellQuote(arg)
+		}
+		command = []string{"sh", "-c", fmt.Sprintf("aws configure set default.s3.max_concurrent_requests %d && %s",
+			parallelism, strings.Join(quoted, " "))}
+	}
+
+	return corev1.Container{
+		Name:         "upload",
+		Image:        r.getBackupImage(),
+		Command:      command,
+		Env:          env,
+		VolumeMounts: []corev1.VolumeMount{{Name: "backup", MountPath: backupMountPath}},
+	}
+}
+
+// buildLogicalBackupScript returns the shell script that dumps each database in custom
+// format, or directory format with parallel workers, into a timestamped directory. On a PVC, only the newest retentionPolicy.keepLast
+// directories are kept.
+func buildLogicalBackupScript(paradedb *databasev1alpha1.ParadeDB) string {
+	var script strings.Builder
+	script.WriteString("set -euo pipefail\n")
+	if delay := getBackupJitterDelay(paradedb); delay > 0 {
+		fmt.Fprintf(&script, "sleep %d\n", int64(delay.Seconds()))
+	}
+	script.WriteString("dir=" + backupMountPath + "/logical/$(date -u +%Y%m%dT%H%M%SZ)\n")
+	script.WriteString("mkdir -p \"$dir\"\n")
+	compression := ""
+	if c := paradedb.Spec.Backup.Compression; c != nil {
+		compression = " -Z " + getCompressionAlgorithm(c)
+		if c.Level > 0 {
+			compression += fmt.Sprintf(":%d", c.Level)
+		}
+	}
+	for _, database := range paradedb.GetLogicalBackupDatabases() {
+		if parallelism := paradedb.GetBackupParallelism(); parallelism > 1 {
+			// Only the directory format can be dumped with parallel workers
+			fmt.Fprintf(&script, "pg_dump -Fd -j %d%s -d %s -f \"$dir\"/%s\n",
+				parallelism, compression, shellQuote(database), shellQuote(database))
+			continue
+		}
+		fmt.Fprintf(&script, "pg_dump -Fc%s -d %s -f \"$dir\"/%s.dump\n", compression, shellQuote(database), shellQuote(database))
+	}
+
+	backup := paradedb.Spec.Backup
+	if backup.PVC != nil && backup.RetentionPolicy != nil && backup.RetentionPolicy.KeepLast > 0 {
+		fmt.Fprintf(&script, "ls -1d %s/logical/*/ | sort | head -n -%d | xargs -r rm -rf\n",
+			backupMountPath, backup.RetentionPolicy.KeepLast)
+	}
+	script.WriteString("du -sb \"$dir\" | cut -f1 > /dev/termination-log\n")
+	return script.String()
+}
+
+// validateBackupCompression checks the compression level against the range of the algorithm
+func validateBackupCompression(compression *databasev1alpha1.BackupCompressionSpec) error {
+	if compression == nil || compression.Level == 0 {
+		return nil
+	}
+	algorithm := getCompressionAlgorithm(compression)
+	maxLevel := map[string]int32{"gzip": 9, "lz4": 12, "zstd": 22}[algorithm]
+	if compression.Level > maxLevel {
+		return fmt.Errorf("compression level %d is out of range for %s", compression.Level, algorithm)
+	}
+	return nil
+}
+
+// getBackupImage returns the AWS CLI image that moves backups and WAL to and from S3,
+// unless the operator defaults pin another one
+func (r *ParadeDBReconciler) getBackupImage() string {
+	if r.Defaults != nil && r.Defaults.BackupImage != "" {
+		return r.Defaults.BackupImage
+	}
+	return r.mirrorImage(awsCLIImage)
+}
+
+// getBackupJitterDelay returns how long backups of the instance wait after their scheduled
+// time: a stable offset below spec.backup.jitter that spreads instances sharing a schedule
+func getBackupJitterDelay(paradedb *databasev1alpha1.ParadeDB) time.Duration {
+	jitter := paradedb.Spec.Backup.Jitter
+	if jitter == nil || jitter.Seconds() < 1 {
+		return 0
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(paradedb.Namespace + "/" + paradedb.Name))
+	return time.Duration(hash.Sum64()%uint64(jitter.Seconds())) * time.Second
+}
+
+// createLogicalBackupJob starts a one-off logical backup from the instance's backup CronJob,
+// like `kubectl create job --from=cronjob/...`, owned by the given object
+func createLogicalBackupJob(ctx context.Context, c client.Client, scheme *runtime.Scheme, owner client.Object,
+	paradedb *databasev1alpha1.ParadeDB, name string) error {
+	cronJob := &batchv1.CronJob{}
+	if err := c.Get(ctx, types.NamespacedName{Name: paradedb.GetLogicalBackupCronJobName(), Namespace: paradedb.Namespace}, cronJob); err != nil {
+		return fmt.Errorf("failed to get logical backup CronJob: %w", err)
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+			Labels:    cronJob.Spec.JobTemplate.Labels,
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+	if err := controllerutil.SetControllerReference(owner, job, scheme); err != nil {
+		return err
+	}
+	return c.Create(ctx, job)
+}
+
+// getJobResult returns the Complete or Failed condition of a finished Job, or nil while
+// it runs
+func getJobResult(job *batchv1.Job) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		condition := &job.Status.Conditions[i]
+		if condition.Status == corev1.ConditionTrue && (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) {
+			return condition
+		}
+	}
+	return nil
+}
+
+// getCompressionAlgorithm returns the compression algorithm, defaulting to gzip
+func getCompressionAlgorithm(compression *databasev1alpha1.BackupCompressionSpec) string {
+	if compression.Algorithm == "" {
+		return "gzip"
+	}
+	return compression.Algorithm
+}
+
+// shellQuote quotes a string for use as a single shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,400 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// importPublicationName is the publication created on each source database
+	importPublicationName = "paradedb_import"
+
+	importMethodLogicalReplication = "logicalReplication"
+)
+
+// importDatabaseNamePattern keeps database names safe to use in the import script and in
+// replication slot names
+var importDatabaseNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,46}$`)
+
+// reconcileImport drives an import from an external server or a clone of another
+// instance: a Job copies the data (or only the schema for logical replication), after
+// which the operator subscribes to an external source until cutover
+func (r *ParadeDBReconciler) reconcileImport(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+	external := paradedb.GetExternalBootstrap()
+	instance := paradedb.GetInstanceBootstrap()
+
+	if paradedb.Status.Import == nil {
+		// Wait for the instance to accept connections before starting the copy
+		if paradedb.Status.ReadyReplicas == 0 {
+			return nil
+		}
+		if external != nil && instance != nil {
+			r.setImportPhase(paradedb, databasev1alpha1.ImportPhaseFailed,
+				"bootstrap.fromExternal and bootstrap.fromInstance cannot be combined")
+			return nil
+		}
+		for _, database := range paradedb.GetImportDatabases() {
+			if !importDatabaseNamePattern.MatchString(database) {
+				r.setImportPhase(paradedb, databasev1alpha1.ImportPhaseFailed, fmt.Sprintf("Invalid database name %q", database))
+				return nil
+			}
+		}
+
+		var source *databasev1alpha1.ParadeDB
+		if instance != nil {
+			source = &databasev1alpha1.ParadeDB{}
+			if err := r.Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: paradedb.Namespace}, source); err != nil {
+				return fmt.Errorf("failed to get source ParadeDB %s: %w", instance.Name, err)
+			}
+		}
+
+		job := r.buildImportJob(paradedb, source)
+		if err := controllerutil.SetControllerReference(paradedb, job, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, job); err != nil && !errors.IsAlreadyExists(err) {
+			return err
+		}
+
+		log.Info("Started import", "job", job.Name)
+		now := metav1.Now()
+		paradedb.Status.Import = &databasev1alpha1.ImportStatus{StartTime: &now}
+		message := "Copying data from the external server"
+		if instance != nil {
+			message = "Cloning " + describeInstanceClone(instance)
+		}
+		r.setImportPhase(paradedb, databasev1alpha1.ImportPhaseCopying, message)
+		return nil
+	}
+
+	switch paradedb.Status.Import.Phase {
+	case databasev1alpha1.ImportPhaseCopying:
+		job := &batchv1.Job{}
+		if err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetImportJobName(), Namespace: paradedb.Namespace}, job); err != nil {
+			return err
+		}
+		for _, condition := range job.Status.Conditions {
+			if condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch condition.Type {
+			case batchv1.JobFailed:
+				r.setImportPhase(paradedb, databasev1alpha1.ImportPhaseFailed,
+					fmt.Sprintf("Import job %s failed: %s", job.Name, condition.Message))
+			case batchv1.JobComplete:
+				if instance != nil {
+					r.setImportPhase(paradedb, databasev1alpha1.ImportPhaseCompleted, "Cloned "+describeInstanceClone(instance))
+					return nil
+				}
+				if external.Method != importMethodLogicalReplication {
+					r.setImportPhase(paradedb, databasev1alpha1.ImportPhaseCompleted, "Data copied from the external server")
+					return nil
+				}
+				if err := r.subscribeToSource(ctx, paradedb); err != nil {
+					return err
+				}
+				r.setImportPhase(paradedb, databasev1alpha1.ImportPhaseReplicating, "Replicating changes from the external server")
+			}
+		}
+	case databasev1alpha1.ImportPhaseReplicating:
+		if external.Cutover {
+			if err := r.dropImportSubscriptions(ctx, paradedb); err != nil {
+				return err
+			}
+			r.setImportPhase(paradedb, databasev1alpha1.ImportPhaseCompleted, "Cut over from the external server")
+			return nil
+		}
+		return r.updateImportProgress(ctx, paradedb)
+	}
+
+	return nil
+}
+
+// describeInstanceClone names the instance a clone copies from, for phase messages
+func describeInstanceClone(instance *databasev1alpha1.InstanceBootstrapSpec) string {
+	if instance.SchemaOnly {
+		return "the schema of ParadeDB " + instance.Name
+	}
+	return "ParadeDB " + instance.Name
+}
+
+// setImportPhase records an import phase change and emits an Event for it
+func (r *ParadeDBReconciler) setImportPhase(paradedb *databasev1alpha1.ParadeDB, phase databasev1alpha1.ImportPhase, message string) {
+	if paradedb.Status.Import == nil {
+		paradedb.Status.Import = &databasev1alpha1.ImportStatus{}
+	}
+	paradedb.Status.Import.Phase = phase
+	paradedb.Status.Import.Message = message
+
+	eventType := corev1.EventTypeNormal
+	switch phase {
+	case databasev1alpha1.ImportPhaseFailed:
+		eventType = corev1.EventTypeWarning
+		recordOperation(paradedb, databasev1alpha1.OperationImport, databasev1alpha1.OperationFailed, message)
+	case databasev1alpha1.ImportPhaseCompleted:
+		now := metav1.Now()
+		paradedb.Status.Import.CompletionTime = &now
+		recordOperation(paradedb, databasev1alpha1.OperationImport, databasev1alpha1.OperationSucceeded, message)
+	}
+	r.Recorder.Event(paradedb, eventType, "Import"+string(phase), message)
+}
+
+// buildImportJob creates the Job that copies the external databases into the instance, or
+// those of the source instance when cloning one
+func (r *ParadeDBReconciler) buildImportJob(paradedb *databasev1alpha1.ParadeDB, source *databasev1alpha1.ParadeDB) *batchv1.Job {
+	backoffLimit := int32(2)
+
+	var sourceEnv []corev1.EnvVar
+	if source != nil {
+		sourceEnv = append([]corev1.EnvVar{{
+			Name: "SOURCE",
+			Value: fmt.Sprintf("host=%s.%s.svc port=%d sslmode=%s",
+				source.GetServiceName(), source.Namespace, source.GetPort(), getSSLMode(source)),
+		}}, buildSuperuserEnv(source, "SOURCE_USER", "SOURCE_PASSWORD")...)
+	} else {
+		sourceEnv = []corev1.EnvVar{{
+			Name: "SOURCE",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &paradedb.GetExternalBootstrap().ConnectionSecretRef,
+			},
+		}}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      paradedb.GetImportJobName(),
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app.kubernetes.io/component": "import"},
+					Annotations: buildJobServiceMeshAnnotations(paradedb),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+					Affinity:         withArchitectureAffinity(paradedb, nil),
+					Containers: []corev1.Container{
+						{
+							Name:            "import",
+							Image:           paradedb.GetImage(),
+							ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+							Command:         []string{"bash", "-c", buildImportScript(paradedb)},
+							Env:             append(sourceEnv, buildClientEnv(paradedb)...),
+						},
+					},
+				},
+			},
+		},
+	}
+	applyServiceAccount(paradedb, &job.Spec.Template.Spec)
+	applyDNS(paradedb, &job.Spec.Template.Spec)
+
+	return job
+}
+
+// buildImportScript returns the shell script that creates each database and restores a
+// dump of it from the source. Logical replication only needs the schema, as the
+// subscription copies the rows. A clone of another instance first copies its roles,
+// without passwords, so the restored objects keep their owners and grants.
+func buildImportScript(paradedb *databasev1alpha1.ParadeDB) string {
+	external := paradedb.GetExternalBootstrap()
+	instance := paradedb.GetInstanceBootstrap()
+
+	var script strings.Builder
+	script.WriteString("set -euo pipefail\n")
+	script.WriteString("until pg_isready -q -d postgres; do sleep 2; done\n")
+
+	dump := "pg_dump --no-owner --no-privileges"
+	if instance != nil {
+		script.WriteString("SOURCE=\"$SOURCE user=$SOURCE_USER\"\n")
+		// Roles that already exist, such as the superuser, fail to be created and are skipped
+		script.WriteString("PGPASSWORD=\"${SOURCE_PASSWORD:-}\" pg_dumpall --roles-only --no-role-passwords -d \"$SOURCE\" | psql -q -d postgres\n")
+		dump = "PGPASSWORD=\"${SOURCE_PASSWORD:-}\" pg_dump"
+		if instance.SchemaOnly {
+			dump += " --schema-only"
+		}
+	} else if external.Method == importMethodLogicalReplication {
+		dump += " --schema-only"
+	}
+
+	for _, database := range paradedb.GetImportDatabases() {
+		fmt.Fprintf(&script, "psql -d postgres -tAc \"SELECT 1 FROM pg_database WHERE datname = '%s'\" | grep -q 1 || createdb %s\n",
+			database, database)
+		fmt.Fprintf(&script, "%s -d \"$SOURCE dbname=%s\" | psql -q -v ON_ERROR_STOP=1 -d %s\n",
+			dump, database, database)
+	}
+	return script.String()
+}
+
+// getImportSubscriptionName returns the subscription, and so the replication slot on the
+// source, used for one imported database
+func getImportSubscriptionName(database string) string {
+	return importPublicationName + "_" + database
+}
+
+// subscribeToSource publishes every table on the source databases and subscribes to them
+func (r *ParadeDBReconciler) subscribeToSource(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	external := paradedb.GetExternalBootstrap()
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: external.ConnectionSecretRef.Name, Namespace: paradedb.Namespace}, secret); err != nil {
+		return fmt.Errorf("failed to read source connection secret: %w", err)
+	}
+	source := string(secret.Data[external.ConnectionSecretRef.Key])
+
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	for _, database := range external.Databases {
+		connInfo := fmt.Sprintf("%s dbname=%s", source, database)
+
+		if err := withDatabase(ctx, connInfo, func(ctx context.Context, db *sql.DB) error {
+			var exists bool
+			if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_publication WHERE pubname = $1)",
+				importPublicationName).Scan(&exists); err != nil || exists {
+				return err
+			}
+			_, err := db.ExecContext(ctx, "CREATE PUBLICATION "+importPublicationName+" FOR ALL TABLES")
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to publish %s on the source: %w", database, err)
+		}
+
+		if err := withDatabase(ctx, buildDatabaseURL(paradedb, username, password, database), func(ctx context.Context, db *sql.DB) error {
+			var exists bool
+			if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_subscription WHERE subname = $1)",
+				getImportSubscriptionName(database)).Scan(&exists); err != nil || exists {
+				return err
+			}
+			_, err := db.ExecContext(ctx, fmt.Sprintf("CREATE SUBSCRIPTION %s CONNECTION %s PUBLICATION %s",
+				getImportSubscriptionName(database), pq.QuoteLiteral(connInfo), importPublicationName))
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to subscribe %s to the source: %w", database, err)
+		}
+	}
+	return nil
+}
+
+// updateImportProgress records how many tables of each database finished their initial copy
+func (r *ParadeDBReconciler) updateImportProgress(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	var databases []databasev1alpha1.DatabaseImportStatus
+	for _, database := range paradedb.GetExternalBootstrap().Databases {
+		progress := databasev1alpha1.DatabaseImportStatus{Name: database}
+		if err := withDatabase(ctx, buildDatabaseURL(paradedb, username, password, database), func(ctx context.Context, db *sql.DB) error {
+			return db.QueryRowContext(ctx, `SELECT count(*), count(*) FILTER (WHERE sr.srsubstate = 'r')
+FROM pg_subscription_rel sr JOIN pg_subscription s ON s.oid = sr.srsubid WHERE s.subname = $1`,
+				getImportSubscriptionName(database)).Scan(&progress.Tables, &progress.TablesSynced)
+		}); err != nil {
+			return fmt.Errorf("failed to read sync progress of %s: %w", database, err)
+		}
+		databases = append(databases, progress)
+	}
+	paradedb.Status.Import.Databases = databases
+	return nil
+}
+
+// dropImportSubscriptions ends replication from the source. Dropping a subscription also
+// drops its replication slot on the source.
+func (r *ParadeDBReconciler) dropImportSubscriptions(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	for _, database := range paradedb.GetExternalBootstrap().Databases {
+		if err := withDatabase(ctx, buildDatabaseURL(paradedb, username, password, database), func(ctx context.Context, db *sql.DB) error {
+			_, err := db.ExecContext(ctx, "DROP SUBSCRIPTION IF EXISTS "+getImportSubscriptionName(database))
+			return err
+		}); err != nil {
+			return fmt.Errorf("failed to drop the subscription of %s: %w", database, err)
+		}
+	}
+	return nil
+}
+
+// checkBootstrapSnapshot verifies that the ParadeDBSnapshot the data volume is restored
+// from can be used, until the StatefulSet and with it the volume exist
+func (r *ParadeDBReconciler) checkBootstrapSnapshot(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	if paradedb.GetExternalBootstrap() != nil {
+		return fmt.Errorf("bootstrap.snapshot and bootstrap.fromExternal cannot be combined")
+	}
+	if paradedb.GetInstanceBootstrap() != nil {
+		return fmt.Errorf("bootstrap.snapshot and bootstrap.fromInstance cannot be combined")
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetStatefulSetName(), Namespace: paradedb.Namespace}, statefulSet)
+	if err == nil {
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	snapshot := &databasev1alpha1.ParadeDBSnapshot{}
+	if err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetBootstrapSnapshot(), Namespace: paradedb.Namespace}, snapshot); err != nil {
+		return fmt.Errorf("failed to get ParadeDBSnapshot %s: %w", paradedb.GetBootstrapSnapshot(), err)
+	}
+	if snapshot.Status.Phase != databasev1alpha1.SnapshotPhaseReady {
+		return fmt.Errorf("ParadeDBSnapshot %s is not ready", snapshot.Name)
+	}
+	if snapshot.Status.RestoreSize != nil && paradedb.Spec.Storage.Size.Cmp(*snapshot.Status.RestoreSize) < 0 {
+		return fmt.Errorf("storage size %s is smaller than the snapshot's restore size %s",
+			paradedb.Spec.Storage.Size.String(), snapshot.Status.RestoreSize.String())
+	}
+	return nil
+}
+
+// getSnapshotCredentials reads the superuser credentials of the instance a snapshot was taken of
+func (r *ParadeDBReconciler) getSnapshotCredentials(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (string, string, error) {
+	snapshot := &databasev1alpha1.ParadeDBSnapshot{ObjectMeta: metav1.ObjectMeta{Name: paradedb.GetBootstrapSnapshot()}}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: snapshot.GetCredentialsSecretName(), Namespace: paradedb.Namespace}, secret); err != nil {
+		return "", "", fmt.Errorf("failed to get snapshot credentials: %w", err)
+	}
+	return string(secret.Data["username"]), string(secret.Data["password"]), nil
+}
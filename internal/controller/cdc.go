@@ -0,0 +1,249 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+
+	"github.com/lib/pq"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// reconcileCDCSecret creates the Secret holding the replication role's credentials
+func (r *ParadeDBReconciler) reconcileCDCSecret(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetCDCSecretName(), Namespace: paradedb.Namespace}, secret)
+	if err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+
+	log.Info("Creating CDC secret", "name", paradedb.GetCDCSecretName())
+
+	labels, annotations := withMetadata(paradedb.Spec.SecretMetadata, r.getLabels(paradedb), nil)
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        paradedb.GetCDCSecretName(),
+			Namespace:   paradedb.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Type: corev1.SecretTypeOpaque,
+		StringData: map[string]string{
+			"username": paradedb.GetCDCUsername(),
+			"password": generateRandomPassword(16),
+			"database": paradedb.Spec.Auth.Database,
+			"host":     paradedb.GetHost(),
+			"port":     fmt.Sprintf("%d", paradedb.GetPort()),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(paradedb, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	if err := r.Create(ctx, secret); err != nil {
+		return err
+	}
+
+	r.Recorder.Event(paradedb, corev1.EventTypeNormal, "SecretCreated", "CDC secret created")
+	return nil
+}
+
+// setCDCReadyCondition provisions the replication role, publication and slots in the
+// application database and reports the outcome
+func (r *ParadeDBReconciler) setCDCReadyCondition(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+		setCondition(paradedb, ConditionTypeCDCReady, metav1.ConditionUnknown, "DatabaseUnreachable",
+			"Waiting for the database to accept connections")
+		return
+	}
+
+	err := r.reconcileCDC(ctx, paradedb)
+	if err != nil {
+		setCondition(paradedb, ConditionTypeCDCReady, metav1.ConditionFalse, "ProvisioningFailed",
+			fmt.Sprintf("Failed to provision CDC: %v", err))
+		return
+	}
+	setCondition(paradedb, ConditionTypeCDCReady, metav1.ConditionTrue, "Provisioned",
+		fmt.Sprintf("Replication role %s and %d slots are provisioned", paradedb.GetCDCUsername(), len(paradedb.Spec.CDC.Slots)))
+}
+
+// reconcileCDC runs the SQL that brings the replication role, publication and slots in
+// line with the spec
+func (r *ParadeDBReconciler) reconcileCDC(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetCDCSecretName(), Namespace: paradedb.Namespace}, secret); err != nil {
+		return fmt.Errorf("failed to read CDC secret: %w", err)
+	}
+
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	return withDatabase(ctx, buildConnectionURL(paradedb, username, password), func(ctx context.Context, db *sql.DB) error {
+		if err := applyCDCAccess(ctx, db, paradedb, string(secret.Data["password"])); err != nil {
+			return err
+		}
+
+		slots, err := applyCDCSlots(ctx, db, paradedb.Spec.CDC.Slots, paradedb.Status.CDCSlots)
+		if err != nil {
+			return err
+		}
+		paradedb.Status.CDCSlots = slots
+		return nil
+	})
+}
+
+// applyCDCAccess applies the replication role and the publication when the role's
+// credentials or the publication changed since status.cdcRoleHash was recorded
+func applyCDCAccess(ctx context.Context, db *sql.DB, paradedb *databasev1alpha1.ParadeDB, password string) error {
+	hash := shortHash(paradedb.GetCDCUsername(), password, paradedb.Spec.Auth.Database, paradedb.Spec.CDC.Publication)
+	if paradedb.Status.CDCRoleHash == hash {
+		return nil
+	}
+	if err := applyCDCRole(ctx, db, paradedb, password); err != nil {
+		return err
+	}
+	if err := applyCDCPublication(ctx, db, paradedb.Spec.CDC.Publication); err != nil {
+		return err
+	}
+	paradedb.Status.CDCRoleHash = hash
+	return nil
+}
+
+// applyCDCRole creates or updates the replication role and grants it read access to the
+// public schema, including tables created later
+func applyCDCRole(ctx context.Context, db *sql.DB, paradedb *databasev1alpha1.ParadeDB, password string) error {
+	role := pq.QuoteIdentifier(paradedb.GetCDCUsername())
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)",
+		paradedb.GetCDCUsername()).Scan(&exists); err != nil {
+		return err
+	}
+
+	verb := "CREATE"
+	if exists {
+		verb = "ALTER"
+	}
+	statements := []string{
+		fmt.Sprintf("%s ROLE %s WITH LOGIN REPLICATION PASSWORD %s", verb, role, pq.QuoteLiteral(password)),
+		fmt.Sprintf("GRANT CONNECT ON DATABASE %s TO %s", pq.QuoteIdentifier(paradedb.Spec.Auth.Database), role),
+		fmt.Sprintf("GRANT USAGE ON SCHEMA public TO %s", role),
+		fmt.Sprintf("GRANT SELECT ON ALL TABLES IN SCHEMA public TO %s", role),
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT ON TABLES TO %s", role),
+	}
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyCDCPublication creates the all-tables publication used by pgoutput connectors
+func applyCDCPublication(ctx context.Context, db *sql.DB, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_publication WHERE pubname = $1)", name).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	_, err := db.ExecContext(ctx, fmt.Sprintf("CREATE PUBLICATION %s FOR ALL TABLES", pq.QuoteIdentifier(name)))
+	return err
+}
+
+// applyCDCSlots creates missing slots and drops slots that the operator created earlier
+// but were removed from the spec. Slots still in use by a consumer are kept, and stay in
+// the returned state until they can be dropped.
+func applyCDCSlots(ctx context.Context, db *sql.DB, wanted []databasev1alpha1.CDCSlotSpec,
+	previous []databasev1alpha1.CDCSlotStatus) ([]databasev1alpha1.CDCSlotStatus, error) {
+	existing, err := listLogicalSlots(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []databasev1alpha1.CDCSlotStatus
+	for _, slot := range previous {
+		current, ok := existing[slot.Name]
+		if !ok || slices.ContainsFunc(wanted, func(s databasev1alpha1.CDCSlotSpec) bool { return s.Name == slot.Name }) {
+			continue
+		}
+		if current.Active {
+			slots = append(slots, current)
+			continue
+		}
+		if _, err := db.ExecContext(ctx, "SELECT pg_drop_replication_slot($1)", slot.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, slot := range wanted {
+		if _, ok := existing[slot.Name]; !ok {
+			plugin := slot.Plugin
+			if plugin == "" {
+				plugin = "pgoutput"
+			}
+			if _, err := db.ExecContext(ctx, "SELECT pg_create_logical_replication_slot($1, $2)", slot.Name, plugin); err != nil {
+				return nil, err
+			}
+			existing[slot.Name] = databasev1alpha1.CDCSlotStatus{Name: slot.Name}
+		}
+		slots = append(slots, existing[slot.Name])
+	}
+	return slots, nil
+}
+
+// listLogicalSlots returns the logical replication slots of the current database by name
+func listLogicalSlots(ctx context.Context, db *sql.DB) (map[string]databasev1alpha1.CDCSlotStatus, error) {
+	rows, err := db.QueryContext(ctx, `SELECT slot_name, active,
+  coalesce(pg_wal_lsn_diff(pg_current_wal_lsn(), restart_lsn), 0)::bigint
+FROM pg_replication_slots WHERE slot_type = 'logical' AND database = current_database()`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	slots := map[string]databasev1alpha1.CDCSlotStatus{}
+	for rows.Next() {
+		var slot databasev1alpha1.CDCSlotStatus
+		if err := rows.Scan(&slot.Name, &slot.Active, &slot.RetainedWALBytes); err != nil {
+			return nil, err
+		}
+		slots[slot.Name] = slot
+	}
+	return slots, rows.Err()
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// cleanupPollInterval is how often deletion checks on a running cleanup
+	cleanupPollInterval = 10 * time.Second
+
+	// serviceAccountTokenPath is where the pod's ServiceAccount token is mounted
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// finalizeParadeDB removes the external state selected by spec.cleanupPolicy. It returns
+// how long to wait before checking again while cleanup is in progress, or zero once the
+// finalizer can be removed.
+func (r *ParadeDBReconciler) finalizeParadeDB(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (time.Duration, error) {
+	log := logf.FromContext(ctx)
+	log.Info("Finalizing ParadeDB", "name", paradedb.Name)
+
+	// In-cluster resources are removed by Kubernetes garbage collection via OwnerReferences
+	var pending time.Duration
+	wait := func(d time.Duration) {
+		if d > 0 && (pending == 0 || d < pending) {
+			pending = d
+		}
+	}
+
+	if policy := paradedb.Spec.CleanupPolicy; policy != nil {
+		if policy.DeregisterDNS {
+			remaining, err := r.deregisterDNS(ctx, paradedb)
+			if err != nil {
+				return 0, fmt.Errorf("failed to deregister DNS records: %w", err)
+			}
+			wait(remaining)
+		}
+		if policy.DeleteBackups && paradedb.Spec.Backup != nil && paradedb.Spec.Backup.S3 != nil {
+			done, err := r.runCleanupJob(ctx, paradedb, r.buildBackupCleanupJob(paradedb))
+			if err != nil {
+				return 0, fmt.Errorf("failed to delete backups: %w", err)
+			}
+			if !done {
+				wait(cleanupPollInterval)
+			}
+		}
+		if policy.Vault != nil {
+			done, err := r.runCleanupJob(ctx, paradedb, r.buildVaultCleanupJob(paradedb))
+			if err != nil {
+				return 0, fmt.Errorf("failed to revoke Vault credentials: %w", err)
+			}
+			if !done {
+				wait(cleanupPollInterval)
+			}
+		}
+	}
+	if pending > 0 {
+		return pending, nil
+	}
+
+	r.Recorder.Event(paradedb, corev1.EventTypeNormal, "Deleted", "ParadeDB instance deleted successfully")
+	return 0, nil
+}
+
+// deregisterDNS removes the external-dns hostname annotation from the primary Service and
+// returns how much of the deregistration delay is left. The Service, and the address its
+// records point at, is kept until external-dns has had time to delete them.
+func (r *ParadeDBReconciler) deregisterDNS(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (time.Duration, error) {
+	service := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetServiceName(), Namespace: paradedb.Namespace}, service)
+	if apierrors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	if _, ok := service.Annotations[externalDNSHostnameAnnotation]; ok {
+		delete(service.Annotations, externalDNSHostnameAnnotation)
+		if err := r.Update(ctx, service); err != nil {
+			return 0, err
+		}
+		logf.FromContext(ctx).Info("Removed external-dns hostname from Service", "name", service.Name)
+	}
+
+	deadline := paradedb.DeletionTimestamp.Add(paradedb.GetDNSDeregistrationDelay())
+	return time.Until(deadline), nil
+}
+
+// runCleanupJob creates the cleanup Job if it does not exist and returns true once it has
+// completed. A failed Job is deleted so the next attempt starts a new one.
+func (r *ParadeDBReconciler) runCleanupJob(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, desired *batchv1.Job) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: paradedb.Namespace}, job)
+	if apierrors.IsNotFound(err) {
+		log.Info("Creating cleanup Job", "name", desired.Name)
+		if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
+			return false, err
+		}
+		return false, r.Create(ctx, desired)
+	} else if err != nil {
+		return false, err
+	}
+
+	result := getJobResult(job)
+	if result == nil {
+		return false, nil
+	}
+	if result.Type == batchv1.JobFailed {
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+		return false, fmt.Errorf("job %s failed: %s", job.Name, result.Message)
+	}
+	return true, nil
+}
+
+// buildBackupCleanupJob returns the Job that deletes everything under the instance's
+// prefix in the backup bucket. The prefix includes the namespace, so that a same-named
+// instance in another namespace sharing the bucket keeps its backups.
+func (r *ParadeDBReconciler) buildBackupCleanupJob(paradedb *databasev1alpha1.ParadeDB) *batchv1.Job {
+	s3 := paradedb.Spec.Backup.S3
+	prefix := getBackupPrefix(paradedb, paradedb.Name) + "/"
+
+	secretName := s3.SecretRef.Name
+	if s3.ServiceAccountAuth {
+		secretName = ""
+	}
+
+	podSpec := r.buildCleanupPodSpec(paradedb, corev1.Container{
+		Name:    "delete-backups",
+		Image:   r.getBackupImage(),
+		Command: []string{"aws", "s3", "rm", "--recursive", "--endpoint-url", s3.Endpoint, prefix},
+		Env:     buildAWSEnv(s3.Region, secretName),
+	})
+	if paradedb.IsS3ServiceAccountAuthEnabled() {
+		podSpec.ServiceAccountName = paradedb.GetBackupServiceAccountName()
+	}
+	return r.buildCleanupJob(paradedb, paradedb.GetBackupCleanupJobName(), podSpec)
+}
+
+// getVaultImage returns the Vault CLI image of the Vault cleanup Job
+func (r *ParadeDBReconciler) getVaultImage(paradedb *databasev1alpha1.ParadeDB) string {
+	if image := paradedb.Spec.CleanupPolicy.Vault.Image; image != "" {
+		return image
+	}
+	return r.mirrorImage("hashicorp/vault:1.20.0")
+}
+
+// buildVaultCleanupJob returns the Job that logs in to Vault as the instance's
+// ServiceAccount, revokes its leases and deletes its KV secrets
+func (r *ParadeDBReconciler) buildVaultCleanupJob(paradedb *databasev1alpha1.ParadeDB) *batchv1.Job {
+	vault := paradedb.Spec.CleanupPolicy.Vault
+	authMount := vault.AuthMount
+	if authMount == "" {
+		authMount = "kubernetes"
+	}
+
+	var script strings.Builder
+	script.WriteString("set -eu\n")
+	fmt.Fprintf(&script, "VAULT_TOKEN=$(vault write -field=token %s role=%s jwt=@%s)\n",
+		shellQuote("auth/"+strings.Trim(authMount, "/")+"/login"), shellQuote(vault.Role), serviceAccountTokenPath)
+	script.WriteString("export VAULT_TOKEN\n")
+	if vault.LeasePrefix != "" {
+		fmt.Fprintf(&script, "vault lease revoke -prefix %s\n", shellQuote(vault.LeasePrefix))
+	}
+	for _, path := range vault.KVPaths {
+		fmt.Fprintf(&script, "vault kv metadata delete %s\n", shellQuote(path))
+	}
+
+	podSpec := r.buildCleanupPodSpec(paradedb, corev1.Container{
+		Name:    "revoke-credentials",
+		Image:   r.getVaultImage(paradedb),
+		Command: []string{"sh", "-c", script.String()},
+		Env:     []corev1.EnvVar{{Name: "VAULT_ADDR", Value: vault.Address}},
+	})
+	// The Kubernetes auth method needs the ServiceAccount token
+	automountToken := true
+	podSpec.AutomountServiceAccountToken = &automountToken
+	return r.buildCleanupJob(paradedb, paradedb.GetVaultCleanupJobName(), podSpec)
+}
+
+// buildCleanupPodSpec returns the pod spec of a cleanup Job running the given container
+func (r *ParadeDBReconciler) buildCleanupPodSpec(paradedb *databasev1alpha1.ParadeDB, container corev1.Container) corev1.PodSpec {
+	podSpec := corev1.PodSpec{
+		RestartPolicy:    corev1.RestartPolicyNever,
+		ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+		Affinity:         withArchitectureAffinity(paradedb, nil),
+		Containers:       []corev1.Container{container},
+	}
+	applyServiceAccount(paradedb, &podSpec)
+	applyDNS(paradedb, &podSpec)
+	return podSpec
+}
+
+// buildCleanupJob wraps a cleanup pod spec in a Job
+func (r *ParadeDBReconciler) buildCleanupJob(paradedb *databasev1alpha1.ParadeDB, name string, podSpec corev1.PodSpec) *batchv1.Job {
+	backoffLimit := int32(2)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app.kubernetes.io/component": "cleanup"},
+					Annotations: buildJobServiceMeshAnnotations(paradedb),
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
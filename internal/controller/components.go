@@ -0,0 +1,130 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// updateComponentStatus reports the readiness of the database, pooler, exporter and
+// logical backups in status.components
+func (r *ParadeDBReconciler) updateComponentStatus(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, statefulSet *appsv1.StatefulSet) error {
+	components := []databasev1alpha1.ComponentStatus{
+		buildReplicaComponent("database", paradedb.GetReplicas(), statefulSet.Status.ReadyReplicas),
+	}
+
+	if paradedb.IsConnectionPoolingEnabled() {
+		deployment := &appsv1.Deployment{}
+		err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetPoolerDeploymentName(), Namespace: paradedb.Namespace}, deployment)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		desired := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desired = *deployment.Spec.Replicas
+		}
+		components = append(components, buildReplicaComponent("pooler", desired, deployment.Status.ReadyReplicas))
+	}
+
+	if paradedb.IsExporterDeployment() {
+		deployment := &appsv1.Deployment{}
+		err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetExporterDeploymentName(), Namespace: paradedb.Namespace}, deployment)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		desired := int32(1)
+		if deployment.Spec.Replicas != nil {
+			desired = *deployment.Spec.Replicas
+		}
+		components = append(components, buildReplicaComponent("exporter", desired, deployment.Status.ReadyReplicas))
+	} else if paradedb.IsMonitoringEnabled() && !paradedb.IsMonitoringSuspended() {
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(paradedb.Namespace), client.MatchingLabels(r.getSelectorLabels(paradedb))); err != nil {
+			return err
+		}
+		components = append(components, buildExporterComponent(paradedb.GetReplicas(), pods.Items))
+	}
+
+	if paradedb.IsLogicalBackupEnabled() {
+		components = append(components, buildBackupComponent(paradedb.Status.LogicalBackup))
+	}
+
+	paradedb.Status.Components = components
+	return nil
+}
+
+// buildReplicaComponent reports a component that is ready once all its replicas are
+func buildReplicaComponent(name string, desired, ready int32) databasev1alpha1.ComponentStatus {
+	return databasev1alpha1.ComponentStatus{
+		Name:          name,
+		Ready:         ready >= desired,
+		Replicas:      desired,
+		ReadyReplicas: ready,
+		Message:       fmt.Sprintf("%d/%d replicas ready", ready, desired),
+	}
+}
+
+// buildExporterComponent reports the metrics exporter sidecars as ready once every pod's
+// exporter container is
+func buildExporterComponent(desired int32, pods []corev1.Pod) databasev1alpha1.ComponentStatus {
+	var ready int32
+	message := ""
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != exporterContainerName {
+				continue
+			}
+			if status.Ready {
+				ready++
+			} else if status.State.Waiting != nil && message == "" {
+				message = fmt.Sprintf("%s on pod %s", status.State.Waiting.Reason, pod.Name)
+			}
+		}
+	}
+
+	component := buildReplicaComponent("exporter", desired, ready)
+	if message != "" {
+		component.Message += ": " + message
+	}
+	return component
+}
+
+// buildBackupComponent reports logical backups as ready unless the most recent one failed
+func buildBackupComponent(backup *databasev1alpha1.BackupStatus) databasev1alpha1.ComponentStatus {
+	component := databasev1alpha1.ComponentStatus{Name: "backups", Ready: true, Message: "No backup has run yet"}
+	if backup == nil {
+		return component
+	}
+
+	component.Message = fmt.Sprintf("Backup %s is %s", backup.JobName, backup.Phase)
+	if backup.Phase == databasev1alpha1.BackupPhaseFailed {
+		component.Ready = false
+		if backup.Message != "" {
+			component.Message += ": " + backup.Message
+		}
+	}
+	return component
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lib/pq"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// passwordPendingAnnotation on a recreated credentials Secret marks a password that has
+// not been applied to the superuser role yet
+const passwordPendingAnnotation = "database.paradedb.io/password-pending"
+
+// applyPendingPassword sets the superuser's password to the one in a recreated credentials
+// Secret. The old password went with the Secret, so the operator cannot connect over the
+// network; instead it runs psql in each ready pod over the trusted local socket. Until a
+// pod is ready the password stays pending.
+func (r *ParadeDBReconciler) applyPendingPassword(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, secret *corev1.Secret) error {
+	log := logf.FromContext(ctx)
+
+	pods, err := r.listReadyPods(ctx, paradedb)
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		log.Info("Waiting for a ready pod to apply the recreated superuser password")
+		return nil
+	}
+
+	username := string(secret.Data["username"])
+	statement := fmt.Sprintf("SET log_statement = 'none';\nALTER ROLE %s PASSWORD %s;\n",
+		pq.QuoteIdentifier(username), pq.QuoteLiteral(string(secret.Data["password"])))
+	for _, pod := range pods {
+		command := []string{"psql", "-v", "ON_ERROR_STOP=1", "-h", "/var/run/postgresql",
+			"-p", fmt.Sprintf("%d", paradedb.GetPort()), "-U", username, "-d", "postgres"}
+		if err := r.execInPod(ctx, &pod, "paradedb", command, statement); err != nil {
+			return fmt.Errorf("failed to set the superuser password in pod %s: %w", pod.Name, err)
+		}
+	}
+
+	delete(secret.Annotations, passwordPendingAnnotation)
+	if err := r.Update(ctx, secret); err != nil {
+		return err
+	}
+	log.Info("Applied recreated superuser password", "secret", secret.Name)
+	r.Recorder.Event(paradedb, corev1.EventTypeNormal, "SuperuserPasswordReset",
+		fmt.Sprintf("Superuser password reset to the one in recreated Secret %s", secret.Name))
+	return nil
+}
+
+// execInPod runs a command in a container of the pod, passing stdin to it
+func (r *ParadeDBReconciler) execInPod(ctx context.Context, pod *corev1.Pod, container string, command []string, stdin string) error {
+	if r.RESTConfig == nil {
+		return fmt.Errorf("no API server configuration to exec into pods with")
+	}
+	clientset, err := kubernetes.NewForConfig(r.RESTConfig)
+	if err != nil {
+		return err
+	}
+
+	request := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(pod.Namespace).Name(pod.Name).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(r.RESTConfig, "POST", request.URL())
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  strings.NewReader(stdin),
+		Stdout: io.Discard,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%w: %s", err, message)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// setDatabasesReadyCondition creates missing auth.databases and their extensions, applies
+// the session limits of databases and users and reports the outcome
+func (r *ParadeDBReconciler) setDatabasesReadyCondition(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+		setCondition(paradedb, ConditionTypeDatabasesReady, metav1.ConditionUnknown, "DatabaseUnreachable",
+			"Waiting for the database to accept connections")
+		return
+	}
+
+	if err := r.reconcileDatabases(ctx, paradedb); err != nil {
+		setCondition(paradedb, ConditionTypeDatabasesReady, metav1.ConditionFalse, "ProvisioningFailed",
+			fmt.Sprintf("Failed to provision databases: %v", err))
+		return
+	}
+	setCondition(paradedb, ConditionTypeDatabasesReady, metav1.ConditionTrue, "Provisioned",
+		fmt.Sprintf("%d databases are provisioned", len(paradedb.Spec.Auth.Databases)))
+}
+
+// reconcileDatabases runs the SQL that brings the databases, their owners and extensions
+// in line with the spec
+func (r *ParadeDBReconciler) reconcileDatabases(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	for _, database := range paradedb.Spec.Auth.Databases {
+		err := withDatabase(ctx, buildConnectionURL(paradedb, username, password), func(ctx context.Context, db *sql.DB) error {
+			return applyDatabase(ctx, db, database)
+		})
+		if err != nil {
+			return fmt.Errorf("database %s: %w", database.Name, err)
+		}
+		if len(database.Extensions) == 0 {
+			continue
+		}
+
+		// Extensions are created in the database itself
+		err = withDatabase(ctx, buildDatabaseURL(paradedb, username, password, database.Name), func(ctx context.Context, db *sql.DB) error {
+			for _, extension := range database.Extensions {
+				if _, err := db.ExecContext(ctx, "CREATE EXTENSION IF NOT EXISTS "+pq.QuoteIdentifier(extension)); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("database %s: %w", database.Name, err)
+		}
+	}
+
+	for _, user := range paradedb.Spec.Auth.Users {
+		if user.SessionLimits.IsEmpty() {
+			continue
+		}
+		err := withDatabase(ctx, buildConnectionURL(paradedb, username, password), func(ctx context.Context, db *sql.DB) error {
+			return applySessionLimits(ctx, db, "ROLE", user.Name, user.SessionLimits)
+		})
+		if err != nil {
+			return fmt.Errorf("user %s: %w", user.Name, err)
+		}
+	}
+	return nil
+}
+
+// applySessionLimits sets the connection limit and timeout defaults of a database or role
+func applySessionLimits(ctx context.Context, db *sql.DB, kind, name string, limits databasev1alpha1.SessionLimits) error {
+	for _, statement := range buildSessionLimitStatements(kind, name, limits) {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildSessionLimitStatements returns the ALTER DATABASE or ALTER ROLE statements for the limits
+func buildSessionLimitStatements(kind, name string, limits databasev1alpha1.SessionLimits) []string {
+	target := kind + " " + pq.QuoteIdentifier(name)
+
+	var statements []string
+	if limits.ConnectionLimit != nil {
+		statements = append(statements, fmt.Sprintf("ALTER %s CONNECTION LIMIT %d", target, *limits.ConnectionLimit))
+	}
+	if limits.StatementTimeout != "" {
+		statements = append(statements, fmt.Sprintf("ALTER %s SET statement_timeout = %s",
+			target, pq.QuoteLiteral(limits.StatementTimeout)))
+	}
+	if limits.IdleInTransactionSessionTimeout != "" {
+		statements = append(statements, fmt.Sprintf("ALTER %s SET idle_in_transaction_session_timeout = %s",
+			target, pq.QuoteLiteral(limits.IdleInTransactionSessionTimeout)))
+	}
+	return statements
+}
+
+// applyDatabase creates the database if it is missing and sets its owner and session limits
+func applyDatabase(ctx context.Context, db *sql.DB, database databasev1alpha1.ApplicationDatabase) error {
+	var owner sql.NullString
+	err := db.QueryRowContext(ctx,
+		"SELECT pg_get_userbyid(datdba) FROM pg_database WHERE datname = $1", database.Name).Scan(&owner)
+	if err == sql.ErrNoRows {
+		statement := "CREATE DATABASE " + pq.QuoteIdentifier(database.Name)
+		if database.Owner != "" {
+			statement += " OWNER " + pq.QuoteIdentifier(database.Owner)
+		}
+		_, err = db.ExecContext(ctx, statement)
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if database.Owner != "" && owner.String != database.Owner {
+		_, err = db.ExecContext(ctx, fmt.Sprintf("ALTER DATABASE %s OWNER TO %s",
+			pq.QuoteIdentifier(database.Name), pq.QuoteIdentifier(database.Owner)))
+		if err != nil {
+			return err
+		}
+	}
+	return applySessionLimits(ctx, db, "DATABASE", database.Name, database.SessionLimits)
+}
+
+// buildDatabasesScript returns the psql commands that create auth.databases during initdb.
+// It reconnects to each new database to create its extensions.
+func buildDatabasesScript(paradedb *databasev1alpha1.ParadeDB) string {
+	if len(paradedb.Spec.Auth.Databases) == 0 {
+		return ""
+	}
+
+	script := "-- Create additional databases\n"
+	for _, database := range paradedb.Spec.Auth.Databases {
+		statement := "CREATE DATABASE " + pq.QuoteIdentifier(database.Name)
+		if database.Owner != "" {
+			statement += " OWNER " + pq.QuoteIdentifier(database.Owner)
+		}
+		script += statement + ";\n"
+	}
+	for _, database := range paradedb.Spec.Auth.Databases {
+		if len(database.Extensions) == 0 {
+			continue
+		}
+		script += fmt.Sprintf("\\connect %s\n", pq.QuoteIdentifier(database.Name))
+		for _, extension := range database.Extensions {
+			script += fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s;\n", pq.QuoteIdentifier(extension))
+		}
+	}
+	return script
+}
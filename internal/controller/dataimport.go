@@ -0,0 +1,224 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/lib/pq"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// ConditionTypeDataImported reports whether the bootstrap datasets were loaded from
+// object storage
+const ConditionTypeDataImported = "DataImported"
+
+// validateDataImports rejects datasets whose server is not in spec.analytics or reads
+// another format
+func validateDataImports(paradedb *databasev1alpha1.ParadeDB) error {
+	servers := map[string]databasev1alpha1.AnalyticsServerSpec{}
+	for _, server := range paradedb.GetAnalyticsServers() {
+		servers[server.Name] = server
+	}
+	for _, dataset := range paradedb.GetDataImports() {
+		server, ok := servers[dataset.Server]
+		if !ok {
+			return fmt.Errorf("import of %s: server %q is not in spec.analytics.servers", dataset.Table, dataset.Server)
+		}
+		if wrapper := getAnalyticsWrapper(server); wrapper != getDataImportFormat(dataset) {
+			return fmt.Errorf("import of %s: server %q reads %s, not %s", dataset.Table, server.Name, wrapper, getDataImportFormat(dataset))
+		}
+	}
+	return nil
+}
+
+// getAnalyticsWrapper returns the pg_analytics foreign data wrapper of the server
+func getAnalyticsWrapper(server databasev1alpha1.AnalyticsServerSpec) string {
+	if server.Wrapper == "" {
+		return "parquet"
+	}
+	return server.Wrapper
+}
+
+// getDataImportFormat returns the format of the dataset's objects
+func getDataImportFormat(dataset databasev1alpha1.DataImportSpec) string {
+	if dataset.Format == "" {
+		return "parquet"
+	}
+	return dataset.Format
+}
+
+// buildDataImportScripts returns the psql script of each database that creates the
+// dataset tables through temporary foreign tables, keyed by database. Tables that already
+// exist are skipped, so a retried Job does not load rows twice.
+func buildDataImportScripts(paradedb *databasev1alpha1.ParadeDB) map[string]string {
+	databases := map[string]string{}
+	for _, server := range paradedb.GetAnalyticsServers() {
+		databases[server.Name] = server.Database
+		if server.Database == "" {
+			databases[server.Name] = paradedb.Spec.Auth.Database
+		}
+	}
+
+	scripts := map[string]*strings.Builder{}
+	for i, dataset := range paradedb.GetDataImports() {
+		database := databases[dataset.Server]
+		if scripts[database] == nil {
+			scripts[database] = &strings.Builder{}
+		}
+		script := scripts[database]
+		table := quoteQualifiedName(dataset.Table)
+		source := pq.QuoteIdentifier(fmt.Sprintf("paradedb_import_%d", i))
+
+		fmt.Fprintf(script, "SELECT to_regclass(%s) IS NULL AS missing \\gset\n", pq.QuoteLiteral(table))
+		script.WriteString("\\if :missing\n")
+		script.WriteString("BEGIN;\n")
+		if i := strings.LastIndex(dataset.Table, "."); i >= 0 {
+			fmt.Fprintf(script, "CREATE SCHEMA IF NOT EXISTS %s;\n", quoteQualifiedName(dataset.Table[:i]))
+		}
+		fmt.Fprintf(script, "CREATE FOREIGN TABLE %s () SERVER %s OPTIONS (files %s);\n",
+			source, pq.QuoteIdentifier(dataset.Server), pq.QuoteLiteral(dataset.Path))
+		fmt.Fprintf(script, "CREATE TABLE %s AS SELECT * FROM %s;\n", table, source)
+		fmt.Fprintf(script, "DROP FOREIGN TABLE %s;\n", source)
+		script.WriteString("COMMIT;\n")
+		script.WriteString("\\else\n")
+		fmt.Fprintf(script, "\\echo Table %s already exists\n", dataset.Table)
+		script.WriteString("\\endif\n")
+	}
+
+	result := make(map[string]string, len(scripts))
+	for database, script := range scripts {
+		result[database] = script.String()
+	}
+	return result
+}
+
+// buildDataImportJob returns the Job that runs the import script of each database
+func (r *ParadeDBReconciler) buildDataImportJob(paradedb *databasev1alpha1.ParadeDB) *batchv1.Job {
+	backoffLimit := int32(2)
+	env := buildClientEnv(paradedb)
+	var command strings.Builder
+	command.WriteString("set -euo pipefail\n")
+	scripts := buildDataImportScripts(paradedb)
+	for i, database := range slices.Sorted(maps.Keys(scripts)) {
+		name := fmt.Sprintf("IMPORT_%d", i)
+		env = append(env, corev1.EnvVar{Name: name, Value: scripts[database]})
+		fmt.Fprintf(&command, "printf '%%s\\n' \"$%s\" | psql -X -v ON_ERROR_STOP=1 -d %s -f -\n", name, shellQuote(database))
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      paradedb.GetDataImportJobName(),
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app.kubernetes.io/component": "import-data"},
+					Annotations: buildJobServiceMeshAnnotations(paradedb),
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+					Affinity:         withArchitectureAffinity(paradedb, nil),
+					Containers: []corev1.Container{{
+						Name:            "import-data",
+						Image:           paradedb.GetImage(),
+						ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+						Command:         []string{"bash", "-c", command.String()},
+						Env:             env,
+					}},
+				},
+			},
+		},
+	}
+	applyServiceAccount(paradedb, &job.Spec.Template.Spec)
+	applyDNS(paradedb, &job.Spec.Template.Spec)
+
+	return job
+}
+
+// reconcileDataImport loads the bootstrap datasets once the analytics servers are
+// provisioned. It runs once per instance: later changes to the list are not applied.
+func (r *ParadeDBReconciler) reconcileDataImport(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	if paradedb.IsStandby() || meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDataImported) {
+		return nil
+	}
+
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetDataImportJobName(), Namespace: paradedb.Namespace}, job)
+	if apierrors.IsNotFound(err) {
+		if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeAnalyticsReady) {
+			setCondition(paradedb, ConditionTypeDataImported, metav1.ConditionFalse, "WaitingForAnalytics",
+				"Waiting for the analytics servers to be provisioned")
+			return nil
+		}
+		job = r.buildDataImportJob(paradedb)
+		if err := controllerutil.SetControllerReference(paradedb, job, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			return err
+		}
+
+		log.Info("Started data import from object storage", "job", job.Name)
+		message := fmt.Sprintf("Loading %d datasets in Job %s", len(paradedb.GetDataImports()), job.Name)
+		setCondition(paradedb, ConditionTypeDataImported, metav1.ConditionFalse, "Importing", message)
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "DataImportStarted", message)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	result := getJobResult(job)
+	if result == nil {
+		return nil
+	}
+	if result.Type == batchv1.JobFailed {
+		message := fmt.Sprintf("Data import job %s failed: %s", job.Name, result.Message)
+		// Report the failure once rather than on every reconcile
+		if condition := meta.FindStatusCondition(paradedb.Status.Conditions, ConditionTypeDataImported); condition == nil || condition.Reason != "ImportFailed" {
+			r.Recorder.Event(paradedb, corev1.EventTypeWarning, "DataImportFailed", message)
+			recordOperation(paradedb, databasev1alpha1.OperationImport, databasev1alpha1.OperationFailed, message)
+		}
+		setCondition(paradedb, ConditionTypeDataImported, metav1.ConditionFalse, "ImportFailed", message)
+		return nil
+	}
+
+	message := fmt.Sprintf("Loaded %d datasets from object storage", len(paradedb.GetDataImports()))
+	setCondition(paradedb, ConditionTypeDataImported, metav1.ConditionTrue, "Imported", message)
+	r.Recorder.Event(paradedb, corev1.EventTypeNormal, "DataImportCompleted", message)
+	recordOperation(paradedb, databasev1alpha1.OperationImport, databasev1alpha1.OperationSucceeded, message)
+	return nil
+}
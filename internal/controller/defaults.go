@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// OperatorDefaults are fleet-wide settings, read from the file given by --defaults-config,
+// that the operator applies to every ParadeDB leaving them unset
+type OperatorDefaults struct {
+	// Image is the ParadeDB image for instances that set neither spec.image nor a class
+	// image and do not follow a version catalog, such as a copy in an air-gapped mirror
+	Image string `json:"image,omitempty"`
+
+	// PoolerImage is the PgBouncer image for instances that do not set one
+	PoolerImage string `json:"poolerImage,omitempty"`
+
+	// ExporterImage is the postgres_exporter image for instances that do not set one
+	ExporterImage string `json:"exporterImage,omitempty"`
+
+	// BackupImage is the AWS CLI image that backup, archive and cleanup containers use to
+	// reach S3
+	BackupImage string `json:"backupImage,omitempty"`
+
+	// ImagePullSecrets are used by instances that do not list their own
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// StorageClassName of the data volumes of instances that do not set one
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Backup is the backup configuration of instances that do not configure backups
+	Backup *databasev1alpha1.BackupSpec `json:"backup,omitempty"`
+
+	// PodSecurityContext of instances that do not set one
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// ContainerSecurityContext of instances that do not set one
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+}
+
+// LoadOperatorDefaults reads the operator defaults from a YAML file, typically a mounted
+// ConfigMap key. Unknown fields are rejected so that a typo does not go unnoticed.
+func LoadOperatorDefaults(path string) (*OperatorDefaults, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defaults := &OperatorDefaults{}
+	if err := yaml.UnmarshalStrict(data, defaults); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return defaults, nil
+}
+
+// applyOperatorDefaults fills in the settings the ParadeDB and its class leave unset from
+// the operator defaults. Like the class defaults, the result is only used for this
+// reconcile and is never written back to the spec.
+func applyOperatorDefaults(paradedb *databasev1alpha1.ParadeDB, defaults *OperatorDefaults) {
+	if defaults == nil {
+		return
+	}
+
+	spec := &paradedb.Spec
+	if spec.Image == "" && spec.UpdatePolicy == nil {
+		spec.Image = defaults.Image
+	}
+	if spec.ConnectionPooling != nil && spec.ConnectionPooling.Image == "" {
+		spec.ConnectionPooling.Image = defaults.PoolerImage
+	}
+	if spec.Monitoring != nil && spec.Monitoring.Image == "" {
+		spec.Monitoring.Image = defaults.ExporterImage
+	}
+	if len(spec.ImagePullSecrets) == 0 {
+		spec.ImagePullSecrets = defaults.ImagePullSecrets
+	}
+	if spec.Storage.StorageClassName == nil && defaults.StorageClassName != nil {
+		storageClassName := *defaults.StorageClassName
+		spec.Storage.StorageClassName = &storageClassName
+	}
+	if spec.Backup == nil && defaults.Backup != nil {
+		spec.Backup = defaults.Backup.DeepCopy()
+	}
+	if spec.PodSecurityContext == nil && defaults.PodSecurityContext != nil {
+		spec.PodSecurityContext = defaults.PodSecurityContext.DeepCopy()
+	}
+	if spec.ContainerSecurityContext == nil && defaults.ContainerSecurityContext != nil {
+		spec.ContainerSecurityContext = defaults.ContainerSecurityContext.DeepCopy()
+	}
+}
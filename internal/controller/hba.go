@@ -0,0 +1,108 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+var (
+	hbaConnectionTypes = []string{"local", "host", "hostssl", "hostnossl", "hostgssenc", "hostnogssenc"}
+	hbaAuthMethods     = []string{"trust", "reject", "scram-sha-256", "md5", "password", "gss", "sspi",
+		"ident", "peer", "ldap", "radius", "cert", "pam", "bsd"}
+)
+
+// validatePgHBARule checks that a pg_hba.conf rule has a known connection type and
+// authentication method, and a valid address for host rules
+func validatePgHBARule(rule string) error {
+	_, err := parsePgHBAMethod(rule)
+	return err
+}
+
+// parsePgHBAMethod returns the authentication method of a pg_hba.conf rule, or an empty
+// string for a comment
+func parsePgHBAMethod(rule string) (string, error) {
+	fields := strings.Fields(rule)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return "", nil
+	}
+	if !slices.Contains(hbaConnectionTypes, fields[0]) {
+		return "", fmt.Errorf("pg_hba rule %q: unknown connection type %q", rule, fields[0])
+	}
+
+	// TYPE DATABASE USER [ADDRESS [MASK]] METHOD [OPTIONS]
+	method := 3
+	if fields[0] != "local" {
+		if len(fields) < 5 {
+			return "", fmt.Errorf("pg_hba rule %q: expected TYPE DATABASE USER ADDRESS METHOD", rule)
+		}
+		if net.ParseIP(fields[3]) != nil {
+			// An IP address is followed by a separate netmask
+			if len(fields) < 6 || net.ParseIP(fields[4]) == nil {
+				return "", fmt.Errorf("pg_hba rule %q: IP address %q needs a netmask", rule, fields[3])
+			}
+			method = 5
+		} else {
+			if strings.Contains(fields[3], "/") {
+				if _, _, err := net.ParseCIDR(fields[3]); err != nil {
+					return "", fmt.Errorf("pg_hba rule %q: invalid address %q", rule, fields[3])
+				}
+			}
+			method = 4
+		}
+	}
+	if len(fields) <= method {
+		return "", fmt.Errorf("pg_hba rule %q: missing authentication method", rule)
+	}
+	if !slices.Contains(hbaAuthMethods, fields[method]) {
+		return "", fmt.Errorf("pg_hba rule %q: unknown authentication method %q", rule, fields[method])
+	}
+	return fields[method], nil
+}
+
+// validatePgHBA checks every user-supplied pg_hba.conf rule
+func validatePgHBA(paradedb *databasev1alpha1.ParadeDB) error {
+	for _, rule := range paradedb.Spec.Auth.PgHBA {
+		if err := validatePgHBARule(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSuperuserAuth checks that a superuser without a password can still connect over
+// the network, through a custom trust or cert rule. Local connections are always trusted.
+func validateSuperuserAuth(paradedb *databasev1alpha1.ParadeDB) error {
+	if !paradedb.IsSuperuserPasswordless() {
+		return nil
+	}
+	for _, rule := range paradedb.Spec.Auth.PgHBA {
+		method, err := parsePgHBAMethod(rule)
+		if err != nil {
+			return err
+		}
+		if (method == "trust" || method == "cert") && strings.Fields(rule)[0] != "local" {
+			return nil
+		}
+	}
+	return fmt.Errorf("auth.managedSuperuserSecret is false: set auth.superuserSecretRef or add a host trust or cert rule to auth.pgHBA")
+}
@@ -18,10 +18,21 @@ package controller
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"path"
+	"slices"
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
 	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
 )
 
@@ -40,95 +51,572 @@ func generateRandomPassword(length int) string {
 	return password
 }
 
-// buildPostgresConfig generates the PostgreSQL configuration
-func buildPostgresConfig(paradedb *databasev1alpha1.ParadeDB) string {
-	var config strings.Builder
+// buildPreStopCommand returns the preStop hook that checkpoints and performs a fast
+// shutdown, so a rollout doesn't leave PostgreSQL to crash-recover on the next start
+func buildPreStopCommand(gracePeriodSeconds int64) []string {
+	// Leave a few seconds of the grace period for the container runtime to stop the pod
+	timeout := max(gracePeriodSeconds-5, 1)
 
-	// Default configuration
-	config.WriteString("# ParadeDB PostgreSQL Configuration\n")
-	config.WriteString("# Generated by paradedb-operator\n\n")
+	script := fmt.Sprintf(`psql -U "$POSTGRES_USER" -d postgres -c CHECKPOINT >/dev/null 2>&1
+if [ "$(id -u)" = "0" ]; then
+  exec gosu postgres pg_ctl stop -D "$PGDATA" -m fast -w -t %d
+fi
+exec pg_ctl stop -D "$PGDATA" -m fast -w -t %d`, timeout, timeout)
 
-	// Listen settings
-	config.WriteString("listen_addresses = '*'\n")
-	config.WriteString("port = 5432\n\n")
-
-	// Connection settings
-	config.WriteString("max_connections = 100\n")
-	config.WriteString("superuser_reserved_connections = 3\n\n")
-
-	// Memory settings
-	config.WriteString("shared_buffers = 128MB\n")
-	config.WriteString("effective_cache_size = 512MB\n")
-	config.WriteString("maintenance_work_mem = 64MB\n")
-	config.WriteString("work_mem = 4MB\n\n")
-
-	// WAL settings
-	config.WriteString("wal_level = replica\n")
-	config.WriteString("max_wal_senders = 10\n")
-	config.WriteString("max_replication_slots = 10\n")
-	config.WriteString("wal_keep_size = 1GB\n\n")
-
-	// Logging
-	config.WriteString("logging_collector = on\n")
-	config.WriteString("log_directory = 'log'\n")
-	config.WriteString("log_filename = 'postgresql-%Y-%m-%d_%H%M%S.log'\n")
-	config.WriteString("log_rotation_age = 1d\n")
-	config.WriteString("log_rotation_size = 100MB\n")
-	config.WriteString("log_min_messages = warning\n")
-	config.WriteString("log_min_error_statement = error\n\n")
-
-	// Checkpoint settings
-	config.WriteString("checkpoint_timeout = 5min\n")
-	config.WriteString("checkpoint_completion_target = 0.9\n\n")
-
-	// Shared preload libraries for ParadeDB extensions
-	config.WriteString("# ParadeDB Extensions\n")
-	var preloadLibs []string
+	return []string{"/bin/sh", "-c", script}
+}
 
-	if paradedb.Spec.Extensions.PgSearch {
-		preloadLibs = append(preloadLibs, "pg_search")
+// buildReplicaBootstrapContainer returns the init container that clones the primary with
+// pg_basebackup into an empty data directory. The clone starts as a standby; promotion
+// happens on the running pod, see promoteReplicaCluster.
+func buildReplicaBootstrapContainer(paradedb *databasev1alpha1.ParadeDB) corev1.Container {
+	replicaOf := paradedb.Spec.ReplicaOf
+	if replicaOf.S3Archive != nil {
+		return buildArchiveBootstrapContainer(paradedb)
 	}
-	if paradedb.Spec.Extensions.PgAnalytics {
-		preloadLibs = append(preloadLibs, "pg_analytics")
+
+	port := replicaOf.Port
+	if port == 0 {
+		port = 5432
 	}
-	if paradedb.Spec.Extensions.PgVector {
-		preloadLibs = append(preloadLibs, "vector")
+	sslMode := replicaOf.SSLMode
+	if sslMode == "" {
+		sslMode = "prefer"
 	}
 
-	if len(preloadLibs) > 0 {
-		config.WriteString(fmt.Sprintf("shared_preload_libraries = '%s'\n\n", strings.Join(preloadLibs, ",")))
+	script := `set -eu
+if [ ! -s "$PGDATA/PG_VERSION" ]; then
+  mkdir -p "$PGDATA"
+  pg_basebackup -D "$PGDATA" -R -X stream -c fast -h "$PRIMARY_HOST" -p "$PRIMARY_PORT" -U "$PGUSER"
+fi
+if [ "$(id -u)" = "0" ]; then
+  chown -R postgres:postgres "$PGDATA"
+fi
+chmod 0700 "$PGDATA"`
+
+	return corev1.Container{
+		Name:            "replica-bootstrap",
+		Image:           paradedb.GetImage(),
+		ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", script},
+		Env: []corev1.EnvVar{
+			{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+			{Name: "PRIMARY_HOST", Value: replicaOf.Host},
+			{Name: "PRIMARY_PORT", Value: fmt.Sprintf("%d", port)},
+			{Name: "PGSSLMODE", Value: sslMode},
+			{Name: "PGAPPNAME", Value: paradedb.Name},
+			{
+				Name: "PGUSER",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: replicaOf.CredentialsSecretRef,
+						Key:                  "username",
+					},
+				},
+			},
+			{
+				Name: "PGPASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: replicaOf.CredentialsSecretRef,
+						Key:                  "password",
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/postgresql/data"}},
+		Resources:    paradedb.Spec.Resources,
 	}
+}
 
-	// TLS configuration if enabled
-	if paradedb.IsTLSEnabled() {
-		config.WriteString("# TLS Configuration\n")
-		config.WriteString("ssl = on\n")
-		config.WriteString("ssl_cert_file = '/etc/postgresql/tls/tls.crt'\n")
-		config.WriteString("ssl_key_file = '/etc/postgresql/tls/tls.key'\n")
-		config.WriteString("ssl_ca_file = '/etc/postgresql/tls/ca.crt'\n\n")
+// applyClassDefaults fills in the settings the ParadeDB leaves unset from its class.
+// The result is only used for this reconcile and is never written back to the spec.
+func applyClassDefaults(paradedb *databasev1alpha1.ParadeDB, class *databasev1alpha1.ParadeDBClass) {
+	spec := &paradedb.Spec
+	if spec.Image == "" {
+		spec.Image = class.Spec.Image
+	}
+	if len(spec.Resources.Requests) == 0 && len(spec.Resources.Limits) == 0 {
+		spec.Resources = *class.Spec.Resources.DeepCopy()
+	}
+	if spec.Storage.StorageClassName == nil && class.Spec.StorageClassName != nil {
+		storageClassName := *class.Spec.StorageClassName
+		spec.Storage.StorageClassName = &storageClassName
+	}
+	if spec.Backup == nil && class.Spec.Backup != nil {
+		spec.Backup = class.Spec.Backup.DeepCopy()
+	}
+	if spec.Monitoring == nil && class.Spec.Monitoring != nil {
+		spec.Monitoring = class.Spec.Monitoring.DeepCopy()
+	}
+}
+
+// applyMonitoringDefault enables the metrics exporter for a ParadeDB that does not
+// configure monitoring, if the operator runs with monitoring enabled by default. Like the
+// class defaults, the result is only used for this reconcile.
+func applyMonitoringDefault(paradedb *databasev1alpha1.ParadeDB, enabledByDefault bool) {
+	if paradedb.Spec.Monitoring == nil && enabledByDefault {
+		paradedb.Spec.Monitoring = &databasev1alpha1.MonitoringSpec{Enabled: true}
+	}
+}
+
+// buildAffinity returns the affinity with the anti-affinity of spec.affinityPreset across
+// nodes for pods matching the selector labels. A podAntiAffinity in affinity is kept as is.
+func buildAffinity(paradedb *databasev1alpha1.ParadeDB, affinity *corev1.Affinity, selectorLabels map[string]string) *corev1.Affinity {
+	preset := paradedb.Spec.AffinityPreset
+	if preset != "soft" && preset != "hard" {
+		return affinity
+	}
+	if affinity != nil && affinity.PodAntiAffinity != nil {
+		return affinity
 	}
 
-	// Apply custom PostgreSQL configuration
-	if len(paradedb.Spec.PostgresConfig) > 0 {
-		config.WriteString("# Custom Configuration\n")
-		for key, value := range paradedb.Spec.PostgresConfig {
-			config.WriteString(fmt.Sprintf("%s = %s\n", key, value))
+	result := &corev1.Affinity{}
+	if affinity != nil {
+		result = affinity.DeepCopy()
+	}
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: selectorLabels},
+		TopologyKey:   corev1.LabelHostname,
+	}
+	if preset == "hard" {
+		result.PodAntiAffinity = &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{term},
+		}
+	} else {
+		result.PodAntiAffinity = &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{Weight: 100, PodAffinityTerm: term},
+			},
+		}
+	}
+	return result
+}
+
+// validateDNS rejects a None DNS policy without nameservers to use instead
+func validateDNS(paradedb *databasev1alpha1.ParadeDB) error {
+	if paradedb.Spec.DNSPolicy == corev1.DNSNone && (paradedb.Spec.DNSConfig == nil || len(paradedb.Spec.DNSConfig.Nameservers) == 0) {
+		return fmt.Errorf("spec.dnsPolicy None requires spec.dnsConfig.nameservers")
+	}
+	return nil
+}
+
+// reservedVolumeNames are the volumes the operator may add to the database pod
+var reservedVolumeNames = []string{"data", "config", "config-fragments", "wal-archive", "local-cache", "metrics-tls"}
+
+// reservedMountPaths are the paths the operator may mount in the database container
+var reservedMountPaths = []string{
+	"/var/lib/postgresql/data", "/docker-entrypoint-initdb.d", configMountPath,
+	configFragmentsMountPath, walArchiveMountPath, localCacheMountPath,
+}
+
+// validateExtraVolumes rejects extra volumes and mounts that collide with those of the
+// operator, which the API server would refuse on every update of the StatefulSet
+func validateExtraVolumes(paradedb *databasev1alpha1.ParadeDB) error {
+	for _, volume := range paradedb.Spec.ExtraVolumes {
+		if slices.Contains(reservedVolumeNames, volume.Name) {
+			return fmt.Errorf("spec.extraVolumes: the name %q is reserved for the operator", volume.Name)
+		}
+	}
+	for _, mount := range paradedb.Spec.ExtraVolumeMounts {
+		if slices.Contains(reservedVolumeNames, mount.Name) {
+			return fmt.Errorf("spec.extraVolumeMounts: the name %q is reserved for the operator", mount.Name)
+		}
+		if slices.Contains(reservedMountPaths, path.Clean(mount.MountPath)) {
+			return fmt.Errorf("spec.extraVolumeMounts: the mount path %q is reserved for the operator", mount.MountPath)
 		}
 	}
+	return nil
+}
+
+// applyDNS sets the host aliases and DNS settings of spec on a pod that has to resolve
+// hosts outside the cluster
+func applyDNS(paradedb *databasev1alpha1.ParadeDB, podSpec *corev1.PodSpec) {
+	podSpec.HostAliases = paradedb.Spec.HostAliases
+	podSpec.DNSPolicy = paradedb.Spec.DNSPolicy
+	podSpec.DNSConfig = paradedb.Spec.DNSConfig
+}
+
+// withArchitectureAffinity returns affinity with a required node affinity on the
+// kubernetes.io/arch label for spec.architectures. Node selector terms are ORed, so the
+// requirement is added to each of them.
+func withArchitectureAffinity(paradedb *databasev1alpha1.ParadeDB, affinity *corev1.Affinity) *corev1.Affinity {
+	if len(paradedb.Spec.Architectures) == 0 {
+		return affinity
+	}
+
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+	}
+	for _, architecture := range paradedb.Spec.Architectures {
+		requirement.Values = append(requirement.Values, string(architecture))
+	}
+
+	result := &corev1.Affinity{}
+	if affinity != nil {
+		result = affinity.DeepCopy()
+	}
+	if result.NodeAffinity == nil {
+		result.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		required = &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{{}}}
+		result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+	}
+	for i := range required.NodeSelectorTerms {
+		term := &required.NodeSelectorTerms[i]
+		term.MatchExpressions = append(term.MatchExpressions, requirement)
+	}
+	return result
+}
+
+// buildPodSecurityContext returns podSecurityContext with spec.sysctls added
+func buildPodSecurityContext(paradedb *databasev1alpha1.ParadeDB) *corev1.PodSecurityContext {
+	if len(paradedb.Spec.Sysctls) == 0 {
+		return paradedb.Spec.PodSecurityContext
+	}
+	securityContext := &corev1.PodSecurityContext{}
+	if paradedb.Spec.PodSecurityContext != nil {
+		securityContext = paradedb.Spec.PodSecurityContext.DeepCopy()
+	}
+	securityContext.Sysctls = append(securityContext.Sysctls, paradedb.Spec.Sysctls...)
+	return securityContext
+}
+
+// mergeMaps returns a copy of base overlaid with overrides; overrides win on conflicts
+func mergeMaps(base, overrides map[string]string) map[string]string {
+	if len(base) == 0 && len(overrides) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// withMetadata adds the user-supplied metadata to the operator's labels and annotations
+func withMetadata(metadata *databasev1alpha1.ResourceMetadata, labels, annotations map[string]string) (map[string]string, map[string]string) {
+	if metadata == nil {
+		return labels, annotations
+	}
+	return mergeMaps(metadata.Labels, labels), mergeMaps(metadata.Annotations, annotations)
+}
+
+// shortHash returns a short, stable hash of the given values, used to detect changes
+func shortHash(values ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(values, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// mergeEnv appends the user-supplied variables to the operator-managed ones, skipping
+// any that would override a variable the operator relies on
+func mergeEnv(managed []corev1.EnvVar, extra []corev1.EnvVar) []corev1.EnvVar {
+	names := make(map[string]bool, len(managed))
+	for _, env := range managed {
+		names[env.Name] = true
+	}
+
+	env := managed
+	for _, e := range extra {
+		if names[e.Name] {
+			continue
+		}
+		env = append(env, e)
+	}
+	return env
+}
+
+// applyPodTemplateOverrides applies spec.podTemplateOverrides to the pod template as a
+// strategic merge patch, keeping the selector labels the StatefulSet depends on
+func applyPodTemplateOverrides(paradedb *databasev1alpha1.ParadeDB, template *corev1.PodTemplateSpec, selectorLabels map[string]string) error {
+	overrides := paradedb.Spec.PodTemplateOverrides
+	if overrides == nil || len(overrides.Raw) == 0 {
+		return nil
+	}
+
+	original, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, overrides.Raw, corev1.PodTemplateSpec{})
+	if err != nil {
+		return fmt.Errorf("invalid spec.podTemplateOverrides: %w", err)
+	}
+
+	result := corev1.PodTemplateSpec{}
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return fmt.Errorf("invalid spec.podTemplateOverrides: %w", err)
+	}
+	result.Labels = mergeMaps(result.Labels, selectorLabels)
+	*template = result
+	return nil
+}
+
+// buildPoolerConnString returns the PgBouncer [databases] connection string routing a
+// database to the primary Service
+func buildPoolerConnString(paradedb *databasev1alpha1.ParadeDB, database databasev1alpha1.PoolerDatabaseSpec) string {
+	entry := fmt.Sprintf("host=%s port=%d dbname=%s",
+		paradedb.GetServiceName(), paradedb.GetPort(), database.Name)
+	if database.PoolSize > 0 {
+		entry += fmt.Sprintf(" pool_size=%d", database.PoolSize)
+	}
+	if database.PoolMode != "" {
+		entry += " pool_mode=" + database.PoolMode
+	}
+	return entry
+}
+
+// buildPoolerDatabaseEnv returns the PGBOUNCER_DSN_<n> variables through which the PgBouncer
+// image adds the databases beyond the application database, followed by the routes
+func buildPoolerDatabaseEnv(paradedb *databasev1alpha1.ParadeDB) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, database := range paradedb.GetPoolerDatabases() {
+		env = append(env, corev1.EnvVar{
+			Name:  fmt.Sprintf("PGBOUNCER_DSN_%d", len(env)),
+			Value: database.Name + "=" + buildPoolerConnString(paradedb, database),
+		})
+	}
+	for _, route := range paradedb.GetPoolerRoutes() {
+		env = append(env, corev1.EnvVar{
+			Name:  fmt.Sprintf("PGBOUNCER_DSN_%d", len(env)),
+			Value: route.Name + "=" + buildPoolerRouteConnString(paradedb, route),
+		})
+	}
+	return env
+}
+
+// buildUpdateStrategy returns the StatefulSet update strategy, defaulting to RollingUpdate
+func buildUpdateStrategy(paradedb *databasev1alpha1.ParadeDB) appsv1.StatefulSetUpdateStrategy {
+	if paradedb.Spec.UpdateStrategy == nil {
+		return appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		}
+	}
+	return *paradedb.Spec.UpdateStrategy.DeepCopy()
+}
+
+// buildLivenessProbe returns the liveness probe for the ParadeDB container
+func buildLivenessProbe(paradedb *databasev1alpha1.ParadeDB) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: buildLivenessCommand(),
+			},
+		},
+		InitialDelaySeconds: 30,
+		PeriodSeconds:       10,
+		TimeoutSeconds:      5,
+		FailureThreshold:    6,
+	}
+	if paradedb.Spec.Probes != nil {
+		applyProbeSpec(probe, paradedb.Spec.Probes.Liveness)
+	}
+	// Kubernetes requires a success threshold of 1 for liveness probes
+	probe.SuccessThreshold = 1
+	return probe
+}
+
+// buildReadinessProbe returns the readiness probe for the ParadeDB container
+func buildReadinessProbe(paradedb *databasev1alpha1.ParadeDB) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: buildReadinessCommand(paradedb),
+			},
+		},
+		InitialDelaySeconds: 5,
+		PeriodSeconds:       5,
+		TimeoutSeconds:      3,
+		FailureThreshold:    3,
+	}
+	if paradedb.Spec.Probes != nil {
+		applyProbeSpec(probe, paradedb.Spec.Probes.Readiness)
+	}
+	return probe
+}
+
+// buildStartupProbe returns the startup probe for the ParadeDB container. By default it
+// allows up to 10 minutes for startup and crash recovery before liveness checks begin.
+func buildStartupProbe(paradedb *databasev1alpha1.ParadeDB) *corev1.Probe {
+	probe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"pg_isready", "-U", "postgres"},
+			},
+		},
+		PeriodSeconds:    10,
+		TimeoutSeconds:   5,
+		FailureThreshold: 60,
+	}
+	if paradedb.Spec.Probes != nil {
+		applyProbeSpec(probe, paradedb.Spec.Probes.Startup)
+	}
+	// Kubernetes requires a success threshold of 1 for startup probes
+	probe.SuccessThreshold = 1
+	return probe
+}
+
+// buildLivenessCommand returns the liveness check. It only verifies that the server
+// answers queries, so a missing database or a lagging replica never triggers a restart.
+func buildLivenessCommand() []string {
+	return []string{"/bin/sh", "-c", `exec psql -U "$POSTGRES_USER" -d postgres -tAq -c "SELECT 1" >/dev/null`}
+}
+
+// buildExporterArgs returns the postgres_exporter flags that toggle collectors and
+// database discovery, in a stable order so the pod template does not churn
+func buildExporterArgs(paradedb *databasev1alpha1.ParadeDB) []string {
+	monitoring := paradedb.Spec.Monitoring
+	if monitoring == nil {
+		return nil
+	}
+
+	var args []string
+	for _, name := range slices.Sorted(maps.Keys(monitoring.Collectors)) {
+		if monitoring.Collectors[name] {
+			args = append(args, "--collector."+name)
+		} else {
+			args = append(args, "--no-collector."+name)
+		}
+	}
+	if monitoring.AutoDiscoverDatabases {
+		args = append(args, "--auto-discover-databases")
+	}
+	return append(args, monitoring.ExtraArgs...)
+}
+
+// buildReadinessCommand returns the readiness check. It runs against the configured
+// database as the configured user and depends on the role of the pod: a primary must
+// pass the readiness query and accept writes, while a replica must pass the replica
+// query, stream WAL when required and not lag further behind than allowed. The queries
+// and limits are passed as positional arguments so they need no shell quoting.
+func buildReadinessCommand(paradedb *databasev1alpha1.ParadeDB) []string {
+	script := `set -e
+query() { psql -U "$POSTGRES_USER" -d "$POSTGRES_DB" -tAq -v ON_ERROR_STOP=1 -c "$1"; }
+if [ "$(query "SELECT pg_is_in_recovery()")" = "f" ]; then
+  query "$1" >/dev/null
+  [ "$(query "SHOW transaction_read_only")" = "off" ]
+  exit 0
+fi
+query "$2" >/dev/null
+[ "$3" != "true" ] || [ "$(query "SELECT count(*) FROM pg_stat_wal_receiver WHERE status = 'streaming'")" -gt 0 ]
+[ "$4" -gt 0 ] || exit 0
+lag=$(query "SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)::int END")
+[ "$lag" -le "$4" ]`
+
+	requireStreaming := paradedb.Spec.Probes != nil && paradedb.Spec.Probes.RequireStreaming
+	// A standby replaying from an archive has no WAL receiver to compare against, so its
+	// lag would be the time since the primary last committed, which grows while it is idle
+	maxLag := paradedb.GetMaxReplicationLagSeconds()
+	if paradedb.IsArchiveReplica() {
+		maxLag = 0
+	}
+	return []string{
+		"/bin/sh", "-c", script, "readiness",
+		paradedb.GetReadinessQuery(),
+		paradedb.GetReplicaReadinessQuery(),
+		fmt.Sprintf("%t", requireStreaming),
+		fmt.Sprintf("%d", maxLag),
+	}
+}
+
+// applyProbeSpec overrides the probe timings that are set in the spec
+func applyProbeSpec(probe *corev1.Probe, spec *databasev1alpha1.ProbeSpec) {
+	if spec == nil {
+		return
+	}
+	if spec.InitialDelaySeconds != nil {
+		probe.InitialDelaySeconds = *spec.InitialDelaySeconds
+	}
+	if spec.PeriodSeconds != nil {
+		probe.PeriodSeconds = *spec.PeriodSeconds
+	}
+	if spec.TimeoutSeconds != nil {
+		probe.TimeoutSeconds = *spec.TimeoutSeconds
+	}
+	if spec.FailureThreshold != nil {
+		probe.FailureThreshold = *spec.FailureThreshold
+	}
+	if spec.SuccessThreshold != nil {
+		probe.SuccessThreshold = *spec.SuccessThreshold
+	}
+}
+
+// buildIncludeConfig generates the configuration file that includes the data directory's
+// postgresql.conf followed by the spec.postgresConfigFrom fragments
+func buildIncludeConfig(paradedb *databasev1alpha1.ParadeDB) string {
+	var config strings.Builder
+
+	config.WriteString("# ParadeDB PostgreSQL Configuration\n")
+	config.WriteString("# Generated by paradedb-operator\n\n")
+	config.WriteString("include '/var/lib/postgresql/data/pgdata/postgresql.conf'\n")
+	for _, selector := range paradedb.Spec.PostgresConfigFrom {
+		directive := "include"
+		if selector.Optional != nil && *selector.Optional {
+			directive = "include_if_exists"
+		}
+		path := configFragmentsMountPath + "/" + selector.Name + "/" + selector.Key
+		config.WriteString(fmt.Sprintf("%s '%s'\n", directive, strings.ReplaceAll(path, "'", "''")))
+	}
 
 	return config.String()
 }
 
-// buildPgHBAConfig generates the pg_hba.conf configuration
+// buildInitDBArgs returns the initdb arguments for spec.bootstrap.initdb. The image's
+// entrypoint evaluates them in a shell, so each one is quoted.
+func buildInitDBArgs(paradedb *databasev1alpha1.ParadeDB) string {
+	if paradedb.Spec.Bootstrap == nil || paradedb.Spec.Bootstrap.InitDB == nil {
+		return ""
+	}
+	initDB := paradedb.Spec.Bootstrap.InitDB
+
+	var args []string
+	if initDB.Locale != "" {
+		args = append(args, "--locale="+initDB.Locale)
+	}
+	if initDB.Encoding != "" {
+		args = append(args, "--encoding="+initDB.Encoding)
+	}
+	if initDB.DataChecksums {
+		args = append(args, "--data-checksums")
+	}
+	if initDB.WALSegmentSize > 0 {
+		args = append(args, fmt.Sprintf("--wal-segsize=%d", initDB.WALSegmentSize))
+	}
+	args = append(args, initDB.Args...)
+
+	for i, arg := range args {
+		args[i] = shellQuote(arg)
+	}
+	return strings.Join(args, " ")
+}
+
+// buildPgHBAConfig generates the pg_hba.conf configuration. PostgreSQL uses the first
+// matching rule, so custom rules come before the managed ones and take precedence.
 func buildPgHBAConfig(paradedb *databasev1alpha1.ParadeDB) string {
 	var config strings.Builder
 
 	config.WriteString("# ParadeDB pg_hba.conf\n")
 	config.WriteString("# Generated by paradedb-operator\n\n")
 
-	// Default rules
 	config.WriteString("# TYPE  DATABASE        USER            ADDRESS                 METHOD\n\n")
 
+	// Custom pg_hba entries
+	if len(paradedb.Spec.Auth.PgHBA) > 0 {
+		config.WriteString("# Custom rules\n")
+		for _, rule := range paradedb.Spec.Auth.PgHBA {
+			config.WriteString(rule + "\n")
+		}
+		config.WriteString("\n")
+	}
+
+	// Default rules
+
 	// Local connections
 	config.WriteString("# Local connections\n")
 	config.WriteString("local   all             all                                     trust\n")
@@ -143,7 +631,8 @@ func buildPgHBAConfig(paradedb *databasev1alpha1.ParadeDB) string {
 
 	// Remote connections
 	config.WriteString("# Remote connections\n")
-	if paradedb.IsTLSEnabled() {
+	// Mesh mTLS already encrypts remote connections, so TLS is optional on top of it
+	if paradedb.IsTLSEnabled() && !paradedb.IsMeshEncrypted() {
 		config.WriteString("hostssl all             all             0.0.0.0/0               scram-sha-256\n")
 		config.WriteString("hostssl all             all             ::/0                    scram-sha-256\n")
 	} else {
@@ -151,14 +640,6 @@ func buildPgHBAConfig(paradedb *databasev1alpha1.ParadeDB) string {
 		config.WriteString("host    all             all             ::/0                    scram-sha-256\n")
 	}
 
-	// Custom pg_hba entries
-	if len(paradedb.Spec.Auth.PgHBA) > 0 {
-		config.WriteString("\n# Custom rules\n")
-		for _, rule := range paradedb.Spec.Auth.PgHBA {
-			config.WriteString(rule + "\n")
-		}
-	}
-
 	return config.String()
 }
 
@@ -210,6 +691,11 @@ func buildInitScript(paradedb *databasev1alpha1.ParadeDB) string {
 		}
 	}
 
+	// Create additional databases last, as it switches the connected database
+	if databases := buildDatabasesScript(paradedb); databases != "" {
+		script.WriteString("\n" + databases)
+	}
+
 	script.WriteString("\n-- Initialization complete\n")
 
 	return script.String()
@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// operationsHistoryLimit bounds status.operationsHistory
+const operationsHistoryLimit = 20
+
+// recordOperation appends an operation to the history, dropping the oldest entries
+// beyond the limit. An operation that repeats the latest one of its type, such as a
+// configuration rejected again on the next reconciliation, is not recorded twice.
+// Returns true if the operation was recorded, so that callers only emit an Event once.
+func recordOperation(paradedb *databasev1alpha1.ParadeDB, operationType databasev1alpha1.OperationType,
+	outcome databasev1alpha1.OperationOutcome, message string) bool {
+	history := paradedb.Status.OperationsHistory
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].Type != operationType {
+			continue
+		}
+		if history[i].Outcome == outcome && history[i].Message == message {
+			return false
+		}
+		break
+	}
+
+	now := metav1.Now()
+	record := databasev1alpha1.OperationRecord{Type: operationType, Outcome: outcome, Message: message, StartTime: now}
+	if outcome != databasev1alpha1.OperationRunning {
+		record.CompletionTime = &now
+	}
+	history = append(history, record)
+	if len(history) > operationsHistoryLimit {
+		history = slices.Clone(history[len(history)-operationsHistoryLimit:])
+	}
+	paradedb.Status.OperationsHistory = history
+	return true
+}
+
+// completeRollouts marks the running pod rollouts as succeeded once every pod runs the
+// current revision and is ready, and returns the operations it completed
+func completeRollouts(paradedb *databasev1alpha1.ParadeDB) []databasev1alpha1.OperationRecord {
+	now := metav1.Now()
+	var completed []databasev1alpha1.OperationRecord
+	for i := range paradedb.Status.OperationsHistory {
+		record := &paradedb.Status.OperationsHistory[i]
+		if record.Outcome == databasev1alpha1.OperationRunning && record.Type != databasev1alpha1.OperationImport {
+			record.Outcome = databasev1alpha1.OperationSucceeded
+			record.CompletionTime = &now
+			completed = append(completed, *record)
+		}
+	}
+	return completed
+}
+
+// recordRollout records the pod template change an update to the StatefulSet rolls out,
+// given the template before and after the update. Returns the operation if one was
+// recorded rather than already running.
+func recordRollout(paradedb *databasev1alpha1.ParadeDB, before, after *corev1.PodTemplateSpec) *databasev1alpha1.OperationRecord {
+	if equality.Semantic.DeepEqual(before, after) {
+		return nil
+	}
+
+	operationType, message := databasev1alpha1.OperationRollout, "Rolling out pod template changes"
+	oldImage, newImage := getContainerImage(before), getContainerImage(after)
+	switch {
+	case oldImage != newImage:
+		operationType, message = databasev1alpha1.OperationUpgrade, "Updating from "+oldImage+" to "+newImage
+	case before.Annotations[restartedAtAnnotation] != after.Annotations[restartedAtAnnotation]:
+		operationType, message = databasev1alpha1.OperationRestart, "Restarting the pods"
+	}
+	if !recordOperation(paradedb, operationType, databasev1alpha1.OperationRunning, message) {
+		return nil
+	}
+	return &paradedb.Status.OperationsHistory[len(paradedb.Status.OperationsHistory)-1]
+}
+
+// emitOperationEvent emits an Event for an operation starting or finishing, with a
+// reason such as UpgradeStarted or RolloutCompleted
+func (r *ParadeDBReconciler) emitOperationEvent(paradedb *databasev1alpha1.ParadeDB, record databasev1alpha1.OperationRecord) {
+	eventType, reason := corev1.EventTypeNormal, string(record.Type)
+	switch record.Outcome {
+	case databasev1alpha1.OperationRunning:
+		reason += "Started"
+	case databasev1alpha1.OperationSucceeded:
+		reason += "Completed"
+	case databasev1alpha1.OperationFailed:
+		eventType, reason = corev1.EventTypeWarning, reason+"Failed"
+	}
+	r.Recorder.Event(paradedb, eventType, reason, record.Message)
+}
+
+// getContainerImage returns the image of the ParadeDB container
+func getContainerImage(template *corev1.PodTemplateSpec) string {
+	for _, container := range template.Spec.Containers {
+		if container.Name == "paradedb" {
+			return container.Image
+		}
+	}
+	return ""
+}
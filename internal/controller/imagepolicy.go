@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// Image tag policies the operator can apply to the images of every instance
+const (
+	// ImageTagPolicyWarn reports images on the latest tag without blocking the instance
+	ImageTagPolicyWarn = "Warn"
+	// ImageTagPolicyEnforce refuses to reconcile instances with images on the latest tag
+	ImageTagPolicyEnforce = "Enforce"
+)
+
+// ConditionTypeFloatingImageTags reports managed containers whose image is on the latest
+// tag under the Warn image tag policy
+const ConditionTypeFloatingImageTags = "FloatingImageTags"
+
+// getManagedImages returns the image of each container the operator runs for the instance,
+// by component
+func (r *ParadeDBReconciler) getManagedImages(paradedb *databasev1alpha1.ParadeDB) map[string]string {
+	images := map[string]string{"database": paradedb.GetImage()}
+	if paradedb.IsConnectionPoolingEnabled() {
+		images["pooler"] = r.getPoolerImage(paradedb)
+	}
+	if paradedb.IsMonitoringEnabled() {
+		images["exporter"] = r.getExporterImage(paradedb)
+		if paradedb.HasSQLMetrics() {
+			images["sql-exporter"] = r.getSQLExporterImage(paradedb)
+		}
+	}
+	if usesBackupImage(paradedb) {
+		images["backup"] = r.getBackupImage()
+	}
+	if paradedb.Spec.CleanupPolicy != nil && paradedb.Spec.CleanupPolicy.Vault != nil {
+		images["vault"] = r.getVaultImage(paradedb)
+	}
+	return images
+}
+
+// usesBackupImage returns true if the instance runs the backup image: to take or prune
+// backups in S3, to restore from them, to bootstrap a replica cluster from an archive or
+// to delete its backups on deletion
+func usesBackupImage(paradedb *databasev1alpha1.ParadeDB) bool {
+	if paradedb.IsArchiveReplica() {
+		return true
+	}
+	if paradedb.Spec.Backup == nil || paradedb.Spec.Backup.S3 == nil {
+		return false
+	}
+	policy := paradedb.Spec.CleanupPolicy
+	return paradedb.IsBackupEnabled() || paradedb.Spec.Restore != nil || (policy != nil && policy.DeleteBackups)
+}
+
+// isFloatingTag returns true if the image is neither pinned by digest nor tagged other
+// than latest
+func isFloatingTag(image string) bool {
+	if hasDigest(image) {
+		return false
+	}
+	_, tag := splitImageTag(image)
+	return tag == "latest"
+}
+
+// checkImageTagPolicy applies the operator's image tag policy to the instance. Under
+// Enforce it returns an error naming the images on the latest tag; under Warn it reports
+// them in a condition and an event instead.
+func (r *ParadeDBReconciler) checkImageTagPolicy(paradedb *databasev1alpha1.ParadeDB) error {
+	var floating []string
+	if r.ImageTagPolicy != "" {
+		for component, image := range r.getManagedImages(paradedb) {
+			if isFloatingTag(image) {
+				floating = append(floating, fmt.Sprintf("%s (%s)", component, image))
+			}
+		}
+	}
+	if len(floating) == 0 {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeFloatingImageTags)
+		return nil
+	}
+	sort.Strings(floating)
+	message := "images on the latest tag: " + strings.Join(floating, ", ")
+
+	if r.ImageTagPolicy == ImageTagPolicyEnforce {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeFloatingImageTags)
+		return fmt.Errorf("%s; pin a version tag or digest", message)
+	}
+
+	if current := meta.FindStatusCondition(paradedb.Status.Conditions, ConditionTypeFloatingImageTags); current == nil || current.Message != message {
+		r.Recorder.Event(paradedb, corev1.EventTypeWarning, "FloatingImageTag", message)
+	}
+	setCondition(paradedb, ConditionTypeFloatingImageTags, metav1.ConditionTrue, "FloatingTag", message)
+	return nil
+}
@@ -0,0 +1,484 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// configMountPath is where the configuration ConfigMap is mounted in the ParadeDB
+	// container. The whole directory is mounted so that updates reach running pods.
+	configMountPath = "/etc/postgresql/config"
+
+	// hbaFilePath is the pg_hba.conf PostgreSQL is started with
+	hbaFilePath = configMountPath + "/pg_hba.conf"
+
+	// includeConfigKey is the ConfigMap key of the configuration file PostgreSQL is
+	// started with when spec.postgresConfigFrom is set
+	includeConfigKey = "postgresql.include.conf"
+
+	// configFragmentsMountPath is where the spec.postgresConfigFrom keys are mounted
+	configFragmentsMountPath = "/etc/postgresql/conf.d"
+)
+
+// promoteWaitSeconds is how long pg_promote waits for a standby to finish promotion, within
+// databaseStatementTimeout
+const promoteWaitSeconds = 20
+
+// errConfigRejected is returned when PostgreSQL reports errors in the configuration files
+var errConfigRejected = errors.New("configuration rejected")
+
+// instanceClient runs SQL on individual ParadeDB pods. Each pod runs its own postmaster,
+// so operations such as configuration reloads have to reach every pod rather than
+// whichever one the primary Service routes to.
+type instanceClient struct {
+	paradedb *databasev1alpha1.ParadeDB
+	username string
+	password string
+}
+
+// newInstanceClient returns an instanceClient that connects with the superuser credentials
+func (r *ParadeDBReconciler) newInstanceClient(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (*instanceClient, error) {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	return &instanceClient{paradedb: paradedb, username: username, password: password}, nil
+}
+
+// withPod connects to the application database on the given pod by its IP
+func (c *instanceClient) withPod(ctx context.Context, pod *corev1.Pod, fn func(ctx context.Context, db *sql.DB) error) error {
+	connectionURL := buildHostURL(c.paradedb, pod.Status.PodIP, c.username, c.password, c.paradedb.Spec.Auth.Database)
+	return withDatabase(ctx, connectionURL, fn)
+}
+
+// reload makes the postmaster on the pod re-read its configuration files once they
+// match the expected contents, keyed by path. Files that PostgreSQL reports errors in
+// are not reloaded, since the old settings would silently stay in effect.
+func (c *instanceClient) reload(ctx context.Context, pod *corev1.Pod, files map[string]string) error {
+	return c.withPod(ctx, pod, func(ctx context.Context, db *sql.DB) error {
+		for _, path := range slices.Sorted(maps.Keys(files)) {
+			var current string
+			if err := db.QueryRowContext(ctx, "SELECT pg_read_file($1)", path).Scan(&current); err != nil {
+				return fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			if current != files[path] {
+				return fmt.Errorf("%s has not been updated on pod %s yet", path, pod.Name)
+			}
+		}
+
+		var problems sql.NullString
+		if err := db.QueryRowContext(ctx, `SELECT string_agg(problem, '; ') FROM (
+  SELECT format('%s line %s: %s', file_name, line_number, error) AS problem FROM pg_hba_file_rules WHERE error IS NOT NULL
+  UNION ALL
+  SELECT format('%s line %s: %s', sourcefile, sourceline, error) FROM pg_file_settings WHERE error IS NOT NULL
+) problems`).Scan(&problems); err != nil {
+			return err
+		}
+		if problems.Valid {
+			return fmt.Errorf("%w on pod %s: %s", errConfigRejected, pod.Name, problems.String)
+		}
+
+		_, err := db.ExecContext(ctx, "SELECT pg_reload_conf()")
+		return err
+	})
+}
+
+// configDrift returns the settings from postgresql.auto.conf, written by ALTER SYSTEM,
+// that are in effect on the pod or waiting for a restart, as name=value. Settings the
+// operator passes on the command line take precedence and are not reported.
+func (c *instanceClient) configDrift(ctx context.Context, pod *corev1.Pod) (map[string]string, error) {
+	var drift map[string]string
+	err := c.withPod(ctx, pod, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		drift, err = queryConfigDrift(ctx, db)
+		return err
+	})
+	return drift, err
+}
+
+// queryConfigDrift runs the query behind configDrift on an open connection
+func queryConfigDrift(ctx context.Context, db *sql.DB) (map[string]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT f.name, f.setting
+FROM pg_file_settings f JOIN pg_settings s ON s.name = f.name
+WHERE f.sourcefile = current_setting('data_directory') || '/postgresql.auto.conf'
+  AND f.error IS NULL AND (s.sourcefile = f.sourcefile OR s.pending_restart)`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	drift := map[string]string{}
+	for rows.Next() {
+		var name, setting string
+		if err := rows.Scan(&name, &setting); err != nil {
+			return nil, err
+		}
+		drift[name] = setting
+	}
+	return drift, rows.Err()
+}
+
+// resetDrift removes the given settings from postgresql.auto.conf on the pod and reloads,
+// so that the configuration from the spec applies again
+func (c *instanceClient) resetDrift(ctx context.Context, pod *corev1.Pod, names []string) error {
+	return c.withPod(ctx, pod, func(ctx context.Context, db *sql.DB) error {
+		return resetSettings(ctx, db, names)
+	})
+}
+
+// resetSettings runs ALTER SYSTEM RESET for each setting and reloads the configuration
+func resetSettings(ctx context.Context, db *sql.DB, names []string) error {
+	for _, name := range names {
+		if _, err := db.ExecContext(ctx, "ALTER SYSTEM RESET "+quoteQualifiedName(name)); err != nil {
+			return fmt.Errorf("failed to reset %s: %w", name, err)
+		}
+	}
+	_, err := db.ExecContext(ctx, "SELECT pg_reload_conf()")
+	return err
+}
+
+// promote ends recovery on the pod with pg_promote, which switches to a new timeline and
+// removes standby.signal, so the pod becomes a primary without a restart. Returns false if
+// the pod was not in recovery.
+func (c *instanceClient) promote(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	promoted := false
+	err := c.withPod(ctx, pod, func(ctx context.Context, db *sql.DB) error {
+		var inRecovery bool
+		if err := db.QueryRowContext(ctx, "SELECT pg_is_in_recovery()").Scan(&inRecovery); err != nil {
+			return err
+		}
+		if !inRecovery {
+			return nil
+		}
+		if err := db.QueryRowContext(ctx, "SELECT pg_promote(true, $1)", promoteWaitSeconds).Scan(&promoted); err != nil {
+			return fmt.Errorf("failed to promote pod %s: %w", pod.Name, err)
+		}
+		if !promoted {
+			return fmt.Errorf("pod %s did not finish promotion within %ds", pod.Name, promoteWaitSeconds)
+		}
+		return nil
+	})
+	return promoted, err
+}
+
+// pendingRestart returns the settings the postmaster on the pod has read but can only
+// apply on restart
+func (c *instanceClient) pendingRestart(ctx context.Context, pod *corev1.Pod) ([]string, error) {
+	var parameters []string
+	err := c.withPod(ctx, pod, func(ctx context.Context, db *sql.DB) error {
+		var err error
+		parameters, err = queryPendingRestart(ctx, db)
+		return err
+	})
+	return parameters, err
+}
+
+// queryPendingRestart returns the settings waiting for a restart on the database
+func queryPendingRestart(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM pg_settings WHERE pending_restart ORDER BY name")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	var parameters []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, name)
+	}
+	return parameters, rows.Err()
+}
+
+// listReadyPods returns the instance's pods that are ready and have an IP
+func (r *ParadeDBReconciler) listReadyPods(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) ([]corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(paradedb.Namespace), client.MatchingLabels(r.getSelectorLabels(paradedb))); err != nil {
+		return nil, err
+	}
+	var ready []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP != "" && isPodReady(&pod) {
+			ready = append(ready, pod)
+		}
+	}
+	return ready, nil
+}
+
+// isPodReady returns true if the pod's Ready condition is true
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// promoteReplicaCluster promotes the running pods of a replica cluster that is set to be
+// promoted but still in recovery. Pods need not be ready, since a standby that requires
+// streaming turns unready once the primary it fails over from is gone.
+func (r *ParadeDBReconciler) promoteReplicaCluster(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	log := logf.FromContext(ctx)
+
+	if paradedb.Spec.ReplicaOf == nil || !paradedb.Spec.ReplicaOf.Promote {
+		return
+	}
+	instances, err := r.newInstanceClient(ctx, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to promote the replica cluster")
+		return
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(paradedb.Namespace), client.MatchingLabels(r.getSelectorLabels(paradedb))); err != nil {
+		log.Error(err, "Failed to list pods for promotion")
+		return
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.PodIP == "" || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		promoted, err := instances.promote(ctx, pod)
+		if err != nil {
+			if recordOperation(paradedb, databasev1alpha1.OperationPromotion, databasev1alpha1.OperationFailed, err.Error()) {
+				r.Recorder.Event(paradedb, corev1.EventTypeWarning, "PromotionFailed", err.Error())
+			}
+			// Retried on the next reconciliation
+			log.Info("Pod not promoted", "pod", pod.Name, "reason", err.Error())
+			continue
+		}
+		if promoted {
+			message := fmt.Sprintf("Promoted pod %s to primary", pod.Name)
+			recordOperation(paradedb, databasev1alpha1.OperationPromotion, databasev1alpha1.OperationSucceeded, message)
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "PromotionCompleted", message)
+		}
+	}
+}
+
+// getReloadableFiles returns the configuration files PostgreSQL re-reads on reload, keyed
+// by their path in the pod: pg_hba.conf and the spec.postgresConfigFrom fragments
+func (r *ParadeDBReconciler) getReloadableFiles(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (map[string]string, error) {
+	files := map[string]string{hbaFilePath: buildPgHBAConfig(paradedb)}
+	for _, selector := range paradedb.Spec.PostgresConfigFrom {
+		configMap := &corev1.ConfigMap{}
+		err := r.Get(ctx, types.NamespacedName{Name: selector.Name, Namespace: paradedb.Namespace}, configMap)
+		if apierrors.IsNotFound(err) && selector.Optional != nil && *selector.Optional {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if content, ok := configMap.Data[selector.Key]; ok {
+			files[configFragmentsMountPath+"/"+selector.Name+"/"+selector.Key] = content
+		}
+	}
+	return files, nil
+}
+
+// reloadConfiguration reloads every ready pod once changes to pg_hba.conf or the
+// configuration fragments have reached it, instead of restarting the pods
+func (r *ParadeDBReconciler) reloadConfiguration(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	log := logf.FromContext(ctx)
+
+	if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+		return
+	}
+	files, err := r.getReloadableFiles(ctx, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to collect configuration files")
+		return
+	}
+	var contents []string
+	for _, path := range slices.Sorted(maps.Keys(files)) {
+		contents = append(contents, path, files[path])
+	}
+	hash := shortHash(contents...)
+	if paradedb.Status.ConfigHash == hash {
+		return
+	}
+
+	instances, err := r.newInstanceClient(ctx, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to reload configuration")
+		return
+	}
+	pods, err := r.listReadyPods(ctx, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to list pods for configuration reload")
+		return
+	}
+	for i := range pods {
+		if err := instances.reload(ctx, &pods[i], files); err != nil {
+			if errors.Is(err, errConfigRejected) &&
+				recordOperation(paradedb, databasev1alpha1.OperationConfigReload, databasev1alpha1.OperationFailed, err.Error()) {
+				r.Recorder.Event(paradedb, corev1.EventTypeWarning, "ConfigRejected", err.Error())
+			}
+			// Retried on the next reconciliation
+			log.Info("Configuration not reloaded", "pod", pods[i].Name, "reason", err.Error())
+			return
+		}
+	}
+
+	paradedb.Status.ConfigHash = hash
+	r.Recorder.Event(paradedb, corev1.EventTypeNormal, "ConfigReloaded", "Reloaded the configuration on all ready pods")
+	recordOperation(paradedb, databasev1alpha1.OperationConfigReload, databasev1alpha1.OperationSucceeded,
+		"Reloaded the configuration on all ready pods")
+}
+
+// setPendingRestartCondition collects the settings that any ready pod is waiting to
+// restart for, e.g. after a configuration fragment changed shared_buffers
+func (r *ParadeDBReconciler) setPendingRestartCondition(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	log := logf.FromContext(ctx)
+
+	if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+		return
+	}
+	instances, err := r.newInstanceClient(ctx, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to check for pending restarts")
+		return
+	}
+	pods, err := r.listReadyPods(ctx, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to list pods for pending restarts")
+		return
+	}
+	checkPendingRestart(ctx, paradedb, instances, pods)
+}
+
+// pendingRestartClient reads the settings waiting for a restart on a pod. It is
+// implemented by instanceClient.
+type pendingRestartClient interface {
+	pendingRestart(ctx context.Context, pod *corev1.Pod) ([]string, error)
+}
+
+// checkPendingRestart sets the PendingRestart condition from the settings the pods are
+// waiting to restart for
+func checkPendingRestart(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, instances pendingRestartClient, pods []corev1.Pod) {
+	pending := map[string]bool{}
+	for i := range pods {
+		parameters, err := instances.pendingRestart(ctx, &pods[i])
+		if err != nil {
+			setCondition(paradedb, ConditionTypePendingRestart, metav1.ConditionUnknown, "QueryFailed",
+				fmt.Sprintf("Failed to query pod %s: %v", pods[i].Name, err))
+			return
+		}
+		for _, parameter := range parameters {
+			pending[parameter] = true
+		}
+	}
+
+	paradedb.Status.PendingRestartParameters = slices.Sorted(maps.Keys(pending))
+	if len(pending) > 0 {
+		setCondition(paradedb, ConditionTypePendingRestart, metav1.ConditionTrue, "RestartRequired",
+			fmt.Sprintf("Restart the pods to apply %s", strings.Join(paradedb.Status.PendingRestartParameters, ", ")))
+	} else {
+		setCondition(paradedb, ConditionTypePendingRestart, metav1.ConditionFalse, "ConfigurationApplied",
+			"The running configuration matches the configuration files")
+	}
+}
+
+// configDriftClient reads and reverts the settings changed with ALTER SYSTEM on a pod.
+// It is implemented by instanceClient.
+type configDriftClient interface {
+	configDrift(ctx context.Context, pod *corev1.Pod) (map[string]string, error)
+	resetDrift(ctx context.Context, pod *corev1.Pod, names []string) error
+}
+
+// setConfigDriftCondition reports settings changed with ALTER SYSTEM on any ready pod, and
+// reverts them with spec.enforceConfig
+func (r *ParadeDBReconciler) setConfigDriftCondition(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	log := logf.FromContext(ctx)
+
+	if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+		return
+	}
+	instances, err := r.newInstanceClient(ctx, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to check for configuration drift")
+		return
+	}
+	pods, err := r.listReadyPods(ctx, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to list pods for configuration drift")
+		return
+	}
+	r.checkConfigDrift(ctx, paradedb, instances, pods)
+}
+
+// checkConfigDrift sets the ConfigDrift condition from the settings changed with ALTER
+// SYSTEM on the pods. With spec.enforceConfig they are reverted and not reported.
+func (r *ParadeDBReconciler) checkConfigDrift(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, instances configDriftClient, pods []corev1.Pod) {
+	log := logf.FromContext(ctx)
+
+	drifted := map[string]string{}
+	for i := range pods {
+		drift, err := instances.configDrift(ctx, &pods[i])
+		if err != nil {
+			setCondition(paradedb, ConditionTypeConfigDrift, metav1.ConditionUnknown, "QueryFailed",
+				fmt.Sprintf("Failed to query pod %s: %v", pods[i].Name, err))
+			return
+		}
+		if len(drift) > 0 && paradedb.Spec.EnforceConfig {
+			names := slices.Sorted(maps.Keys(drift))
+			if err := instances.resetDrift(ctx, &pods[i], names); err != nil {
+				setCondition(paradedb, ConditionTypeConfigDrift, metav1.ConditionTrue, "RevertFailed",
+					fmt.Sprintf("Failed to revert %s on pod %s: %v", strings.Join(names, ", "), pods[i].Name, err))
+				return
+			}
+			message := fmt.Sprintf("Reverted %s changed with ALTER SYSTEM on pod %s", strings.Join(names, ", "), pods[i].Name)
+			log.Info(message)
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "ConfigDriftReverted", message)
+			continue
+		}
+		for name, setting := range drift {
+			drifted[name] = setting
+		}
+	}
+
+	paradedb.Status.DriftedParameters = slices.Sorted(maps.Keys(drifted))
+	if len(drifted) == 0 {
+		setCondition(paradedb, ConditionTypeConfigDrift, metav1.ConditionFalse, "InSync",
+			"No settings were changed with ALTER SYSTEM")
+		return
+	}
+	settings := make([]string, 0, len(drifted))
+	for _, name := range paradedb.Status.DriftedParameters {
+		settings = append(settings, name+"="+drifted[name])
+	}
+	setCondition(paradedb, ConditionTypeConfigDrift, metav1.ConditionTrue, "ChangedOutOfBand",
+		fmt.Sprintf("Changed with ALTER SYSTEM: %s", strings.Join(settings, ", ")))
+}
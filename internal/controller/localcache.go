@@ -0,0 +1,159 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// ConditionTypeLocalCacheReady reports whether the temp tablespace on the node-local
+	// volume exists
+	ConditionTypeLocalCacheReady = "LocalCacheReady"
+
+	// localCacheMountPath is where the node-local volume is mounted
+	localCacheMountPath = "/var/lib/postgresql/local-cache"
+
+	// localCacheLocation is the tablespace directory. A subdirectory, as PostgreSQL has to
+	// own it and the mount point of an emptyDir belongs to root.
+	localCacheLocation = localCacheMountPath + "/tablespace"
+
+	// localCacheTablespace is the temp tablespace on the node-local volume
+	localCacheTablespace = "local_cache"
+)
+
+// buildLocalCacheVolume returns the node-local volume, an emptyDir or, with a storage
+// class, a generic ephemeral volume
+func buildLocalCacheVolume(paradedb *databasev1alpha1.ParadeDB) corev1.Volume {
+	cache := paradedb.Spec.Storage.LocalCache
+	if cache.StorageClassName == nil {
+		return corev1.Volume{
+			Name: "local-cache",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{Medium: cache.Medium, SizeLimit: cache.SizeLimit},
+			},
+		}
+	}
+
+	size := resource.MustParse("10Gi")
+	if cache.SizeLimit != nil {
+		size = *cache.SizeLimit
+	}
+	return corev1.Volume{
+		Name: "local-cache",
+		VolumeSource: corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						StorageClassName: cache.StorageClassName,
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// buildLocalCacheContainer returns the init container that prepares the tablespace
+// directory on the node-local volume. The volume starts out empty on every new pod, so
+// once the tablespace exists its version directory has to be recreated, or PostgreSQL
+// fails to create temporary files there.
+func buildLocalCacheContainer(paradedb *databasev1alpha1.ParadeDB) corev1.Container {
+	script := `set -eu
+mkdir -p "$LOCATION"
+[ "$(id -u)" != "0" ] || chown postgres:postgres "$LOCATION"
+chmod 700 "$LOCATION"
+[ -s "$PGDATA/PG_VERSION" ] || exit 0
+for link in "$PGDATA"/pg_tblspc/*; do
+  if [ -L "$link" ] && [ "$(readlink "$link")" = "$LOCATION" ]; then
+    catalog=$(pg_controldata "$PGDATA" | awk -F: '/Catalog version number/ {gsub(/ /, "", $2); print $2}')
+    dir="$LOCATION/PG_$(cat "$PGDATA/PG_VERSION")_$catalog"
+    mkdir -p "$dir"
+    [ "$(id -u)" != "0" ] || chown postgres:postgres "$dir"
+  fi
+done`
+
+	return corev1.Container{
+		Name:            "local-cache",
+		Image:           paradedb.GetImage(),
+		ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", script},
+		Env: []corev1.EnvVar{
+			{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+			{Name: "LOCATION", Value: localCacheLocation},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "data", MountPath: "/var/lib/postgresql/data"},
+			{Name: "local-cache", MountPath: localCacheMountPath},
+		},
+		SecurityContext: paradedb.Spec.ContainerSecurityContext,
+	}
+}
+
+// setLocalCacheReadyCondition creates the temp tablespace once the database is reachable.
+// Until then temp_tablespaces names a tablespace that does not exist, which PostgreSQL
+// skips in favor of the data directory.
+func (r *ParadeDBReconciler) setLocalCacheReadyCondition(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+		setCondition(paradedb, ConditionTypeLocalCacheReady, metav1.ConditionUnknown, "DatabaseUnreachable",
+			"Waiting for the database to accept connections")
+		return
+	}
+
+	if err := r.reconcileLocalCacheTablespace(ctx, paradedb); err != nil {
+		setCondition(paradedb, ConditionTypeLocalCacheReady, metav1.ConditionFalse, "TablespaceFailed",
+			fmt.Sprintf("Failed to create tablespace %s: %v", localCacheTablespace, err))
+		return
+	}
+	setCondition(paradedb, ConditionTypeLocalCacheReady, metav1.ConditionTrue, "TablespaceReady",
+		fmt.Sprintf("Temporary files go to tablespace %s on the node-local volume", localCacheTablespace))
+}
+
+// reconcileLocalCacheTablespace creates the temp tablespace on the node-local volume
+func (r *ParadeDBReconciler) reconcileLocalCacheTablespace(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	return withDatabase(ctx, buildConnectionURL(paradedb, username, password), func(ctx context.Context, db *sql.DB) error {
+		var exists bool
+		if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_tablespace WHERE spcname = $1)",
+			localCacheTablespace).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return nil
+		}
+		_, err := db.ExecContext(ctx, fmt.Sprintf("CREATE TABLESPACE %s LOCATION %s",
+			pq.QuoteIdentifier(localCacheTablespace), pq.QuoteLiteral(localCacheLocation)))
+		return err
+	})
+}
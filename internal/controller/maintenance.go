@@ -0,0 +1,214 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// autovacuumProfiles are the settings of each spec.maintenance.autovacuumProfile
+var autovacuumProfiles = map[string][]string{
+	// Vacuum and analyze after a small share of a table changes, with a cost limit that
+	// lets autovacuum keep up with heavy writes to search indexes
+	"aggressive": {
+		"autovacuum_naptime=15s",
+		"autovacuum_vacuum_scale_factor=0.02",
+		"autovacuum_vacuum_insert_scale_factor=0.05",
+		"autovacuum_analyze_scale_factor=0.02",
+		"autovacuum_vacuum_cost_delay=2ms",
+		"autovacuum_vacuum_cost_limit=2000",
+	},
+	// Keep autovacuum in the background, relying on the maintenance window for the rest
+	"throttled": {
+		"autovacuum_naptime=5min",
+		"autovacuum_vacuum_cost_delay=20ms",
+		"autovacuum_vacuum_cost_limit=100",
+	},
+}
+
+// validateMaintenance rejects table maintenance that has no window to run in
+func validateMaintenance(paradedb *databasev1alpha1.ParadeDB) error {
+	maintenance := paradedb.Spec.Maintenance
+	if maintenance == nil || len(maintenance.Tables) == 0 {
+		return nil
+	}
+	if maintenance.Window == nil {
+		return fmt.Errorf("spec.maintenance.tables requires spec.maintenance.window")
+	}
+	if _, err := time.Parse("15:04", maintenance.Window.StartTime); err != nil {
+		return fmt.Errorf("spec.maintenance.window.startTime: %w", err)
+	}
+	return nil
+}
+
+// buildAutovacuumArgs returns the postgres arguments for spec.maintenance.autovacuumProfile
+func buildAutovacuumArgs(paradedb *databasev1alpha1.ParadeDB) []string {
+	var args []string
+	for _, setting := range autovacuumProfiles[paradedb.GetAutovacuumProfile()] {
+		args = append(args, "-c", setting)
+	}
+	return args
+}
+
+// getMaintenanceTableDatabase returns the database the table is in
+func getMaintenanceTableDatabase(paradedb *databasev1alpha1.ParadeDB, table databasev1alpha1.MaintenanceTableSpec) string {
+	if table.Database == "" {
+		return paradedb.Spec.Auth.Database
+	}
+	return table.Database
+}
+
+// buildMaintenanceScript returns the psql script that maintains the tables, connecting to
+// each table's database in turn. Operations run in a fixed order so that ANALYZE sees
+// the table after VACUUM.
+func buildMaintenanceScript(paradedb *databasev1alpha1.ParadeDB) string {
+	quote := strings.NewReplacer(`\`, `\\`, `'`, `''`)
+
+	var script strings.Builder
+	database := ""
+	for _, table := range paradedb.GetMaintenanceTables() {
+		if db := getMaintenanceTableDatabase(paradedb, table); db != database {
+			database = db
+			fmt.Fprintf(&script, "\\connect '%s'\n", quote.Replace(database))
+		}
+
+		name := quoteQualifiedName(table.Table)
+		vacuum := slices.Contains(table.Operations, databasev1alpha1.MaintenanceOperationVacuum)
+		analyze := slices.Contains(table.Operations, databasev1alpha1.MaintenanceOperationAnalyze)
+		switch {
+		case vacuum && analyze:
+			fmt.Fprintf(&script, "VACUUM (ANALYZE) %s;\n", name)
+		case vacuum:
+			fmt.Fprintf(&script, "VACUUM %s;\n", name)
+		case analyze:
+			fmt.Fprintf(&script, "ANALYZE %s;\n", name)
+		}
+		if slices.Contains(table.Operations, databasev1alpha1.MaintenanceOperationReindex) {
+			fmt.Fprintf(&script, "REINDEX TABLE CONCURRENTLY %s;\n", name)
+		}
+	}
+	return script.String()
+}
+
+// buildMaintenanceSchedule returns the cron schedule that starts at the beginning of the
+// daily window
+func buildMaintenanceSchedule(window *databasev1alpha1.MaintenanceWindowSpec) string {
+	start, _ := time.Parse("15:04", window.StartTime)
+	return fmt.Sprintf("%d %d * * *", start.Minute(), start.Hour())
+}
+
+// getMaintenanceWindowDuration returns the length of the window
+func getMaintenanceWindowDuration(window *databasev1alpha1.MaintenanceWindowSpec) time.Duration {
+	if window.Duration.Duration == 0 {
+		return 2 * time.Hour
+	}
+	return window.Duration.Duration
+}
+
+// buildMaintenanceCronJob returns the CronJob that maintains the tables in the window.
+// The Job's deadline ends it with the window, so a long VACUUM is cancelled rather than
+// running into business hours.
+func (r *ParadeDBReconciler) buildMaintenanceCronJob(paradedb *databasev1alpha1.ParadeDB) *batchv1.CronJob {
+	window := paradedb.Spec.Maintenance.Window
+	env := append(buildClientEnv(paradedb),
+		corev1.EnvVar{Name: "PGDATABASE", Value: paradedb.Spec.Auth.Database},
+		corev1.EnvVar{Name: "SCRIPT", Value: buildMaintenanceScript(paradedb)},
+	)
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy:    corev1.RestartPolicyNever,
+		ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+		Affinity:         withArchitectureAffinity(paradedb, nil),
+		Containers: []corev1.Container{{
+			Name:            "psql",
+			Image:           paradedb.GetImage(),
+			ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+			Command:         []string{"sh", "-c", `printf '%s\n' "$SCRIPT" | psql -X -v ON_ERROR_STOP=1 -f -`},
+			Env:             env,
+		}},
+	}
+	applyServiceAccount(paradedb, &podSpec)
+
+	timeZone := "Etc/UTC"
+	deadline := int64(getMaintenanceWindowDuration(window).Seconds())
+	backoffLimit := int32(0)
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      paradedb.GetMaintenanceCronJobName(),
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          buildMaintenanceSchedule(window),
+			TimeZone:          &timeZone,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: r.getLabels(paradedb)},
+				Spec: batchv1.JobSpec{
+					ActiveDeadlineSeconds: &deadline,
+					BackoffLimit:          &backoffLimit,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels:      map[string]string{"app.kubernetes.io/component": "maintenance"},
+							Annotations: buildJobServiceMeshAnnotations(paradedb),
+						},
+						Spec: podSpec,
+					},
+				},
+			},
+		},
+	}
+}
+
+// reconcileMaintenance keeps the table maintenance CronJob up to date. A standby cannot
+// vacuum and gets the results from the primary.
+func (r *ParadeDBReconciler) reconcileMaintenance(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	if paradedb.IsStandby() {
+		return nil
+	}
+
+	desired := r.buildMaintenanceCronJob(paradedb)
+	cronJob := &batchv1.CronJob{}
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: paradedb.Namespace}, cronJob)
+	if apierrors.IsNotFound(err) {
+		log.Info("Creating maintenance CronJob", "name", desired.Name)
+		if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, desired)
+	} else if err != nil {
+		return err
+	}
+	cronJob.Spec = desired.Spec
+	return r.Update(ctx, cronJob)
+}
@@ -0,0 +1,432 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// exporterContainerName is the name of the metrics exporter container
+	exporterContainerName = "postgres-exporter"
+
+	// exporterWebConfigKey is the key of the exporter's web configuration in the ConfigMap
+	exporterWebConfigKey = "exporter-web-config.yml"
+
+	// exporterConfigMountPath is where the exporter mounts the ConfigMap
+	exporterConfigMountPath = "/etc/postgres-exporter/config"
+
+	// exporterTLSMountPath is where the exporter mounts its serving certificate
+	exporterTLSMountPath = "/etc/postgres-exporter/tls"
+)
+
+// getExporterImage returns the postgres_exporter image of the instance
+func (r *ParadeDBReconciler) getExporterImage(paradedb *databasev1alpha1.ParadeDB) string {
+	if paradedb.Spec.Monitoring != nil && paradedb.Spec.Monitoring.Image != "" {
+		return paradedb.Spec.Monitoring.Image
+	}
+	return r.mirrorImage("quay.io/prometheuscommunity/postgres-exporter:latest")
+}
+
+// buildExporterContainer returns the postgres_exporter container connecting to the given
+// host, and the volumes it needs
+func (r *ParadeDBReconciler) buildExporterContainer(paradedb *databasev1alpha1.ParadeDB, host, sslMode string) (corev1.Container, []corev1.Volume) {
+	exporter := corev1.Container{
+		Name:            exporterContainerName,
+		Image:           r.getExporterImage(paradedb),
+		ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+		Args:            buildExporterArgs(paradedb),
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "metrics",
+				ContainerPort: paradedb.GetMetricsPort(),
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Env: append([]corev1.EnvVar{
+			{
+				Name:  "DATA_SOURCE_URI",
+				Value: fmt.Sprintf("%s:%d/%s?sslmode=%s", host, paradedb.GetPort(), paradedb.Spec.Auth.Database, sslMode),
+			},
+		}, buildSuperuserEnv(paradedb, "DATA_SOURCE_USER", "DATA_SOURCE_PASS")...),
+	}
+	if paradedb.Spec.Monitoring != nil {
+		exporter.Resources = paradedb.Spec.Monitoring.Resources
+	}
+
+	var volumes []corev1.Volume
+	if paradedb.GetMetricsTLSSecretName() != "" {
+		volumes = append(volumes, applyExporterTLS(paradedb, &exporter))
+	}
+	return exporter, volumes
+}
+
+// getExporterLabels returns the labels of the standalone exporter pods
+func getExporterLabels(paradedb *databasev1alpha1.ParadeDB) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "postgres-exporter",
+		"app.kubernetes.io/instance":   paradedb.Name,
+		"app.kubernetes.io/component":  "metrics",
+		"app.kubernetes.io/managed-by": "paradedb-operator",
+	}
+}
+
+// getMetricsSelector returns the labels the metrics Service selects the exporter by
+func (r *ParadeDBReconciler) getMetricsSelector(paradedb *databasev1alpha1.ParadeDB) map[string]string {
+	if paradedb.IsExporterDeployment() {
+		return getExporterLabels(paradedb)
+	}
+	return r.getSelectorLabels(paradedb)
+}
+
+// buildExporterDeployment returns the Deployment running the exporter on its own, scraping
+// the primary through its Service
+func (r *ParadeDBReconciler) buildExporterDeployment(paradedb *databasev1alpha1.ParadeDB) *appsv1.Deployment {
+	labels := getExporterLabels(paradedb)
+	annotations := buildPrometheusAnnotations(paradedb)
+	for k, v := range buildServiceMeshAnnotations(paradedb, 0) {
+		annotations[k] = v
+	}
+	podLabels, podAnnotations := withMetadata(paradedb.Spec.PodMetadata, labels, annotations)
+
+	host := fmt.Sprintf("%s.%s.svc", paradedb.GetServiceName(), paradedb.Namespace)
+	exporter, volumes := r.buildExporterContainer(paradedb, host, getSSLMode(paradedb))
+	containers := []corev1.Container{exporter}
+	if paradedb.HasSQLMetrics() {
+		containers = append(containers, r.buildSQLExporterContainer(paradedb))
+		podAnnotations[sqlExporterConfigHashAnnotation] = shortHash(buildSQLExporterConfig(paradedb))
+	}
+	if len(volumes) > 0 || paradedb.HasSQLMetrics() {
+		volumes = append(volumes, corev1.Volume{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: paradedb.Name + "-config"},
+				},
+			},
+		})
+	}
+
+	replicas := int32(1)
+	if paradedb.IsMonitoringSuspended() {
+		replicas = 0
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      paradedb.GetExporterDeploymentName(),
+			Namespace: paradedb.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: podAnnotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers:       containers,
+					Volumes:          volumes,
+					ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+					NodeSelector:     paradedb.Spec.NodeSelector,
+					Tolerations:      paradedb.Spec.Tolerations,
+				},
+			},
+		},
+	}
+	applyServiceAccount(paradedb, &deployment.Spec.Template.Spec)
+	return deployment
+}
+
+// reconcileExporterDeployment creates or updates the standalone exporter Deployment.
+// Changing its settings only rolls the exporter pod.
+func (r *ParadeDBReconciler) reconcileExporterDeployment(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	// The exporter reads the credentials from its environment at startup
+	credentialsHash, err := getCredentialsHash(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+	desired := r.buildExporterDeployment(paradedb)
+	desired.Spec.Template.Annotations = mergeMaps(desired.Spec.Template.Annotations,
+		map[string]string{credentialsHashAnnotation: credentialsHash})
+
+	deployment := &appsv1.Deployment{}
+	err = r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: paradedb.Namespace}, deployment)
+	if apierrors.IsNotFound(err) {
+		log.Info("Creating exporter Deployment", "name", desired.Name)
+		if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "ExporterCreated", "Metrics exporter Deployment created")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	deployment.Spec.Replicas = desired.Spec.Replicas
+	deployment.Spec.Template = desired.Spec.Template
+	return r.Update(ctx, deployment)
+}
+
+// buildExporterWebConfig returns the exporter-toolkit web configuration that serves
+// /metrics over TLS
+func buildExporterWebConfig() string {
+	return "tls_server_config:\n" +
+		"  cert_file: " + exporterTLSMountPath + "/tls.crt\n" +
+		"  key_file: " + exporterTLSMountPath + "/tls.key\n"
+}
+
+// applyExporterTLS has the exporter container serve /metrics over TLS and returns the
+// volume holding its certificate
+func applyExporterTLS(paradedb *databasev1alpha1.ParadeDB, exporter *corev1.Container) corev1.Volume {
+	exporter.Args = append(exporter.Args, "--web.config.file="+exporterConfigMountPath+"/"+exporterWebConfigKey)
+	exporter.VolumeMounts = append(exporter.VolumeMounts,
+		corev1.VolumeMount{Name: "config", MountPath: exporterConfigMountPath, ReadOnly: true},
+		corev1.VolumeMount{Name: "metrics-tls", MountPath: exporterTLSMountPath, ReadOnly: true},
+	)
+	return corev1.Volume{
+		Name: "metrics-tls",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: paradedb.GetMetricsTLSSecretName()},
+		},
+	}
+}
+
+// buildPrometheusAnnotations returns the prometheus.io annotations for the pods and the
+// metrics Service, or an empty map when monitoring or the annotations are disabled, or
+// the exporter is suspended
+func buildPrometheusAnnotations(paradedb *databasev1alpha1.ParadeDB) map[string]string {
+	annotations := map[string]string{}
+	if !paradedb.IsMonitoringEnabled() || paradedb.IsMonitoringSuspended() {
+		return annotations
+	}
+	var spec *databasev1alpha1.PrometheusAnnotationsSpec
+	if paradedb.Spec.Monitoring != nil {
+		spec = paradedb.Spec.Monitoring.PrometheusAnnotations
+	}
+	if spec != nil && !spec.Enabled {
+		return annotations
+	}
+
+	annotations["prometheus.io/scrape"] = "true"
+	annotations["prometheus.io/port"] = fmt.Sprintf("%d", paradedb.GetMetricsPort())
+	if paradedb.GetMetricsTLSSecretName() != "" {
+		annotations["prometheus.io/scheme"] = "https"
+	}
+	if spec != nil && spec.Path != "" {
+		annotations["prometheus.io/path"] = spec.Path
+	}
+	return annotations
+}
+
+// syncPrometheusAnnotations replaces the prometheus.io annotations in existing with the
+// desired ones and reports whether anything changed
+func syncPrometheusAnnotations(existing, desired map[string]string) (map[string]string, bool) {
+	annotations := map[string]string{}
+	for key, value := range existing {
+		if !strings.HasPrefix(key, "prometheus.io/") {
+			annotations[key] = value
+		}
+	}
+	for key, value := range desired {
+		annotations[key] = value
+	}
+	return annotations, !maps.Equal(annotations, existing)
+}
+
+// getMetricsServerName returns the name scrapers verify the exporter's certificate against
+func getMetricsServerName(paradedb *databasev1alpha1.ParadeDB) string {
+	return fmt.Sprintf("%s.%s.svc", paradedb.GetMetricsServiceName(), paradedb.Namespace)
+}
+
+// buildMetricsCertificate returns the cert-manager Certificate for the metrics Service
+func (r *ParadeDBReconciler) buildMetricsCertificate(paradedb *databasev1alpha1.ParadeDB) (*unstructured.Unstructured, error) {
+	issuerRef := paradedb.Spec.TLS.CertManager.IssuerRef
+	if issuerRef == nil {
+		return nil, errors.New("spec.tls.certManager needs an issuerRef")
+	}
+	kind := issuerRef.Kind
+	if kind == "" {
+		kind = "Issuer"
+	}
+
+	service := paradedb.GetMetricsServiceName()
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"secretName": paradedb.GetMetricsTLSSecretName(),
+			"dnsNames": []interface{}{
+				service,
+				service + "." + paradedb.Namespace,
+				getMetricsServerName(paradedb),
+				getMetricsServerName(paradedb) + ".cluster.local",
+			},
+			"usages": []interface{}{"server auth"},
+			"issuerRef": map[string]interface{}{
+				"name":  issuerRef.Name,
+				"kind":  kind,
+				"group": "cert-manager.io",
+			},
+		},
+	}}
+	certificate.SetAPIVersion("cert-manager.io/v1")
+	certificate.SetKind("Certificate")
+	certificate.SetName(paradedb.Name + "-metrics")
+	certificate.SetNamespace(paradedb.Namespace)
+	certificate.SetLabels(r.getLabels(paradedb))
+	return certificate, nil
+}
+
+// buildServiceMonitor returns the Prometheus Operator ServiceMonitor scraping the metrics
+// Service, over TLS verified against the certificate's CA when metrics are served over TLS
+func (r *ParadeDBReconciler) buildServiceMonitor(paradedb *databasev1alpha1.ParadeDB) *unstructured.Unstructured {
+	serviceMonitor := paradedb.Spec.Monitoring.ServiceMonitor
+
+	ports := []interface{}{"metrics"}
+	if paradedb.HasSQLMetrics() {
+		ports = append(ports, "sql-metrics")
+	}
+	var endpoints []interface{}
+	for _, port := range ports {
+		endpoint := map[string]interface{}{"port": port}
+		if serviceMonitor.Interval != "" {
+			endpoint["interval"] = serviceMonitor.Interval
+		}
+		if secretName := paradedb.GetMetricsTLSSecretName(); secretName != "" {
+			endpoint["scheme"] = "https"
+			endpoint["tlsConfig"] = map[string]interface{}{
+				"serverName": getMetricsServerName(paradedb),
+				"ca": map[string]interface{}{
+					"secret": map[string]interface{}{"name": secretName, "key": "ca.crt"},
+				},
+			}
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	selector := map[string]interface{}{}
+	for key, value := range r.getLabels(paradedb) {
+		selector[key] = value
+	}
+
+	monitor := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"selector":  map[string]interface{}{"matchLabels": selector},
+			"endpoints": endpoints,
+		},
+	}}
+	monitor.SetAPIVersion("monitoring.coreos.com/v1")
+	monitor.SetKind("ServiceMonitor")
+	monitor.SetName(paradedb.Name)
+	monitor.SetNamespace(paradedb.Namespace)
+	labels := r.getLabels(paradedb)
+	for key, value := range serviceMonitor.Labels {
+		labels[key] = value
+	}
+	monitor.SetLabels(labels)
+	return monitor
+}
+
+// reconcileMonitoringResources creates or updates the metrics Certificate and the
+// ServiceMonitor, whose CRDs come with cert-manager and the Prometheus Operator
+func (r *ParadeDBReconciler) reconcileMonitoringResources(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	if paradedb.IsCertManagerEnabled() {
+		certificate, err := r.buildMetricsCertificate(paradedb)
+		if err != nil {
+			return err
+		}
+		if err := r.reconcileUnstructured(ctx, paradedb, certificate); err != nil {
+			return err
+		}
+	}
+	if paradedb.IsServiceMonitorEnabled() {
+		return r.reconcileUnstructured(ctx, paradedb, r.buildServiceMonitor(paradedb))
+	}
+	return r.deleteServiceMonitor(ctx, paradedb)
+}
+
+// deleteServiceMonitor removes the ServiceMonitor the operator created for the ParadeDB,
+// so that Prometheus stops scraping an exporter that no longer runs. A ServiceMonitor the
+// operator does not control is left alone, as is a cluster without the Prometheus Operator.
+func (r *ParadeDBReconciler) deleteServiceMonitor(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	monitor := &unstructured.Unstructured{}
+	monitor.SetAPIVersion("monitoring.coreos.com/v1")
+	monitor.SetKind("ServiceMonitor")
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.Name, Namespace: paradedb.Namespace}, monitor)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(monitor, paradedb) {
+		return nil
+	}
+
+	if err := r.Delete(ctx, monitor); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	logf.FromContext(ctx).Info("Deleted ServiceMonitor", "name", monitor.GetName())
+	r.Recorder.Event(paradedb, corev1.EventTypeNormal, "ServiceMonitorDeleted", "ServiceMonitor deleted as monitoring is disabled")
+	return nil
+}
+
+// reconcileUnstructured creates the object or updates its spec and labels
+func (r *ParadeDBReconciler) reconcileUnstructured(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, desired *unstructured.Unstructured) error {
+	log := logf.FromContext(ctx)
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(desired.GroupVersionKind())
+	err := r.Get(ctx, types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, existing)
+	if apierrors.IsNotFound(err) {
+		log.Info("Creating "+desired.GetKind(), "name", desired.GetName())
+		if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, desired.GetKind()+"Created", desired.GetKind()+" created successfully")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	existing.Object["spec"] = desired.Object["spec"]
+	existing.SetLabels(desired.GetLabels())
+	return r.Update(ctx, existing)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// ConditionTypeResourcesInSync reports whether resources left behind by earlier versions
+// of the spec have been removed
+const ConditionTypeResourcesInSync = "ResourcesInSync"
+
+// getExpectedResources returns the "Kind/name" of every Deployment, Service, ConfigMap,
+// CronJob, ServiceAccount, PodDisruptionBudget and Gateway route the spec calls for.
+// StatefulSets, PVCs, Secrets and Jobs are
+// never garbage collected, as they hold data or credentials or record a one-off run.
+func getExpectedResources(paradedb *databasev1alpha1.ParadeDB) map[string]bool {
+	expected := map[string]bool{
+		"ConfigMap/" + paradedb.Name + "-config":                true,
+		"ConfigMap/" + paradedb.GetConfigHistoryConfigMapName(): true,
+		"Service/" + paradedb.GetServiceName():                  true,
+		"Service/" + paradedb.GetServiceName() + "-headless":    true,
+	}
+	if paradedb.IsServiceAccountManaged() {
+		expected["ServiceAccount/"+paradedb.GetServiceAccountName()] = true
+	}
+	if paradedb.IsConnectionPoolingEnabled() {
+		expected["ConfigMap/"+paradedb.Name+"-pooler-config"] = true
+		expected["Deployment/"+paradedb.GetPoolerDeploymentName()] = true
+		expected["Service/"+paradedb.GetPoolerServiceName()] = true
+		if paradedb.GetPoolerReplicas() > 1 {
+			expected["PodDisruptionBudget/"+paradedb.GetPoolerPDBName()] = true
+		}
+	}
+	if paradedb.IsMonitoringEnabled() {
+		expected["Service/"+paradedb.GetMetricsServiceName()] = true
+	}
+	if paradedb.IsGatewayAPIEnabled() {
+		expected[getGatewayRouteKind(paradedb)+"/"+paradedb.Name] = true
+	}
+	if paradedb.IsExporterDeployment() {
+		expected["Deployment/"+paradedb.GetExporterDeploymentName()] = true
+	}
+	if paradedb.IsLogicalBackupEnabled() {
+		expected["CronJob/"+paradedb.GetLogicalBackupCronJobName()] = true
+		if paradedb.IsS3ServiceAccountAuthEnabled() && paradedb.Spec.Backup.S3.ServiceAccountName == "" {
+			expected["ServiceAccount/"+paradedb.GetBackupServiceAccountName()] = true
+		}
+	}
+	if !paradedb.IsStandby() {
+		for _, index := range paradedb.GetVectorIndexes() {
+			if index.RebuildSchedule != "" {
+				expected["CronJob/"+getVectorReindexCronJobName(paradedb, index)] = true
+			}
+		}
+		if len(paradedb.GetMaintenanceTables()) > 0 {
+			expected["CronJob/"+paradedb.GetMaintenanceCronJobName()] = true
+		}
+	}
+	return expected
+}
+
+// reconcileOrphans deletes objects controlled by the ParadeDB that the spec no longer calls
+// for, such as the pooler after connection pooling is disabled, and reports the objects
+// that are still around in the ResourcesInSync condition
+func (r *ParadeDBReconciler) reconcileOrphans(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	owned, err := r.listOwnedResources(ctx, paradedb)
+	if err != nil {
+		return err
+	}
+
+	expected := getExpectedResources(paradedb)
+	var remaining []string
+	for key, object := range owned {
+		if expected[key] {
+			continue
+		}
+		if object.GetDeletionTimestamp() != nil {
+			remaining = append(remaining, key)
+			continue
+		}
+
+		if err := r.Delete(ctx, object, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+			log.Error(err, "Failed to delete orphaned resource", "resource", key)
+			remaining = append(remaining, key)
+			continue
+		}
+		log.Info("Deleted orphaned resource", "resource", key)
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "OrphanDeleted", fmt.Sprintf("Deleted %s, which the spec no longer requires", key))
+	}
+
+	if len(remaining) > 0 {
+		slices.Sort(remaining)
+		setCondition(paradedb, ConditionTypeResourcesInSync, metav1.ConditionFalse, "OrphanedResources",
+			"Resources no longer required by the spec are still present: "+strings.Join(remaining, ", "))
+	} else {
+		setCondition(paradedb, ConditionTypeResourcesInSync, metav1.ConditionTrue, "InSync",
+			"No resources left behind by earlier versions of the spec")
+	}
+	return nil
+}
+
+// listOwnedResources returns the garbage-collectable objects controlled by the ParadeDB,
+// keyed by "Kind/name". Per-pod Services are managed by reconcilePodServices.
+func (r *ParadeDBReconciler) listOwnedResources(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (map[string]client.Object, error) {
+	lists := map[string]client.ObjectList{
+		"Deployment":          &appsv1.DeploymentList{},
+		"Service":             &corev1.ServiceList{},
+		"ConfigMap":           &corev1.ConfigMapList{},
+		"CronJob":             &batchv1.CronJobList{},
+		"ServiceAccount":      &corev1.ServiceAccountList{},
+		"PodDisruptionBudget": &policyv1.PodDisruptionBudgetList{},
+	}
+	for _, kind := range gatewayRouteKinds {
+		list := &unstructured.UnstructuredList{}
+		list.SetAPIVersion(gatewayRouteAPIVersion)
+		list.SetKind(kind + "List")
+		lists[kind] = list
+	}
+
+	owned := map[string]client.Object{}
+	for kind, list := range lists {
+		if err := r.List(ctx, list, client.InNamespace(paradedb.Namespace)); meta.IsNoMatchError(err) {
+			// The Gateway API is not installed
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to list %s objects: %w", kind, err)
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			object, ok := item.(client.Object)
+			if !ok || !metav1.IsControlledBy(object, paradedb) {
+				continue
+			}
+			if _, ok := object.GetLabels()[podServiceLabel]; ok && kind == "Service" {
+				continue
+			}
+			owned[kind+"/"+object.GetName()] = object
+		}
+	}
+	return owned, nil
+}
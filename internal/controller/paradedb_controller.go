@@ -19,21 +19,33 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
 )
@@ -47,9 +59,53 @@ const (
 	ConditionTypeProgressing = "Progressing"
 	ConditionTypeDegraded    = "Degraded"
 
+	// ConditionTypeDatabaseReachable reports whether the operator can query the database
+	ConditionTypeDatabaseReachable = "DatabaseReachable"
+
+	// ConditionTypeCDCReady reports whether the change data capture role and slots are provisioned
+	ConditionTypeCDCReady = "CDCReady"
+
+	// ConditionTypeDatabasesReady reports whether the databases in auth.databases exist and
+	// the session limits of databases and users are applied
+	ConditionTypeDatabasesReady = "DatabasesReady"
+
+	// ConditionTypeWorkloadRolesReady reports whether the search and analytics workload
+	// roles and the reporting user are provisioned
+	ConditionTypeWorkloadRolesReady = "WorkloadRolesReady"
+
+	// ConditionTypePendingRestart reports whether reloaded settings are waiting for a restart
+	ConditionTypePendingRestart = "PendingRestart"
+
+	// ConditionTypeConfigDrift reports whether settings were changed with ALTER SYSTEM
+	ConditionTypeConfigDrift = "ConfigDrift"
+
+	// ConditionTypeBackupFailed reports whether the most recent logical backup failed
+	ConditionTypeBackupFailed = "BackupFailed"
+
+	// ConditionTypeContinuousArchiving reports whether WAL segments are being archived,
+	// while archive_mode is on
+	ConditionTypeContinuousArchiving = "ContinuousArchiving"
+
+	// restartAnnotation on a ParadeDB requests a rolling restart whenever its value changes
+	restartAnnotation = "database.paradedb.io/restart"
+
+	// restartedAtAnnotation on the pod template records the last requested restart
+	restartedAtAnnotation = "database.paradedb.io/restartedAt"
+
+	// credentialsHashAnnotation on a pod template records the credentials its containers
+	// were started with
+	credentialsHashAnnotation = "database.paradedb.io/credentials-hash"
+
+	// podServiceLabel marks per-pod Services with the name of the pod they select
+	podServiceLabel = "database.paradedb.io/pod-service"
+
+	// externalDNSHostnameAnnotation is read by external-dns to publish a Service's DNS name
+	externalDNSHostnameAnnotation = "external-dns.alpha.kubernetes.io/hostname"
+
 	// Requeue intervals
-	requeueAfterError   = 30 * time.Second
-	requeueAfterSuccess = 60 * time.Second
+	requeueAfterError    = 30 * time.Second
+	requeueAfterSuccess  = 60 * time.Second
+	maxRequeueAfterError = 10 * time.Minute
 )
 
 // ParadeDBReconciler reconciles a ParadeDB object
@@ -57,21 +113,58 @@ type ParadeDBReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// ResolveImageDigests pins the pods to the digest an image tag resolves to in the
+	// registry when the tag is first used
+	ResolveImageDigests bool
+
+	// MonitoringEnabledByDefault runs the metrics exporter for instances that leave
+	// spec.monitoring unset
+	MonitoringEnabledByDefault bool
+
+	// Defaults fill in settings that neither the ParadeDB nor its class set
+	Defaults *OperatorDefaults
+
+	// ImageRegistryOverride is the registry the operator's built-in default images, such
+	// as PgBouncer and postgres_exporter, are pulled from instead of their public one
+	ImageRegistryOverride string
+
+	// Quotas limit the instances, storage and replicas of each namespace
+	Quotas *OperatorQuotas
+
+	// ImageTagPolicy is how instances whose managed containers run an image on the latest
+	// tag are handled: Warn, Enforce, or empty to allow them
+	ImageTagPolicy string
+
+	// RESTConfig is used to exec into pods, which the operator only does to reset the
+	// superuser password after the credentials Secret was deleted
+	RESTConfig *rest.Config
 }
 
 // +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbsnapshots,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=volumeattachments,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is the main reconciliation loop
 func (r *ParadeDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -102,8 +195,16 @@ func (r *ParadeDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 				return ctrl.Result{}, err
 			}
 
-			// Perform cleanup operations
-			r.finalizeParadeDB(ctx, paradedb)
+			// Perform cleanup operations, keeping the finalizer until they have finished
+			requeueAfter, err := r.finalizeParadeDB(ctx, paradedb)
+			if err != nil {
+				log.Error(err, "Failed to clean up external state")
+				r.Recorder.Event(paradedb, corev1.EventTypeWarning, "CleanupFailed", err.Error())
+				return ctrl.Result{}, err
+			}
+			if requeueAfter > 0 {
+				return ctrl.Result{RequeueAfter: requeueAfter}, nil
+			}
 
 			// Remove finalizer
 			controllerutil.RemoveFinalizer(paradedb, paradedbFinalizer)
@@ -136,8 +237,9 @@ func (r *ParadeDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// Update status to Creating if Pending
-	if paradedb.Status.Phase == databasev1alpha1.ParadeDBPhasePending {
+	// Update status to Creating if Pending, unless held back by the namespace quota
+	if paradedb.Status.Phase == databasev1alpha1.ParadeDBPhasePending &&
+		!meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeQuotaExceeded) {
 		paradedb.Status.Phase = databasev1alpha1.ParadeDBPhaseCreating
 		if err := r.Status().Update(ctx, paradedb); err != nil {
 			log.Error(err, "Failed to update ParadeDB status")
@@ -146,12 +248,160 @@ func (r *ParadeDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "Creating", "Starting ParadeDB creation")
 	}
 
+	// The status as last written, so that an unchanged status is not written again
+	persisted := paradedb.Status.DeepCopy()
+
+	// Owned resources changing can trigger a reconciliation during the backoff after a
+	// failure; wait it out unless the spec has changed since
+	if wait := remainingBackoff(paradedb); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	// Fill in defaults, the catalog version, the schedule in effect and the image digest
+	if invalid, err := r.resolveSpec(ctx, paradedb); invalid {
+		log.Error(err, "Image tag policy violated")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Image tag policy violated")
+	} else if err != nil {
+		log.Error(err, "Failed to resolve the spec")
+		return r.handleError(ctx, paradedb, err, "Failed to resolve the spec")
+	}
+
+	// New instances with more than one replica and no replication are rejected here as
+	// well as by the webhook, which may be disabled. Instances that already run keep
+	// being managed, with a warning once per change.
+	if err := paradedb.ValidateReplicas(); err != nil {
+		statefulSet := &appsv1.StatefulSet{}
+		getErr := r.Get(ctx, types.NamespacedName{Name: paradedb.GetStatefulSetName(), Namespace: paradedb.Namespace}, statefulSet)
+		if errors.IsNotFound(getErr) {
+			log.Error(err, "Replication not configured")
+			return r.handleInvalidSpec(ctx, paradedb, err, "Replication not configured")
+		} else if getErr != nil {
+			return r.handleError(ctx, paradedb, getErr, "Failed to get StatefulSet")
+		}
+		if paradedb.Status.ObservedGeneration != paradedb.Generation {
+			log.Info("Replication not configured", "reason", err.Error())
+			r.Recorder.Event(paradedb, corev1.EventTypeWarning, "ReplicationNotConfigured", err.Error())
+		}
+	}
+
+	if err := validateStorage(paradedb); err != nil {
+		log.Error(err, "Invalid storage")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid storage")
+	}
+
+	if err := validateSuperuserAuth(paradedb); err != nil {
+		log.Error(err, "Invalid superuser authentication")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid superuser authentication")
+	}
+
+	if err := validateReplicaOf(paradedb); err != nil {
+		log.Error(err, "Invalid replicaOf")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid replicaOf")
+	}
+
+	if err := validateVectorSpec(paradedb); err != nil {
+		log.Error(err, "Invalid vector configuration")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid vector configuration")
+	}
+
+	if err := validateWALConfig(paradedb); err != nil {
+		log.Error(err, "Invalid WAL configuration")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid WAL configuration")
+	}
+
+	if err := validateMaintenance(paradedb); err != nil {
+		log.Error(err, "Invalid maintenance")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid maintenance")
+	}
+
+	if err := validatePoolerRoutes(paradedb); err != nil {
+		log.Error(err, "Invalid pooler routes")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid pooler routes")
+	}
+
+	if err := validateDNS(paradedb); err != nil {
+		log.Error(err, "Invalid DNS settings")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid DNS settings")
+	}
+
+	if err := validateExtraVolumes(paradedb); err != nil {
+		log.Error(err, "Invalid extra volumes")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid extra volumes")
+	}
+
+	if err := validateRestore(paradedb); err != nil {
+		log.Error(err, "Invalid restore")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid restore")
+	}
+	if err := validateDataImports(paradedb); err != nil {
+		log.Error(err, "Invalid data import")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid data import")
+	}
+
+	warning, err := paradedb.ValidateMemorySettings()
+	if err != nil {
+		log.Error(err, "Invalid memory settings")
+		return r.handleInvalidSpec(ctx, paradedb, err, "Invalid memory settings")
+	}
+	// Warn once per change to the spec rather than on every reconciliation
+	if warning != "" && paradedb.Status.ObservedGeneration != paradedb.Generation {
+		r.Recorder.Event(paradedb, corev1.EventTypeWarning, "MemoryOvercommitted", warning)
+	}
+
+	// Restoring from a snapshot has to wait until it is ready
+	if paradedb.GetBootstrapSnapshot() != "" {
+		if err := r.checkBootstrapSnapshot(ctx, paradedb); err != nil {
+			log.Error(err, "Cannot restore from snapshot")
+			return r.handleError(ctx, paradedb, err, "Cannot restore from snapshot")
+		}
+	}
+
+	// Hold back instances that do not fit the quota of their namespace from using more
+	quotaMessage, err := r.checkQuota(ctx, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to check namespace quota")
+		return r.handleError(ctx, paradedb, err, "Failed to check namespace quota")
+	}
+	if quotaMessage != "" {
+		log.Info("Namespace quota exceeded", "reason", quotaMessage)
+		pending, err := r.handleQuotaExceeded(ctx, paradedb, quotaMessage)
+		if err != nil {
+			return ctrl.Result{}, err
+		} else if pending {
+			return ctrl.Result{RequeueAfter: quotaRecheckInterval}, nil
+		}
+	} else {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeQuotaExceeded)
+	}
+
 	// Reconcile credentials secret
 	if err := r.reconcileCredentialsSecret(ctx, paradedb); err != nil {
 		log.Error(err, "Failed to reconcile credentials secret")
 		return r.handleError(ctx, paradedb, err, "Failed to reconcile credentials secret")
 	}
 
+	// Reconcile the replication role's secret if change data capture is enabled
+	if paradedb.IsCDCEnabled() {
+		if err := r.reconcileCDCSecret(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile CDC secret")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile CDC secret")
+		}
+	}
+
+	// Reconcile the Secrets of the workload roles and the reporting user
+	if paradedb.HasWorkloadRoles() {
+		if err := r.reconcileWorkloadRoleSecrets(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile workload role secrets")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile workload role secrets")
+		}
+	}
+
+	// Reconcile the ServiceAccount the pods run as
+	if err := r.reconcileServiceAccount(ctx, paradedb); err != nil {
+		log.Error(err, "Failed to reconcile ServiceAccount")
+		return r.handleError(ctx, paradedb, err, "Failed to reconcile ServiceAccount")
+	}
+
 	// Reconcile ConfigMap for PostgreSQL configuration
 	if err := r.reconcileConfigMap(ctx, paradedb); err != nil {
 		log.Error(err, "Failed to reconcile ConfigMap")
@@ -176,6 +426,27 @@ func (r *ParadeDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return r.handleError(ctx, paradedb, err, "Failed to reconcile Headless Service")
 	}
 
+	// Reconcile per-pod Services
+	if err := r.reconcilePodServices(ctx, paradedb); err != nil {
+		log.Error(err, "Failed to reconcile per-pod Services")
+		return r.handleError(ctx, paradedb, err, "Failed to reconcile per-pod Services")
+	}
+
+	// Reconcile Gateway API route if external exposure is configured
+	if paradedb.IsGatewayAPIEnabled() {
+		if err := r.reconcileGatewayRoute(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile Gateway route")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile Gateway route")
+		}
+	} else {
+		for _, kind := range gatewayRouteKinds {
+			if err := r.deleteGatewayRoute(ctx, paradedb, kind); err != nil {
+				log.Error(err, "Failed to delete Gateway route")
+				return r.handleError(ctx, paradedb, err, "Failed to delete Gateway route")
+			}
+		}
+	}
+
 	// Reconcile Connection Pooler (PgBouncer) if enabled
 	if paradedb.IsConnectionPoolingEnabled() {
 		if err := r.reconcileConnectionPooler(ctx, paradedb); err != nil {
@@ -190,6 +461,19 @@ func (r *ParadeDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			log.Error(err, "Failed to reconcile Metrics Service")
 			return r.handleError(ctx, paradedb, err, "Failed to reconcile Metrics Service")
 		}
+		if paradedb.IsExporterDeployment() {
+			if err := r.reconcileExporterDeployment(ctx, paradedb); err != nil {
+				log.Error(err, "Failed to reconcile exporter Deployment")
+				return r.handleError(ctx, paradedb, err, "Failed to reconcile exporter Deployment")
+			}
+		}
+		if err := r.reconcileMonitoringResources(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile monitoring resources")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile monitoring resources")
+		}
+	} else if err := r.deleteServiceMonitor(ctx, paradedb); err != nil {
+		log.Error(err, "Failed to delete ServiceMonitor")
+		return r.handleError(ctx, paradedb, err, "Failed to delete ServiceMonitor")
 	}
 
 	// Reconcile Backup CronJob if backup is enabled
@@ -200,8 +484,86 @@ func (r *ParadeDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		}
 	}
 
+	// Reconcile the logical backup CronJob, which runs on its own schedule
+	if paradedb.IsLogicalBackupEnabled() {
+		if err := r.reconcileLogicalBackup(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile logical backup CronJob")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile logical backup CronJob")
+		}
+	}
+
+	// Build the pgvector indexes in Jobs, since builds can take far longer than a reconcile
+	if len(paradedb.GetVectorIndexes()) > 0 {
+		if err := r.reconcileVectorIndexes(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile vector indexes")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile vector indexes")
+		}
+	}
+
+	// Vacuum, analyze and reindex the configured tables in the maintenance window
+	if len(paradedb.GetMaintenanceTables()) > 0 {
+		if err := r.reconcileMaintenance(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile maintenance CronJob")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile maintenance CronJob")
+		}
+	}
+
+	// Import data from an external server or another instance into a new instance
+	if paradedb.GetExternalBootstrap() != nil || paradedb.GetInstanceBootstrap() != nil {
+		if err := r.reconcileImport(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile import")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile import")
+		}
+	}
+
+	// Load datasets from object storage into a new instance
+	if len(paradedb.GetDataImports()) > 0 {
+		if err := r.reconcileDataImport(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile data import")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile data import")
+		}
+	} else {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeDataImported)
+	}
+
+	// Restore selected databases and tables from a logical backup
+	if paradedb.Spec.Restore != nil {
+		if err := r.reconcileRestore(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to reconcile restore")
+			return r.handleError(ctx, paradedb, err, "Failed to reconcile restore")
+		}
+	}
+
+	// Remove resources the spec no longer calls for, e.g. after disabling a feature
+	if err := r.reconcileOrphans(ctx, paradedb); err != nil {
+		log.Error(err, "Failed to remove orphaned resources")
+		return r.handleError(ctx, paradedb, err, "Failed to remove orphaned resources")
+	}
+
+	// Surface data volumes PostgreSQL cannot start on, which deleting the pod does not fix
+	if paradedb.IsVolumeCheckEnabled() {
+		if err := r.checkVolumeCheckFailures(ctx, paradedb); err != nil {
+			log.Error(err, "Data volume check failed")
+			return r.handleError(ctx, paradedb, err, "Data volume check failed")
+		}
+	}
+
+	// Remediate stuck and crash-looping pods unless disabled
+	if paradedb.IsRemediationEnabled() {
+		if err := r.reconcileRemediation(ctx, paradedb); err != nil {
+			log.Error(err, "Failed to remediate pods")
+			return r.handleError(ctx, paradedb, err, "Failed to remediate pods")
+		}
+	}
+
+	// Label the pods with their role and record the primary
+	if err := r.reconcilePodRoles(ctx, paradedb); err != nil {
+		log.Error(err, "Failed to label pod roles")
+		return r.handleError(ctx, paradedb, err, "Failed to label pod roles")
+	}
+
 	// Update status based on StatefulSet status
-	if err := r.updateStatus(ctx, paradedb); err != nil {
+	if err := r.updateStatus(ctx, paradedb, persisted); err != nil {
 		log.Error(err, "Failed to update status")
 		return ctrl.Result{RequeueAfter: requeueAfterError}, err
 	}
@@ -210,36 +572,125 @@ func (r *ParadeDBReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	return ctrl.Result{RequeueAfter: requeueAfterSuccess}, nil
 }
 
-// handleError handles errors during reconciliation
+// handleError handles errors during reconciliation. The error is recorded in the status
+// and retried with a backoff that grows with the number of consecutive failures, rather
+// than being returned to the workqueue's rate limiter.
 func (r *ParadeDBReconciler) handleError(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, err error, message string) (ctrl.Result, error) {
+	now := metav1.Now()
+	// A failure that persists across retries is reported once rather than on every retry
+	repeated := paradedb.Status.Phase == databasev1alpha1.ParadeDBPhaseFailed && paradedb.Status.Message == message+": "+err.Error()
 	paradedb.Status.Phase = databasev1alpha1.ParadeDBPhaseFailed
 	paradedb.Status.Message = message + ": " + err.Error()
+	paradedb.Status.FailureCount++
+	paradedb.Status.LastFailureTime = &now
 
-	meta.SetStatusCondition(&paradedb.Status.Conditions, metav1.Condition{
-		Type:               ConditionTypeDegraded,
-		Status:             metav1.ConditionTrue,
-		Reason:             "ReconciliationFailed",
-		Message:            message,
-		LastTransitionTime: metav1.Now(),
-	})
+	setCondition(paradedb, ConditionTypeDegraded, metav1.ConditionTrue, "ReconciliationFailed", message)
 
 	if updateErr := r.Status().Update(ctx, paradedb); updateErr != nil {
 		return ctrl.Result{}, updateErr
 	}
 
-	r.Recorder.Event(paradedb, corev1.EventTypeWarning, "ReconciliationFailed", message)
-	return ctrl.Result{RequeueAfter: requeueAfterError}, err
+	if !repeated {
+		r.Recorder.Event(paradedb, corev1.EventTypeWarning, "ReconciliationFailed", message)
+	}
+	return ctrl.Result{RequeueAfter: failureBackoff(paradedb.Status.FailureCount)}, nil
 }
 
-// finalizeParadeDB performs cleanup when ParadeDB is being deleted
-func (r *ParadeDBReconciler) finalizeParadeDB(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
-	log := logf.FromContext(ctx)
-	log.Info("Finalizing ParadeDB", "name", paradedb.Name)
+// resolveSpec sets, for this reconcile only, what the ParadeDB leaves to the operator:
+// defaults from its class and the operator configuration, the image from the version
+// catalog, the replicas of the schedule in effect and the digest the image is pinned to.
+// RenderManifests uses it too, so that a preview shows what would run. It returns true if
+// the images violate the image tag policy.
+func (r *ParadeDBReconciler) resolveSpec(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (bool, error) {
+	if paradedb.Spec.ClassName != "" {
+		class := &databasev1alpha1.ParadeDBClass{}
+		if err := r.Get(ctx, types.NamespacedName{Name: paradedb.Spec.ClassName}, class); err != nil {
+			return false, fmt.Errorf("failed to get ParadeDBClass %s: %w", paradedb.Spec.ClassName, err)
+		}
+		applyClassDefaults(paradedb, class)
+	}
+	applyMonitoringDefault(paradedb, r.MonitoringEnabledByDefault)
+	applyOperatorDefaults(paradedb, r.Defaults)
+	if r.ImageRegistryOverride != "" && paradedb.Spec.Image == "" && paradedb.Spec.UpdatePolicy == nil {
+		paradedb.Spec.Image = r.mirrorImage(paradedb.GetImage())
+	}
+
+	// Resolve the image from the version catalog
+	if err := r.applyUpdatePolicy(ctx, paradedb); err != nil {
+		return false, fmt.Errorf("failed to apply update policy: %w", err)
+	}
+
+	// Scale to the schedule in effect
+	if err := r.applySchedules(ctx, paradedb); err != nil {
+		return false, fmt.Errorf("failed to apply schedules: %w", err)
+	}
+
+	// Check the images as given, before the database image is pinned to a digest
+	if err := r.checkImageTagPolicy(paradedb); err != nil {
+		return true, err
+	}
+
+	// Run by digest so that moving tags such as latest do not mix images across restarts
+	r.pinImageDigest(ctx, paradedb)
+	return false, nil
+}
+
+// handleInvalidSpec reports a spec that cannot be reconciled as it is. Retrying cannot
+// help, so it is not requeued: the next attempt follows a change to the ParadeDB or its
+// class. A repeat of the same failure leaves the status alone so as not to trigger itself.
+func (r *ParadeDBReconciler) handleInvalidSpec(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, err error, message string) (ctrl.Result, error) {
+	message = message + ": " + err.Error()
+	degraded := meta.FindStatusCondition(paradedb.Status.Conditions, ConditionTypeDegraded)
+	if degraded != nil && degraded.Reason == "InvalidSpec" && degraded.Message == message &&
+		degraded.ObservedGeneration == paradedb.Generation && paradedb.Status.Phase == databasev1alpha1.ParadeDBPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	now := metav1.Now()
+	paradedb.Status.Phase = databasev1alpha1.ParadeDBPhaseFailed
+	paradedb.Status.Message = message
+	paradedb.Status.LastFailureTime = &now
+	setCondition(paradedb, ConditionTypeDegraded, metav1.ConditionTrue, "InvalidSpec", message)
+
+	if updateErr := r.Status().Update(ctx, paradedb); updateErr != nil {
+		return ctrl.Result{}, updateErr
+	}
+	r.Recorder.Event(paradedb, corev1.EventTypeWarning, "InvalidSpec", message)
+	return ctrl.Result{}, nil
+}
+
+// remainingBackoff returns how long is left of the backoff after a failed reconciliation
+// of the current generation
+func remainingBackoff(paradedb *databasev1alpha1.ParadeDB) time.Duration {
+	degraded := meta.FindStatusCondition(paradedb.Status.Conditions, ConditionTypeDegraded)
+	if paradedb.Status.Phase != databasev1alpha1.ParadeDBPhaseFailed || paradedb.Status.LastFailureTime == nil ||
+		degraded == nil || degraded.Reason != "ReconciliationFailed" || degraded.ObservedGeneration != paradedb.Generation {
+		return 0
+	}
+	return failureBackoff(paradedb.Status.FailureCount) - time.Since(paradedb.Status.LastFailureTime.Time)
+}
 
-	// Cleanup is handled by Kubernetes garbage collection via OwnerReferences
-	// Add any additional cleanup logic here if needed
+// failureBackoff doubles the error requeue interval for each consecutive failure, up to
+// maxRequeueAfterError
+func failureBackoff(failures int32) time.Duration {
+	backoff := requeueAfterError
+	for i := int32(1); i < failures && backoff < maxRequeueAfterError; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxRequeueAfterError)
+}
 
-	r.Recorder.Event(paradedb, corev1.EventTypeNormal, "Deleted", "ParadeDB instance deleted successfully")
+// setCondition records a condition against the generation being reconciled. The
+// LastTransitionTime is left to meta.SetStatusCondition, which only moves it when the
+// status actually changes.
+func setCondition(paradedb *databasev1alpha1.ParadeDB, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&paradedb.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: paradedb.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
 }
 
 // reconcileCredentialsSecret creates or updates the credentials secret
@@ -260,25 +711,58 @@ func (r *ParadeDBReconciler) reconcileCredentialsSecret(ctx context.Context, par
 		return nil
 	}
 
+	// The superuser has no password to keep in a Secret
+	if paradedb.IsSuperuserPasswordless() {
+		return nil
+	}
+
 	// Create default credentials secret
 	secretName := paradedb.Name + "-credentials"
 	secret := &corev1.Secret{}
 	err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: paradedb.Namespace}, secret)
 
 	if err != nil && errors.IsNotFound(err) {
-		log.Info("Creating credentials secret", "name", secretName)
+		// An existing StatefulSet means the data directory already has a superuser password.
+		// It went with the deleted Secret only if the operator generated it; an adopted
+		// StatefulSet got its password from elsewhere, which must not be overwritten.
+		existing := true
+		statefulSet := &appsv1.StatefulSet{}
+		if err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetStatefulSetName(), Namespace: paradedb.Namespace}, statefulSet); errors.IsNotFound(err) {
+			existing = false
+		} else if err != nil {
+			return err
+		}
+		recreated := existing && paradedb.Status.CredentialsSecretCreated
+		log.Info("Creating credentials secret", "name", secretName, "recreated", recreated)
+
+		username, password := "postgres", generateRandomPassword(16)
+		if paradedb.GetBootstrapSnapshot() != "" {
+			// The restored data directory keeps the roles of the snapshotted instance
+			username, password, err = r.getSnapshotCredentials(ctx, paradedb)
+			if err != nil {
+				return err
+			}
+		}
 
+		var pending map[string]string
+		if recreated {
+			pending = map[string]string{passwordPendingAnnotation: "true"}
+		}
+		labels, annotations := withMetadata(paradedb.Spec.SecretMetadata, r.getLabels(paradedb), pending)
 		secret = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      secretName,
-				Namespace: paradedb.Namespace,
-				Labels:    r.getLabels(paradedb),
+				Name:        secretName,
+				Namespace:   paradedb.Namespace,
+				Labels:      labels,
+				Annotations: annotations,
 			},
 			Type: corev1.SecretTypeOpaque,
 			StringData: map[string]string{
-				"username": "postgres",
-				"password": generateRandomPassword(16),
+				"username": username,
+				"password": password,
 				"database": paradedb.Spec.Auth.Database,
+				"host":     paradedb.GetHost(),
+				"port":     fmt.Sprintf("%d", paradedb.GetPort()),
 			},
 		}
 
@@ -289,10 +773,61 @@ func (r *ParadeDBReconciler) reconcileCredentialsSecret(ctx context.Context, par
 		if err := r.Create(ctx, secret); err != nil {
 			return err
 		}
+		// Recorded right away, since losing it would leave a later deletion undetected. The
+		// patch goes through a copy, so that the response does not replace the defaults
+		// applied to the spec for this reconcile.
+		if !paradedb.Status.CredentialsSecretCreated {
+			latest := paradedb.DeepCopy()
+			patch := client.MergeFrom(latest.DeepCopy())
+			latest.Status.CredentialsSecretCreated = true
+			if err := r.Status().Patch(ctx, latest, patch); err != nil {
+				return err
+			}
+			paradedb.Status.CredentialsSecretCreated = true
+			paradedb.ResourceVersion = latest.ResourceVersion
+		}
 
-		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "SecretCreated", "Credentials secret created")
+		switch {
+		case recreated:
+			r.Recorder.Event(paradedb, corev1.EventTypeWarning, "SecretRecreated",
+				"Credentials secret was deleted and has been recreated with a new superuser password")
+		case existing:
+			r.Recorder.Event(paradedb, corev1.EventTypeWarning, "SecretCreatedForExistingData", fmt.Sprintf(
+				"Created %s for an existing StatefulSet without changing the superuser password in the database; "+
+					"set it to match or use spec.auth.superuserSecretRef", secretName))
+		default:
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "SecretCreated", "Credentials secret created")
+		}
 	} else if err != nil {
 		return err
+	} else {
+		// The operator never adopts Secrets, so one it controls is one it created, such as
+		// before the status recorded this
+		if metav1.IsControlledBy(secret, paradedb) {
+			paradedb.Status.CredentialsSecretCreated = true
+		}
+
+		// A standby is read-only and gets its roles from the primary
+		if secret.Annotations[passwordPendingAnnotation] != "" && !paradedb.IsStandby() {
+			if err := r.applyPendingPassword(ctx, paradedb, secret); err != nil {
+				return err
+			}
+		}
+
+		// Keep the connection details in sync with the exposed endpoint
+		host := paradedb.GetHost()
+		port := fmt.Sprintf("%d", paradedb.GetPort())
+		if string(secret.Data["host"]) != host || string(secret.Data["port"]) != port {
+			if secret.Data == nil {
+				secret.Data = map[string][]byte{}
+			}
+			secret.Data["host"] = []byte(host)
+			secret.Data["port"] = []byte(port)
+
+			if err := r.Update(ctx, secret); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -302,53 +837,66 @@ func (r *ParadeDBReconciler) reconcileCredentialsSecret(ctx context.Context, par
 func (r *ParadeDBReconciler) reconcileConfigMap(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
 	log := logf.FromContext(ctx)
 
-	configMapName := paradedb.Name + "-config"
-	configMap := &corev1.ConfigMap{}
-	err := r.Get(ctx, types.NamespacedName{Name: configMapName, Namespace: paradedb.Namespace}, configMap)
+	desired, err := r.buildConfigMap(paradedb)
+	if err != nil {
+		return err
+	}
 
-	// Build PostgreSQL configuration
-	postgresConf := buildPostgresConfig(paradedb)
-	pgHBAConf := buildPgHBAConfig(paradedb)
-	initScript := buildInitScript(paradedb)
+	configMap := &corev1.ConfigMap{}
+	err = r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: paradedb.Namespace}, configMap)
 
 	if err != nil && errors.IsNotFound(err) {
-		log.Info("Creating ConfigMap", "name", configMapName)
-
-		configMap = &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      configMapName,
-				Namespace: paradedb.Namespace,
-				Labels:    r.getLabels(paradedb),
-			},
-			Data: map[string]string{
-				"postgresql.conf": postgresConf,
-				"pg_hba.conf":     pgHBAConf,
-				"init.sql":        initScript,
-			},
-		}
+		log.Info("Creating ConfigMap", "name", desired.Name)
 
-		if err := controllerutil.SetControllerReference(paradedb, configMap, r.Scheme); err != nil {
+		if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
 			return err
 		}
 
-		if err := r.Create(ctx, configMap); err != nil {
+		if err := r.Create(ctx, desired); err != nil {
 			return err
 		}
 	} else if err != nil {
 		return err
 	} else {
 		// Update existing ConfigMap
-		configMap.Data = map[string]string{
-			"postgresql.conf": postgresConf,
-			"pg_hba.conf":     pgHBAConf,
-			"init.sql":        initScript,
-		}
+		configMap.Data = desired.Data
 		if err := r.Update(ctx, configMap); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return r.reconcileConfigHistory(ctx, paradedb, desired.Data["postgresql.conf"])
+}
+
+// buildConfigMap creates the ConfigMap holding the PostgreSQL configuration
+func (r *ParadeDBReconciler) buildConfigMap(paradedb *databasev1alpha1.ParadeDB) (*corev1.ConfigMap, error) {
+	if err := validatePgHBA(paradedb); err != nil {
+		return nil, err
+	}
+
+	data := map[string]string{
+		"postgresql.conf": buildPostgresConfig(paradedb),
+		"pg_hba.conf":     buildPgHBAConfig(paradedb),
+		"init.sql":        buildInitScript(paradedb),
+	}
+	if len(paradedb.Spec.PostgresConfigFrom) > 0 {
+		data[includeConfigKey] = buildIncludeConfig(paradedb)
+	}
+	if paradedb.GetMetricsTLSSecretName() != "" {
+		data[exporterWebConfigKey] = buildExporterWebConfig()
+	}
+	if paradedb.HasSQLMetrics() {
+		data[sqlExporterConfigKey] = buildSQLExporterConfig(paradedb)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      paradedb.Name + "-config",
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Data: data,
+	}, nil
 }
 
 // reconcileStatefulSet creates or updates the StatefulSet for ParadeDB
@@ -359,6 +907,25 @@ func (r *ParadeDBReconciler) reconcileStatefulSet(ctx context.Context, paradedb
 	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetStatefulSetName(), Namespace: paradedb.Namespace}, statefulSet)
 
 	desired := r.buildStatefulSet(paradedb)
+	if err := applyPodTemplateOverrides(paradedb, &desired.Spec.Template, r.getSelectorLabels(paradedb)); err != nil {
+		return err
+	}
+
+	// The metrics exporter reads the credentials from its environment, so roll the pods
+	// when they change
+	if paradedb.IsMonitoringEnabled() {
+		credentialsHash, err := getCredentialsHash(ctx, r.Client, paradedb)
+		if err != nil {
+			return fmt.Errorf("failed to read credentials: %w", err)
+		}
+		desired.Spec.Template.Annotations = mergeMaps(desired.Spec.Template.Annotations,
+			map[string]string{credentialsHashAnnotation: credentialsHash})
+	}
+
+	// Provision the volumes of replicas pinned to a zone before their pods are created
+	if err := r.reconcileZonalClaims(ctx, paradedb, desired.Spec.VolumeClaimTemplates); err != nil {
+		return fmt.Errorf("failed to place volume claims: %w", err)
+	}
 
 	if err != nil && errors.IsNotFound(err) {
 		log.Info("Creating StatefulSet", "name", desired.Name)
@@ -372,16 +939,58 @@ func (r *ParadeDBReconciler) reconcileStatefulSet(ctx context.Context, paradedb
 		}
 
 		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "StatefulSetCreated", "StatefulSet created successfully")
+		paradedb.Status.PostgresVersion = paradedb.Spec.PostgresVersion
+		if snapshot := paradedb.GetBootstrapSnapshot(); snapshot != "" {
+			recordOperation(paradedb, databasev1alpha1.OperationRestore, databasev1alpha1.OperationRunning,
+				"Restoring from ParadeDBSnapshot "+snapshot)
+		}
 	} else if err != nil {
 		return err
 	} else {
-		// Update existing StatefulSet
+		if err := r.claimStatefulSet(paradedb, statefulSet); err != nil {
+			return err
+		}
+
+		// Update existing StatefulSet. The selector is immutable, so an adopted StatefulSet
+		// keeps selecting its pods by the labels it was created with.
+		before := statefulSet.Spec.Template.DeepCopy()
+		previousReplicas := statefulSet.Spec.Replicas
 		statefulSet.Spec.Replicas = desired.Spec.Replicas
 		statefulSet.Spec.Template = desired.Spec.Template
+		if statefulSet.Spec.Selector != nil {
+			statefulSet.Spec.Template.Labels = mergeMaps(statefulSet.Spec.Template.Labels, statefulSet.Spec.Selector.MatchLabels)
+		}
+		statefulSet.Spec.UpdateStrategy = desired.Spec.UpdateStrategy
+
+		// Compare against the template as the API server would store it, so that defaulted
+		// fields do not count as a change the safeguards have to allow
+		var reason, message string
+		if paradedb.Spec.Safeguards != nil {
+			dryRun := statefulSet.DeepCopy()
+			if err := r.Update(ctx, dryRun, client.DryRunAll); err != nil {
+				return err
+			}
+			reason, message = checkSafeguards(paradedb, before, &dryRun.Spec.Template, time.Now())
+			if reason != "" {
+				log.Info("Safeguards hold back pod rollout", "reason", reason)
+				statefulSet.Spec.Template = *before
+			}
+		}
+		r.setOperationBlocked(paradedb, reason, message)
 
 		if err := r.Update(ctx, statefulSet); err != nil {
 			return err
 		}
+		if reason == "" {
+			paradedb.Status.PostgresVersion = paradedb.Spec.PostgresVersion
+		}
+		if previousReplicas != nil && desired.Spec.Replicas != nil && *previousReplicas != *desired.Spec.Replicas {
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "Scaled",
+				fmt.Sprintf("Scaled from %d to %d replicas", *previousReplicas, *desired.Spec.Replicas))
+		}
+		if operation := recordRollout(paradedb, before, &statefulSet.Spec.Template); operation != nil {
+			r.emitOperationEvent(paradedb, *operation)
+		}
 	}
 
 	return nil
@@ -411,10 +1020,21 @@ func (r *ParadeDBReconciler) reconcileService(ctx context.Context, paradedb *dat
 	} else if err != nil {
 		return err
 	} else {
+		if err := r.claimObject(paradedb, "Service", service); err != nil {
+			return err
+		}
+
 		// Update existing Service (preserve ClusterIP)
 		service.Spec.Ports = desired.Spec.Ports
 		service.Spec.Type = desired.Spec.Type
 		service.Spec.Selector = desired.Spec.Selector
+		service.Spec.LoadBalancerSourceRanges = desired.Spec.LoadBalancerSourceRanges
+		if desired.Spec.ExternalTrafficPolicy != "" {
+			service.Spec.ExternalTrafficPolicy = desired.Spec.ExternalTrafficPolicy
+		}
+		service.Spec.LoadBalancerIP = desired.Spec.LoadBalancerIP
+		service.Labels = mergeMaps(service.Labels, desired.Labels)
+		service.Annotations = mergeMaps(service.Annotations, desired.Annotations)
 
 		if err := r.Update(ctx, service); err != nil {
 			return err
@@ -424,35 +1044,16 @@ func (r *ParadeDBReconciler) reconcileService(ctx context.Context, paradedb *dat
 	return nil
 }
 
-// reconcileHeadlessService creates the headless service for StatefulSet
+// reconcileHeadlessService creates or updates the headless service for StatefulSet
 func (r *ParadeDBReconciler) reconcileHeadlessService(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
 	log := logf.FromContext(ctx)
 
-	serviceName := paradedb.GetServiceName() + "-headless"
 	service := &corev1.Service{}
-	err := r.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: paradedb.Namespace}, service)
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetServiceName() + "-headless", Namespace: paradedb.Namespace}, service)
 
 	if err != nil && errors.IsNotFound(err) {
-		log.Info("Creating Headless Service", "name", serviceName)
-
-		service = &corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      serviceName,
-				Namespace: paradedb.Namespace,
-				Labels:    r.getLabels(paradedb),
-			},
-			Spec: corev1.ServiceSpec{
-				Selector:  r.getSelectorLabels(paradedb),
-				ClusterIP: "None",
-				Ports: []corev1.ServicePort{
-					{
-						Name:     "postgres",
-						Port:     5432,
-						Protocol: corev1.ProtocolTCP,
-					},
-				},
-			},
-		}
+		service = r.buildHeadlessService(paradedb)
+		log.Info("Creating Headless Service", "name", service.Name)
 
 		if err := controllerutil.SetControllerReference(paradedb, service, r.Scheme); err != nil {
 			return err
@@ -463,25 +1064,203 @@ func (r *ParadeDBReconciler) reconcileHeadlessService(ctx context.Context, parad
 		}
 	} else if err != nil {
 		return err
+	} else {
+		if err := r.claimObject(paradedb, "Service", service); err != nil {
+			return err
+		}
+
+		desired := r.buildHeadlessService(paradedb)
+		service.Spec.Ports = desired.Spec.Ports
+		service.Spec.Selector = desired.Spec.Selector
+		service.Spec.PublishNotReadyAddresses = desired.Spec.PublishNotReadyAddresses
+		service.Labels = mergeMaps(service.Labels, desired.Labels)
+		service.Annotations = mergeMaps(service.Annotations, desired.Annotations)
+		return r.Update(ctx, service)
 	}
 
 	return nil
 }
 
-// reconcileConnectionPooler creates or updates the PgBouncer deployment
-func (r *ParadeDBReconciler) reconcileConnectionPooler(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
-	log := logf.FromContext(ctx)
-
-	// Create PgBouncer ConfigMap
-	if err := r.reconcilePoolerConfigMap(ctx, paradedb); err != nil {
-		return err
+// buildHeadlessService creates the headless Service that governs the StatefulSet. It
+// publishes pods before they are ready, so that replicas can reach their peers by DNS
+// while they bootstrap.
+func (r *ParadeDBReconciler) buildHeadlessService(paradedb *databasev1alpha1.ParadeDB) *corev1.Service {
+	labels, annotations := withMetadata(paradedb.Spec.ServiceMetadata, r.getLabels(paradedb), nil)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        paradedb.GetServiceName() + "-headless",
+			Namespace:   paradedb.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:                 r.getSelectorLabels(paradedb),
+			ClusterIP:                "None",
+			PublishNotReadyAddresses: true,
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "postgres",
+					Port:     paradedb.GetPort(),
+					Protocol: corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+// reconcilePodServices creates a Service per StatefulSet pod when enabled and removes
+// the ones that no longer match a pod
+func (r *ParadeDBReconciler) reconcilePodServices(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	wanted := map[string]bool{}
+	if paradedb.Spec.PerPodServices {
+		for i := int32(0); i < paradedb.GetReplicas(); i++ {
+			desired := r.buildPodService(paradedb, fmt.Sprintf("%s-%d", paradedb.GetStatefulSetName(), i))
+			wanted[desired.Name] = true
+
+			service := &corev1.Service{}
+			err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: paradedb.Namespace}, service)
+			if err != nil && errors.IsNotFound(err) {
+				log.Info("Creating per-pod Service", "name", desired.Name)
+
+				if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
+					return err
+				}
+
+				if err := r.Create(ctx, desired); err != nil {
+					return err
+				}
+			} else if err != nil {
+				return err
+			} else {
+				service.Spec.Ports = desired.Spec.Ports
+				service.Spec.Selector = desired.Spec.Selector
+				service.Labels = mergeMaps(service.Labels, desired.Labels)
+				service.Annotations = mergeMaps(service.Annotations, desired.Annotations)
+
+				if err := r.Update(ctx, service); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Remove Services for pods that were scaled away or when the feature is disabled
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services,
+		client.InNamespace(paradedb.Namespace),
+		client.MatchingLabels{"app.kubernetes.io/instance": paradedb.Name},
+		client.HasLabels{podServiceLabel},
+	); err != nil {
+		return err
+	}
+	for i := range services.Items {
+		service := &services.Items[i]
+		if wanted[service.Name] || !metav1.IsControlledBy(service, paradedb) {
+			continue
+		}
+
+		log.Info("Deleting per-pod Service", "name", service.Name)
+		if err := r.Delete(ctx, service); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileGatewayRoute creates or updates the TCPRoute/TLSRoute exposing the primary Service
+func (r *ParadeDBReconciler) reconcileGatewayRoute(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	desired := r.buildGatewayRoute(paradedb)
+
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(desired.GroupVersionKind())
+	err := r.Get(ctx, types.NamespacedName{Name: desired.GetName(), Namespace: desired.GetNamespace()}, route)
+
+	if err != nil && errors.IsNotFound(err) {
+		log.Info("Creating Gateway route", "kind", desired.GetKind(), "name", desired.GetName())
+
+		if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
+			return err
+		}
+
+		if err := r.Create(ctx, desired); err != nil {
+			return err
+		}
+
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "GatewayRouteCreated", desired.GetKind()+" created successfully")
+	} else if err != nil {
+		return err
+	} else {
+		// Update existing route
+		route.Object["spec"] = desired.Object["spec"]
+
+		if err := r.Update(ctx, route); err != nil {
+			return err
+		}
+	}
+
+	// Switching between TCPRoute and TLSRoute leaves the route of the other kind behind
+	for _, kind := range gatewayRouteKinds {
+		if kind != desired.GetKind() {
+			if err := r.deleteGatewayRoute(ctx, paradedb, kind); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteGatewayRoute removes the route of the given kind that the operator created for the
+// ParadeDB, so that the Gateway stops routing to the Service. A route the operator does not
+// control is left alone, as is a cluster without the Gateway API.
+func (r *ParadeDBReconciler) deleteGatewayRoute(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, kind string) error {
+	route := &unstructured.Unstructured{}
+	route.SetAPIVersion(gatewayRouteAPIVersion)
+	route.SetKind(kind)
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.Name, Namespace: paradedb.Namespace}, route)
+	if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(route, paradedb) {
+		return nil
+	}
+
+	if err := r.Delete(ctx, route); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	logf.FromContext(ctx).Info("Deleted Gateway route", "kind", kind, "name", route.GetName())
+	r.Recorder.Event(paradedb, corev1.EventTypeNormal, "GatewayRouteDeleted", kind+" deleted")
+	return nil
+}
+
+// reconcileConnectionPooler creates or updates the PgBouncer deployment
+func (r *ParadeDBReconciler) reconcileConnectionPooler(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	// Create PgBouncer ConfigMap
+	if err := r.reconcilePoolerConfigMap(ctx, paradedb); err != nil {
+		return err
+	}
+
+	// PgBouncer builds its userlist from the credentials at startup
+	credentialsHash, err := getCredentialsHash(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
 	}
 
 	// Create PgBouncer Deployment
 	deployment := &appsv1.Deployment{}
-	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetPoolerDeploymentName(), Namespace: paradedb.Namespace}, deployment)
+	err = r.Get(ctx, types.NamespacedName{Name: paradedb.GetPoolerDeploymentName(), Namespace: paradedb.Namespace}, deployment)
 
 	desired := r.buildPoolerDeployment(paradedb)
+	desired.Spec.Template.Annotations = mergeMaps(desired.Spec.Template.Annotations,
+		map[string]string{credentialsHashAnnotation: credentialsHash})
 
 	if err != nil && errors.IsNotFound(err) {
 		log.Info("Creating PgBouncer Deployment", "name", desired.Name)
@@ -497,50 +1276,118 @@ func (r *ParadeDBReconciler) reconcileConnectionPooler(ctx context.Context, para
 		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "PoolerCreated", "Connection pooler created")
 	} else if err != nil {
 		return err
+	} else {
+		if deployment.Spec.Template.Annotations[credentialsHashAnnotation] != credentialsHash {
+			log.Info("Restarting PgBouncer for changed credentials", "name", deployment.Name)
+
+			deployment.Spec.Template.Annotations = mergeMaps(deployment.Spec.Template.Annotations,
+				map[string]string{credentialsHashAnnotation: credentialsHash})
+			if err := r.Update(ctx, deployment); err != nil {
+				return err
+			}
+
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "PoolerRestarted", "Connection pooler restarted for changed credentials")
+		}
+
+		podSpec := &deployment.Spec.Template.Spec
+		if podSpec.ServiceAccountName != desired.Spec.Template.Spec.ServiceAccountName ||
+			!equality.Semantic.DeepEqual(podSpec.AutomountServiceAccountToken, desired.Spec.Template.Spec.AutomountServiceAccountToken) {
+			podSpec.ServiceAccountName = desired.Spec.Template.Spec.ServiceAccountName
+			podSpec.AutomountServiceAccountToken = desired.Spec.Template.Spec.AutomountServiceAccountToken
+			if err := r.Update(ctx, deployment); err != nil {
+				return err
+			}
+		}
+
+		// A single pooler keeps the defaulted strategy it was created with
+		if *deployment.Spec.Replicas != *desired.Spec.Replicas || (desired.Spec.Strategy.RollingUpdate != nil &&
+			!equality.Semantic.DeepEqual(deployment.Spec.Strategy, desired.Spec.Strategy)) {
+			log.Info("Updating PgBouncer replicas", "name", deployment.Name, "replicas", *desired.Spec.Replicas)
+
+			deployment.Spec.Replicas = desired.Spec.Replicas
+			deployment.Spec.Strategy = desired.Spec.Strategy
+			if err := r.Update(ctx, deployment); err != nil {
+				return err
+			}
+		}
+
+		// PgBouncer reads its databases from the environment, so changing them rolls the pods
+		containers := deployment.Spec.Template.Spec.Containers
+		desiredEnv := desired.Spec.Template.Spec.Containers[0].Env
+		if len(containers) > 0 && !equality.Semantic.DeepEqual(containers[0].Env, desiredEnv) {
+			log.Info("Updating PgBouncer databases", "name", deployment.Name)
+
+			containers[0].Env = desiredEnv
+			if err := r.Update(ctx, deployment); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := r.reconcilePoolerPDB(ctx, paradedb); err != nil {
+		return err
 	}
 
-	// Create PgBouncer Service
+	// Create or update PgBouncer Service
 	service := &corev1.Service{}
 	err = r.Get(ctx, types.NamespacedName{Name: paradedb.GetPoolerServiceName(), Namespace: paradedb.Namespace}, service)
 
-	if err != nil && errors.IsNotFound(err) {
-		service = &corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      paradedb.GetPoolerServiceName(),
-				Namespace: paradedb.Namespace,
-				Labels:    r.getLabels(paradedb),
-			},
-			Spec: corev1.ServiceSpec{
-				Selector: map[string]string{
-					"app.kubernetes.io/name":      "pgbouncer",
-					"app.kubernetes.io/instance":  paradedb.Name,
-					"app.kubernetes.io/component": "pooler",
-				},
-				Type: paradedb.Spec.ServiceType,
-				Ports: []corev1.ServicePort{
-					{
-						Name:     "pgbouncer",
-						Port:     5432,
-						Protocol: corev1.ProtocolTCP,
-					},
-				},
-			},
-		}
+	desiredService := r.buildPoolerService(paradedb)
 
-		if err := controllerutil.SetControllerReference(paradedb, service, r.Scheme); err != nil {
+	if err != nil && errors.IsNotFound(err) {
+		if err := controllerutil.SetControllerReference(paradedb, desiredService, r.Scheme); err != nil {
 			return err
 		}
 
-		if err := r.Create(ctx, service); err != nil {
+		if err := r.Create(ctx, desiredService); err != nil {
 			return err
 		}
 	} else if err != nil {
 		return err
+	} else {
+		// Update existing Service (preserve ClusterIP)
+		service.Spec.Ports = desiredService.Spec.Ports
+		service.Spec.Type = desiredService.Spec.Type
+		service.Spec.Selector = desiredService.Spec.Selector
+		service.Labels = mergeMaps(service.Labels, desiredService.Labels)
+		service.Annotations = mergeMaps(service.Annotations, desiredService.Annotations)
+
+		if err := r.Update(ctx, service); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// buildPoolerService creates the Service in front of the PgBouncer pods
+func (r *ParadeDBReconciler) buildPoolerService(paradedb *databasev1alpha1.ParadeDB) *corev1.Service {
+	labels, annotations := withMetadata(paradedb.Spec.ServiceMetadata, r.getLabels(paradedb), nil)
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        paradedb.GetPoolerServiceName(),
+			Namespace:   paradedb.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"app.kubernetes.io/name":      "pgbouncer",
+				"app.kubernetes.io/instance":  paradedb.Name,
+				"app.kubernetes.io/component": "pooler",
+			},
+			Type: paradedb.Spec.ServiceType,
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "pgbouncer",
+					Port:     5432,
+					Protocol: corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
 // reconcilePoolerConfigMap creates the PgBouncer configuration
 func (r *ParadeDBReconciler) reconcilePoolerConfigMap(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
 	configMapName := paradedb.Name + "-pooler-config"
@@ -548,8 +1395,16 @@ func (r *ParadeDBReconciler) reconcilePoolerConfigMap(ctx context.Context, parad
 	err := r.Get(ctx, types.NamespacedName{Name: configMapName, Namespace: paradedb.Namespace}, configMap)
 
 	pooling := paradedb.Spec.ConnectionPooling
+	databases := []string{paradedb.Spec.Auth.Database + " = " +
+		buildPoolerConnString(paradedb, databasev1alpha1.PoolerDatabaseSpec{Name: paradedb.Spec.Auth.Database})}
+	for _, database := range paradedb.GetPoolerDatabases() {
+		databases = append(databases, database.Name+" = "+buildPoolerConnString(paradedb, database))
+	}
+	for _, route := range paradedb.GetPoolerRoutes() {
+		databases = append(databases, route.Name+" = "+buildPoolerRouteConnString(paradedb, route))
+	}
 	pgbouncerIni := fmt.Sprintf(`[databases]
-%s = host=%s port=5432 dbname=%s
+%s
 
 [pgbouncer]
 listen_addr = 0.0.0.0
@@ -564,9 +1419,7 @@ reserve_pool_size = %d
 admin_users = postgres
 stats_users = postgres
 `,
-		paradedb.Spec.Auth.Database,
-		paradedb.GetServiceName(),
-		paradedb.Spec.Auth.Database,
+		strings.Join(databases, "\n"),
 		pooling.PoolMode,
 		pooling.MaxClientConnections,
 		pooling.DefaultPoolSize,
@@ -593,6 +1446,9 @@ stats_users = postgres
 		return r.Create(ctx, configMap)
 	} else if err != nil {
 		return err
+	} else if configMap.Data["pgbouncer.ini"] != pgbouncerIni {
+		configMap.Data = map[string]string{"pgbouncer.ini": pgbouncerIni}
+		return r.Update(ctx, configMap)
 	}
 
 	return nil
@@ -605,33 +1461,20 @@ func (r *ParadeDBReconciler) reconcileMetricsService(ctx context.Context, parade
 	service := &corev1.Service{}
 	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetMetricsServiceName(), Namespace: paradedb.Namespace}, service)
 
-	metricsPort := int32(9187)
-	if paradedb.Spec.Monitoring != nil && paradedb.Spec.Monitoring.Port != 0 {
-		metricsPort = paradedb.Spec.Monitoring.Port
-	}
-
 	if err != nil && errors.IsNotFound(err) {
 		log.Info("Creating Metrics Service", "name", paradedb.GetMetricsServiceName())
 
+		labels, annotations := withMetadata(paradedb.Spec.ServiceMetadata, r.getLabels(paradedb), buildPrometheusAnnotations(paradedb))
 		service = &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      paradedb.GetMetricsServiceName(),
-				Namespace: paradedb.Namespace,
-				Labels:    r.getLabels(paradedb),
-				Annotations: map[string]string{
-					"prometheus.io/scrape": "true",
-					"prometheus.io/port":   fmt.Sprintf("%d", metricsPort),
-				},
+				Name:        paradedb.GetMetricsServiceName(),
+				Namespace:   paradedb.Namespace,
+				Labels:      labels,
+				Annotations: annotations,
 			},
 			Spec: corev1.ServiceSpec{
-				Selector: r.getSelectorLabels(paradedb),
-				Ports: []corev1.ServicePort{
-					{
-						Name:     "metrics",
-						Port:     metricsPort,
-						Protocol: corev1.ProtocolTCP,
-					},
-				},
+				Selector: r.getMetricsSelector(paradedb),
+				Ports:    buildMetricsServicePorts(paradedb),
 			},
 		}
 
@@ -644,6 +1487,25 @@ func (r *ParadeDBReconciler) reconcileMetricsService(ctx context.Context, parade
 		}
 	} else if err != nil {
 		return err
+	} else {
+		// Only the scrape annotations, the spec.serviceMetadata, the selector and the ports
+		// are kept in sync, leaving the rest in place
+		labels, userAnnotations := withMetadata(paradedb.Spec.ServiceMetadata, r.getLabels(paradedb), nil)
+		labels = mergeMaps(service.Labels, labels)
+		annotations, _ := syncPrometheusAnnotations(service.Annotations, buildPrometheusAnnotations(paradedb))
+		annotations = mergeMaps(annotations, userAnnotations)
+		selector := r.getMetricsSelector(paradedb)
+		ports := buildMetricsServicePorts(paradedb)
+		if !maps.Equal(service.Labels, labels) || !maps.Equal(service.Annotations, annotations) ||
+			!maps.Equal(service.Spec.Selector, selector) || metricsServicePortsChanged(service.Spec.Ports, ports) {
+			service.Labels = labels
+			service.Annotations = annotations
+			service.Spec.Selector = selector
+			service.Spec.Ports = ports
+			if err := r.Update(ctx, service); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -657,7 +1519,7 @@ func (r *ParadeDBReconciler) reconcileBackupCronJob(ctx context.Context, paraded
 }
 
 // updateStatus updates the ParadeDB status based on the StatefulSet status
-func (r *ParadeDBReconciler) updateStatus(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+func (r *ParadeDBReconciler) updateStatus(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, persisted *databasev1alpha1.ParadeDBStatus) error {
 	statefulSet := &appsv1.StatefulSet{}
 	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetStatefulSetName(), Namespace: paradedb.Namespace}, statefulSet)
 	if err != nil {
@@ -665,116 +1527,280 @@ func (r *ParadeDBReconciler) updateStatus(ctx context.Context, paradedb *databas
 	}
 
 	// Update ready replicas
+	// Reconciliation got this far, so clear any failure left by an earlier attempt
+	if paradedb.Status.FailureCount > 0 {
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "Recovered",
+			fmt.Sprintf("Reconciliation succeeded after %d failed attempts", paradedb.Status.FailureCount))
+		paradedb.Status.FailureCount = 0
+	}
+	if degraded := meta.FindStatusCondition(paradedb.Status.Conditions, ConditionTypeDegraded); degraded != nil &&
+		(degraded.Reason == "ReconciliationFailed" || degraded.Reason == "InvalidSpec") {
+		setCondition(paradedb, ConditionTypeDegraded, metav1.ConditionFalse, "ReconciliationSucceeded", "Reconciliation succeeded")
+	}
+
 	paradedb.Status.ReadyReplicas = statefulSet.Status.ReadyReplicas
+	paradedb.Status.Selector = metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: r.getSelectorLabels(paradedb)})
 	paradedb.Status.ObservedGeneration = paradedb.Generation
-	paradedb.Status.CurrentVersion = paradedb.GetImage()
+	if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeOperationBlocked) {
+		paradedb.Status.CurrentVersion = paradedb.GetImage()
+	}
 
-	// Determine phase based on replica status
+	// Determine phase based on replica status. A revision mismatch means the StatefulSet
+	// is rolling out a spec change, which is reported separately from scaling.
 	desiredReplicas := paradedb.GetReplicas()
-	if statefulSet.Status.ReadyReplicas == desiredReplicas {
+	readyReplicas := statefulSet.Status.ReadyReplicas
+	rollingOut := statefulSet.Status.CurrentRevision != "" &&
+		statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision
+
+	switch {
+	case rollingOut:
+		paradedb.Status.Phase = databasev1alpha1.ParadeDBPhaseUpdating
+		paradedb.Status.Message = fmt.Sprintf("Rolling out changes: %d/%d replicas updated",
+			statefulSet.Status.UpdatedReplicas, desiredReplicas)
+
+		setCondition(paradedb, ConditionTypeProgressing, metav1.ConditionTrue, "RollingUpdate", paradedb.Status.Message)
+		if readyReplicas == desiredReplicas {
+			setCondition(paradedb, ConditionTypeReady, metav1.ConditionTrue, "AllReplicasReady",
+				fmt.Sprintf("All %d replicas are ready", desiredReplicas))
+		} else {
+			setCondition(paradedb, ConditionTypeReady, metav1.ConditionFalse, "RollingUpdate",
+				fmt.Sprintf("%d/%d replicas ready during rollout", readyReplicas, desiredReplicas))
+		}
+	case readyReplicas == desiredReplicas:
 		paradedb.Status.Phase = databasev1alpha1.ParadeDBPhaseRunning
 		paradedb.Status.Message = "ParadeDB is running"
+		// The StatefulSet controller has to have seen the latest template first
+		if statefulSet.Status.ObservedGeneration >= statefulSet.Generation {
+			for _, operation := range completeRollouts(paradedb) {
+				r.emitOperationEvent(paradedb, operation)
+			}
+		}
 
-		meta.SetStatusCondition(&paradedb.Status.Conditions, metav1.Condition{
-			Type:               ConditionTypeReady,
-			Status:             metav1.ConditionTrue,
-			Reason:             "AllReplicasReady",
-			Message:            fmt.Sprintf("All %d replicas are ready", desiredReplicas),
-			LastTransitionTime: metav1.Now(),
-		})
-
-		meta.SetStatusCondition(&paradedb.Status.Conditions, metav1.Condition{
-			Type:               ConditionTypeProgressing,
-			Status:             metav1.ConditionFalse,
-			Reason:             "DeploymentComplete",
-			Message:            "Deployment complete",
-			LastTransitionTime: metav1.Now(),
-		})
-
-		meta.SetStatusCondition(&paradedb.Status.Conditions, metav1.Condition{
-			Type:               ConditionTypeDegraded,
-			Status:             metav1.ConditionFalse,
-			Reason:             "AllReplicasHealthy",
-			Message:            "All replicas are healthy",
-			LastTransitionTime: metav1.Now(),
-		})
-	} else if statefulSet.Status.ReadyReplicas > 0 {
+		setCondition(paradedb, ConditionTypeReady, metav1.ConditionTrue, "AllReplicasReady",
+			fmt.Sprintf("All %d replicas are ready", desiredReplicas))
+		setCondition(paradedb, ConditionTypeProgressing, metav1.ConditionFalse, "DeploymentComplete", "Deployment complete")
+		setCondition(paradedb, ConditionTypeDegraded, metav1.ConditionFalse, "AllReplicasHealthy", "All replicas are healthy")
+	case readyReplicas > 0:
 		paradedb.Status.Phase = databasev1alpha1.ParadeDBPhaseUpdating
-		paradedb.Status.Message = fmt.Sprintf("Scaling: %d/%d replicas ready", statefulSet.Status.ReadyReplicas, desiredReplicas)
-
-		meta.SetStatusCondition(&paradedb.Status.Conditions, metav1.Condition{
-			Type:               ConditionTypeProgressing,
-			Status:             metav1.ConditionTrue,
-			Reason:             "Scaling",
-			Message:            paradedb.Status.Message,
-			LastTransitionTime: metav1.Now(),
-		})
-	} else {
+		paradedb.Status.Message = fmt.Sprintf("Scaling: %d/%d replicas ready", readyReplicas, desiredReplicas)
+
+		setCondition(paradedb, ConditionTypeProgressing, metav1.ConditionTrue, "Scaling", paradedb.Status.Message)
+		setCondition(paradedb, ConditionTypeReady, metav1.ConditionFalse, "Scaling", paradedb.Status.Message)
+	default:
 		paradedb.Status.Phase = databasev1alpha1.ParadeDBPhaseCreating
 		paradedb.Status.Message = "Waiting for replicas to become ready"
 
-		meta.SetStatusCondition(&paradedb.Status.Conditions, metav1.Condition{
-			Type:               ConditionTypeProgressing,
-			Status:             metav1.ConditionTrue,
-			Reason:             "Creating",
-			Message:            "Creating ParadeDB pods",
-			LastTransitionTime: metav1.Now(),
-		})
+		// Tell a pod waiting on its volume or on a node apart from one that is starting
+		reason, message, err := r.diagnosePendingPods(ctx, paradedb)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			paradedb.Status.Message = message
+			setCondition(paradedb, ConditionTypeProgressing, metav1.ConditionTrue, reason, message)
+			setCondition(paradedb, ConditionTypeReady, metav1.ConditionFalse, reason, message)
+		} else {
+			setCondition(paradedb, ConditionTypeProgressing, metav1.ConditionTrue, "Creating", "Creating ParadeDB pods")
+			setCondition(paradedb, ConditionTypeReady, metav1.ConditionFalse, "NoReplicasReady", paradedb.Status.Message)
+		}
+	}
+
+	if err := r.updateComponentStatus(ctx, paradedb, statefulSet); err != nil {
+		return err
 	}
 
+	// Fail a replica cluster over before anything relies on it accepting writes
+	r.promoteReplicaCluster(ctx, paradedb)
+
+	// Check that the database actually accepts connections
+	r.setDatabaseReachableCondition(ctx, paradedb, readyReplicas)
+
+	// Provision the change data capture role and slots once the database is reachable.
+	// A standby is read-only, so it only gets them once promoted.
+	if paradedb.IsCDCEnabled() && !paradedb.IsStandby() {
+		r.setCDCReadyCondition(ctx, paradedb)
+	} else {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeCDCReady)
+		paradedb.Status.CDCSlots = nil
+		paradedb.Status.CDCRoleHash = ""
+	}
+
+	// Keep the additional databases in existence
+	if (len(paradedb.Spec.Auth.Databases) > 0 || paradedb.HasSessionLimits()) && !paradedb.IsStandby() {
+		r.setDatabasesReadyCondition(ctx, paradedb)
+	} else {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeDatabasesReady)
+	}
+
+	// Keep the workload roles' passwords and limits in line with the spec
+	if paradedb.HasWorkloadRoles() && !paradedb.IsStandby() {
+		r.setWorkloadRolesReadyCondition(ctx, paradedb)
+	} else {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeWorkloadRolesReady)
+	}
+
+	// Create the temp tablespace on the node-local volume. A standby is read-only.
+	if paradedb.IsLocalCacheEnabled() && !paradedb.IsStandby() {
+		r.setLocalCacheReadyCondition(ctx, paradedb)
+	} else {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeLocalCacheReady)
+	}
+
+	// Keep the analytics user mappings in sync with their credentials
+	if len(paradedb.GetAnalyticsServers()) > 0 && !paradedb.IsStandby() {
+		r.setAnalyticsReadyCondition(ctx, paradedb)
+	} else {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeAnalyticsReady)
+	}
+
+	// Apply pg_hba.conf and configuration fragment changes without restarting the pods
+	r.reloadConfiguration(ctx, paradedb)
+	r.setConfigDriftCondition(ctx, paradedb)
+	r.setPendingRestartCondition(ctx, paradedb)
+
+	// Run the self-test requested with the self-test annotation
+	r.runSelfTest(ctx, paradedb)
+
 	// Set endpoint
-	paradedb.Status.Endpoint = fmt.Sprintf("%s.%s.svc.cluster.local:5432", paradedb.GetServiceName(), paradedb.Namespace)
+	paradedb.Status.Endpoint = fmt.Sprintf("%s:%d", paradedb.GetHost(), paradedb.GetPort())
 
 	if paradedb.IsConnectionPoolingEnabled() {
 		paradedb.Status.PoolerEndpoint = fmt.Sprintf("%s.%s.svc.cluster.local:5432", paradedb.GetPoolerServiceName(), paradedb.Namespace)
 	}
+	r.updatePoolerStatus(ctx, paradedb)
 
+	// Skip the write when nothing has changed
+	if equality.Semantic.DeepEqual(persisted, &paradedb.Status) {
+		return nil
+	}
 	return r.Status().Update(ctx, paradedb)
 }
 
+// setDatabaseReachableCondition connects to the primary Service with the managed
+// credentials, records the outcome of a trivial query and updates the
+// capacity figures and installed extensions in the status
+func (r *ParadeDBReconciler) setDatabaseReachableCondition(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, readyReplicas int32) {
+	if readyReplicas == 0 {
+		setCondition(paradedb, ConditionTypeDatabaseReachable, metav1.ConditionUnknown, "NoReadyReplicas",
+			"Waiting for a ready replica before checking connectivity")
+		return
+	}
+
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		setCondition(paradedb, ConditionTypeDatabaseReachable, metav1.ConditionUnknown, "CredentialsUnavailable",
+			fmt.Sprintf("Failed to read credentials: %v", err))
+		return
+	}
+
+	connectionURL := buildConnectionURL(paradedb, username, password)
+	stats, err := queryDatabaseStats(ctx, connectionURL)
+	if err != nil {
+		setCondition(paradedb, ConditionTypeDatabaseReachable, metav1.ConditionFalse, "QueryFailed",
+			fmt.Sprintf("Failed to query the database: %v", err))
+		return
+	}
+
+	setCondition(paradedb, ConditionTypeDatabaseReachable, metav1.ConditionTrue, "QuerySucceeded",
+		"Query succeeded")
+
+	paradedb.Status.Extensions = stats.Extensions
+	refreshCapacityFigures(paradedb, stats, time.Now())
+	setContinuousArchivingCondition(paradedb, stats.WALArchive)
+	paradedb.Status.Replication = buildReplicationStatus(paradedb, stats.Replication)
+}
+
+// refreshCapacityFigures records the database size, data volume usage and connection
+// count. They change on nearly every query, so they are refreshed at most once per
+// periodic reconciliation rather than on every event.
+func refreshCapacityFigures(paradedb *databasev1alpha1.ParadeDB, stats *databaseStats, now time.Time) {
+	if updated := paradedb.Status.StatsUpdatedTime; updated != nil && now.Sub(updated.Time) < requeueAfterSuccess {
+		return
+	}
+
+	paradedb.Status.DatabaseSizeBytes = stats.SizeBytes
+	paradedb.Status.CurrentConnections = stats.Connections
+	if capacity := paradedb.Spec.Storage.Size.Value(); capacity > 0 {
+		paradedb.Status.DataVolumeUsedPercent = int32((stats.SizeBytes + stats.WALBytes) * 100 / capacity)
+	}
+	updated := metav1.NewTime(now)
+	paradedb.Status.StatsUpdatedTime = &updated
+}
+
+// buildReplicationStatus summarizes the replication figures for status.replication. A
+// primary that neither streams nor holds slots has nothing to report.
+func buildReplicationStatus(paradedb *databasev1alpha1.ParadeDB, stats replicationStats) *databasev1alpha1.ReplicationStatus {
+	if stats.InRecovery {
+		status := &databasev1alpha1.ReplicationStatus{Role: "Standby", State: databasev1alpha1.ReplicationDisconnected,
+			Senders: stats.Senders, InactiveSlots: stats.InactiveSlots, LagSeconds: stats.LagSeconds}
+		if stats.Receiving {
+			status.State = databasev1alpha1.ReplicationStreaming
+		} else if paradedb.IsArchiveStandby() {
+			status.State = databasev1alpha1.ReplicationArchiveReplay
+		}
+		return status
+	}
+
+	if stats.Senders == 0 && stats.InactiveSlots == 0 {
+		return nil
+	}
+	status := &databasev1alpha1.ReplicationStatus{Role: "Primary", State: databasev1alpha1.ReplicationStreaming,
+		Senders: stats.Senders, InactiveSlots: stats.InactiveSlots}
+	if stats.InactiveSlots > 0 {
+		status.State = databasev1alpha1.ReplicationInactiveSlots
+	}
+	return status
+}
+
+// setContinuousArchivingCondition reports the archiver statistics in the status, so that
+// a failing archive_command is noticed before the archive is needed for a restore
+func setContinuousArchivingCondition(paradedb *databasev1alpha1.ParadeDB, archive *databasev1alpha1.WALArchiveStatus) {
+	paradedb.Status.WALArchive = archive
+	switch {
+	case archive == nil:
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeContinuousArchiving)
+	case archive.Failing:
+		setCondition(paradedb, ConditionTypeContinuousArchiving, metav1.ConditionFalse, "ArchiveFailing",
+			fmt.Sprintf("Archiving WAL segment %s has been failing since %s", archive.LastFailedWAL,
+				archive.LastFailedTime.UTC().Format(time.RFC3339)))
+	case archive.LastArchivedTime == nil:
+		setCondition(paradedb, ConditionTypeContinuousArchiving, metav1.ConditionUnknown, "NoSegmentArchived",
+			"No WAL segment has been archived yet")
+	default:
+		setCondition(paradedb, ConditionTypeContinuousArchiving, metav1.ConditionTrue, "Archiving",
+			fmt.Sprintf("Archived WAL segment %s at %s", archive.LastArchivedWAL,
+				archive.LastArchivedTime.UTC().Format(time.RFC3339)))
+	}
+}
+
 // buildStatefulSet creates the StatefulSet spec for ParadeDB
 func (r *ParadeDBReconciler) buildStatefulSet(paradedb *databasev1alpha1.ParadeDB) *appsv1.StatefulSet {
 	labels := r.getLabels(paradedb)
 	selectorLabels := r.getSelectorLabels(paradedb)
 	replicas := paradedb.GetReplicas()
+	terminationGracePeriod := paradedb.GetTerminationGracePeriodSeconds()
 
-	// Get credentials secret name
-	credentialsSecretName := paradedb.Name + "-credentials"
-	if paradedb.Spec.Auth.SuperuserSecretRef != nil {
-		credentialsSecretName = paradedb.Spec.Auth.SuperuserSecretRef.Name
+	env := buildSuperuserEnv(paradedb, "POSTGRES_USER", "POSTGRES_PASSWORD")
+	if paradedb.IsSuperuserPasswordless() {
+		// Lets the entrypoint initialize without a password. The method only goes into the
+		// data directory's pg_hba.conf, which is not used in favor of hba_file.
+		env = append(env, corev1.EnvVar{Name: "POSTGRES_HOST_AUTH_METHOD", Value: "trust"})
 	}
 
 	// Build containers
 	containers := []corev1.Container{
 		{
-			Name:  "paradedb",
-			Image: paradedb.GetImage(),
+			Name:            "paradedb",
+			Image:           paradedb.GetImage(),
+			ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
 			Ports: []corev1.ContainerPort{
 				{
 					Name:          "postgres",
-					ContainerPort: 5432,
+					ContainerPort: paradedb.GetPort(),
 					Protocol:      corev1.ProtocolTCP,
 				},
 			},
-			Env: []corev1.EnvVar{
-				{
-					Name: "POSTGRES_USER",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
-							Key:                  "username",
-						},
-					},
-				},
-				{
-					Name: "POSTGRES_PASSWORD",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
-							Key:                  "password",
-						},
-					},
-				},
+			Env: mergeEnv(append(env, []corev1.EnvVar{
 				{
 					Name:  "POSTGRES_DB",
 					Value: paradedb.Spec.Auth.Database,
@@ -783,7 +1809,19 @@ func (r *ParadeDBReconciler) buildStatefulSet(paradedb *databasev1alpha1.ParadeD
 					Name:  "PGDATA",
 					Value: "/var/lib/postgresql/data/pgdata",
 				},
-			},
+				{
+					Name:  "PGPORT",
+					Value: fmt.Sprintf("%d", paradedb.GetPort()),
+				},
+			}...), paradedb.Spec.Env),
+			EnvFrom: paradedb.Spec.EnvFrom,
+			Command: paradedb.Spec.Command,
+			// The entrypoint passes these on to postgres. The last of repeated settings
+			// wins, so the operator's follow the user's.
+			Args: append(slices.Clone(paradedb.Spec.Args),
+				"-c", "hba_file="+hbaFilePath,
+				"-c", fmt.Sprintf("max_connections=%d", paradedb.GetMaxConnections()),
+			),
 			VolumeMounts: []corev1.VolumeMount{
 				{
 					Name:      "data",
@@ -793,105 +1831,172 @@ func (r *ParadeDBReconciler) buildStatefulSet(paradedb *databasev1alpha1.ParadeD
 					Name:      "config",
 					MountPath: "/docker-entrypoint-initdb.d",
 				},
-			},
-			Resources: paradedb.Spec.Resources,
-			LivenessProbe: &corev1.Probe{
-				ProbeHandler: corev1.ProbeHandler{
-					Exec: &corev1.ExecAction{
-						Command: []string{"pg_isready", "-U", "postgres"},
-					},
+				{
+					Name:      "config",
+					MountPath: configMountPath,
 				},
-				InitialDelaySeconds: 30,
-				PeriodSeconds:       10,
-				TimeoutSeconds:      5,
-				FailureThreshold:    6,
 			},
-			ReadinessProbe: &corev1.Probe{
-				ProbeHandler: corev1.ProbeHandler{
+			Resources:      paradedb.Spec.Resources,
+			LivenessProbe:  buildLivenessProbe(paradedb),
+			ReadinessProbe: buildReadinessProbe(paradedb),
+			StartupProbe:   buildStartupProbe(paradedb),
+			Lifecycle: &corev1.Lifecycle{
+				PreStop: &corev1.LifecycleHandler{
 					Exec: &corev1.ExecAction{
-						Command: []string{"pg_isready", "-U", "postgres"},
+						Command: buildPreStopCommand(terminationGracePeriod),
 					},
 				},
-				InitialDelaySeconds: 5,
-				PeriodSeconds:       5,
-				TimeoutSeconds:      3,
-				FailureThreshold:    3,
 			},
 		},
 	}
 
-	// Add metrics exporter sidecar if monitoring is enabled
-	if paradedb.IsMonitoringEnabled() {
-		metricsImage := "quay.io/prometheuscommunity/postgres-exporter:latest"
-		metricsPort := int32(9187)
-		if paradedb.Spec.Monitoring != nil {
-			if paradedb.Spec.Monitoring.Image != "" {
-				metricsImage = paradedb.Spec.Monitoring.Image
-			}
-			if paradedb.Spec.Monitoring.Port != 0 {
-				metricsPort = paradedb.Spec.Monitoring.Port
-			}
-		}
+	// Only set with spec.bootstrap.initdb, so that existing pods are not restarted
+	if initDBArgs := buildInitDBArgs(paradedb); initDBArgs != "" {
+		containers[0].Env = mergeEnv([]corev1.EnvVar{{Name: "POSTGRES_INITDB_ARGS", Value: initDBArgs}}, containers[0].Env)
+	}
 
-		exporterContainer := corev1.Container{
-			Name:  "postgres-exporter",
-			Image: metricsImage,
-			Ports: []corev1.ContainerPort{
-				{
-					Name:          "metrics",
-					ContainerPort: metricsPort,
-					Protocol:      corev1.ProtocolTCP,
-				},
-			},
-			Env: []corev1.EnvVar{
-				{
-					Name:  "DATA_SOURCE_URI",
-					Value: "localhost:5432/" + paradedb.Spec.Auth.Database + "?sslmode=disable",
-				},
-				{
-					Name: "DATA_SOURCE_USER",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
-							Key:                  "username",
-						},
-					},
-				},
-				{
-					Name: "DATA_SOURCE_PASS",
-					ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
-							Key:                  "password",
-						},
-					},
-				},
-			},
+	// Add metrics exporter sidecar if monitoring is enabled and it does not run on its own
+	var exporterVolumes []corev1.Volume
+	if paradedb.IsMonitoringEnabled() && !paradedb.IsExporterDeployment() && !paradedb.IsMonitoringSuspended() {
+		var exporterContainer corev1.Container
+		exporterContainer, exporterVolumes = r.buildExporterContainer(paradedb, "localhost", "disable")
+		containers = append(containers, exporterContainer)
+		if paradedb.HasSQLMetrics() {
+			containers = append(containers, r.buildSQLExporterContainer(paradedb))
 		}
+	}
 
-		if paradedb.Spec.Monitoring != nil {
-			exporterContainer.Resources = paradedb.Spec.Monitoring.Resources
-		}
+	// Add user-defined sidecars
+	containers = append(containers, paradedb.Spec.Sidecars...)
 
-		containers = append(containers, exporterContainer)
+	// Check the data volume before anything writes to it, then have a replica cluster
+	// clone its data from the primary before PostgreSQL starts
+	var initContainers []corev1.Container
+	if paradedb.IsVolumeCheckEnabled() {
+		volumeCheck := buildVolumeCheckContainer(paradedb)
+		volumeCheck.SecurityContext = paradedb.Spec.ContainerSecurityContext
+		initContainers = append(initContainers, volumeCheck)
+	}
+	if paradedb.IsArchiveReplica() {
+		initContainers = append(initContainers, r.buildBaseBackupDownloadContainer(paradedb))
+	}
+	if paradedb.Spec.ReplicaOf != nil {
+		bootstrap := buildReplicaBootstrapContainer(paradedb)
+		bootstrap.SecurityContext = paradedb.Spec.ContainerSecurityContext
+		initContainers = append(initContainers, bootstrap)
 	}
+	if paradedb.IsLocalCacheEnabled() {
+		initContainers = append(initContainers, buildLocalCacheContainer(paradedb))
+	}
+	initContainers = append(initContainers, paradedb.Spec.InitContainers...)
 
 	// Apply container security context
 	if paradedb.Spec.ContainerSecurityContext != nil {
 		containers[0].SecurityContext = paradedb.Spec.ContainerSecurityContext
 	}
 
+	containers[0].Args = append(containers[0].Args, buildWALArgs(paradedb)...)
+	containers[0].Args = append(containers[0].Args, buildAutovacuumArgs(paradedb)...)
+
+	// A standby without a network path to its primary replays WAL fetched from the archive.
+	// The pod template stays the same on promotion so that the pods do not restart.
+	if paradedb.IsArchiveReplica() {
+		containers[0].Args = append(containers[0].Args, buildArchiveRestoreArgs()...)
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts,
+			corev1.VolumeMount{Name: "wal-archive", MountPath: walArchiveMountPath})
+		containers = append(containers, r.buildWALFetchContainer(paradedb))
+	}
+
+	// Fail at startup rather than silently fall back to regular pages
+	if paradedb.UsesHugePages() {
+		containers[0].Args = append(containers[0].Args, "-c", "huge_pages=on")
+	}
+
+	// Spill temporary files to the node-local volume
+	if paradedb.IsLocalCacheEnabled() {
+		containers[0].Args = append(containers[0].Args, "-c", "temp_tablespaces="+localCacheTablespace)
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts,
+			corev1.VolumeMount{Name: "local-cache", MountPath: localCacheMountPath})
+	}
+
+	// Mount user-supplied volumes into the ParadeDB container
+	containers[0].VolumeMounts = append(containers[0].VolumeMounts, paradedb.Spec.ExtraVolumeMounts...)
+
+	volumes := []corev1.Volume{
+		{
+			Name: "config",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: paradedb.Name + "-config",
+					},
+				},
+			},
+		},
+	}
+	// Start PostgreSQL from a configuration that includes the ConfigMap fragments
+	if len(paradedb.Spec.PostgresConfigFrom) > 0 {
+		var sources []corev1.VolumeProjection
+		for _, selector := range paradedb.Spec.PostgresConfigFrom {
+			sources = append(sources, corev1.VolumeProjection{ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: selector.LocalObjectReference,
+				Items:                []corev1.KeyToPath{{Key: selector.Key, Path: selector.Name + "/" + selector.Key}},
+				Optional:             selector.Optional,
+			}})
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name:         "config-fragments",
+			VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: sources}},
+		})
+		containers[0].VolumeMounts = append(containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "config-fragments",
+			MountPath: configFragmentsMountPath,
+		})
+		containers[0].Args = append(containers[0].Args, "-c", "config_file="+configMountPath+"/"+includeConfigKey)
+	}
+	if paradedb.IsArchiveReplica() {
+		volumes = append(volumes, corev1.Volume{
+			Name:         "wal-archive",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+	volumes = append(volumes, exporterVolumes...)
+	if paradedb.IsLocalCacheEnabled() {
+		volumes = append(volumes, buildLocalCacheVolume(paradedb))
+	}
+	volumes = append(volumes, paradedb.Spec.ExtraVolumes...)
+
 	// Build PVC template
 	accessModes := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
 	if len(paradedb.Spec.Storage.AccessModes) > 0 {
 		accessModes = paradedb.Spec.Storage.AccessModes
 	}
 
+	// A standalone exporter carries the scrape annotations on its own pods
+	scrapeAnnotations := map[string]string{}
+	if !paradedb.IsExporterDeployment() {
+		scrapeAnnotations = buildPrometheusAnnotations(paradedb)
+	}
+	for k, v := range buildServiceMeshAnnotations(paradedb, paradedb.GetPort()) {
+		scrapeAnnotations[k] = v
+	}
+	podLabels, podAnnotations := withMetadata(paradedb.Spec.PodMetadata, labels, scrapeAnnotations)
+	pvcLabels, pvcAnnotations := withMetadata(paradedb.Spec.PodMetadata, labels, nil)
+
+	// Changing the pod template annotation rolls the pods, highest ordinal (replicas) first
+	if restart := paradedb.Annotations[restartAnnotation]; restart != "" {
+		podAnnotations[restartedAtAnnotation] = restart
+	}
+	if paradedb.HasSQLMetrics() && !paradedb.IsExporterDeployment() && !paradedb.IsMonitoringSuspended() {
+		podAnnotations[sqlExporterConfigHashAnnotation] = shortHash(buildSQLExporterConfig(paradedb))
+	}
+
 	volumeClaimTemplates := []corev1.PersistentVolumeClaim{
 		{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:   "data",
-				Labels: labels,
+				Name:        "data",
+				Labels:      pvcLabels,
+				Annotations: pvcAnnotations,
 			},
 			Spec: corev1.PersistentVolumeClaimSpec{
 				AccessModes: accessModes,
@@ -904,6 +2009,15 @@ func (r *ParadeDBReconciler) buildStatefulSet(paradedb *databasev1alpha1.ParadeD
 			},
 		},
 	}
+	if snapshot := paradedb.GetBootstrapSnapshot(); snapshot != "" {
+		// The VolumeSnapshot is named after the ParadeDBSnapshot
+		snapshotAPIGroup := "snapshot.storage.k8s.io"
+		volumeClaimTemplates[0].Spec.DataSource = &corev1.TypedLocalObjectReference{
+			APIGroup: &snapshotAPIGroup,
+			Kind:     "VolumeSnapshot",
+			Name:     snapshot,
+		}
+	}
 
 	statefulSet := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -919,47 +2033,77 @@ func (r *ParadeDBReconciler) buildStatefulSet(paradedb *databasev1alpha1.ParadeD
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
-					Annotations: map[string]string{
-						"prometheus.io/scrape": "true",
-						"prometheus.io/port":   "9187",
-					},
+					Labels:      podLabels,
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
-					Containers:       containers,
-					NodeSelector:     paradedb.Spec.NodeSelector,
-					Tolerations:      paradedb.Spec.Tolerations,
-					Affinity:         paradedb.Spec.Affinity,
-					SecurityContext:  paradedb.Spec.PodSecurityContext,
-					ImagePullSecrets: []corev1.LocalObjectReference{},
-					Volumes: []corev1.Volume{
-						{
-							Name: "config",
-							VolumeSource: corev1.VolumeSource{
-								ConfigMap: &corev1.ConfigMapVolumeSource{
-									LocalObjectReference: corev1.LocalObjectReference{
-										Name: paradedb.Name + "-config",
-									},
-								},
-							},
-						},
-					},
+					InitContainers:                initContainers,
+					Containers:                    containers,
+					NodeSelector:                  paradedb.Spec.NodeSelector,
+					Tolerations:                   paradedb.Spec.Tolerations,
+					Affinity:                      buildAffinity(paradedb, withArchitectureAffinity(paradedb, paradedb.Spec.Affinity), r.getSelectorLabels(paradedb)),
+					TopologySpreadConstraints:     r.buildTopologySpreadConstraints(paradedb),
+					SecurityContext:               buildPodSecurityContext(paradedb),
+					TerminationGracePeriodSeconds: &terminationGracePeriod,
+					ImagePullSecrets:              paradedb.Spec.ImagePullSecrets,
+					Volumes:                       volumes,
 				},
 			},
 			VolumeClaimTemplates: volumeClaimTemplates,
+			UpdateStrategy:       buildUpdateStrategy(paradedb),
 		},
 	}
+	applyServiceAccount(paradedb, &statefulSet.Spec.Template.Spec)
+	applyDNS(paradedb, &statefulSet.Spec.Template.Spec)
 
 	return statefulSet
 }
 
+// buildTopologySpreadConstraints returns the user-provided constraints plus, when
+// spreadAcrossZones is enabled for a multi-replica instance, node and zone level spreading
+func (r *ParadeDBReconciler) buildTopologySpreadConstraints(paradedb *databasev1alpha1.ParadeDB) []corev1.TopologySpreadConstraint {
+	constraints := append([]corev1.TopologySpreadConstraint(nil), paradedb.Spec.TopologySpreadConstraints...)
+
+	if !paradedb.IsSpreadAcrossZonesEnabled() || paradedb.GetReplicas() < 2 {
+		return constraints
+	}
+
+	for _, topologyKey := range []string{corev1.LabelTopologyZone, corev1.LabelHostname} {
+		// Explicit constraints for the same topology key take precedence
+		exists := false
+		for _, c := range constraints {
+			if c.TopologyKey == topologyKey {
+				exists = true
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: corev1.DoNotSchedule,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: r.getSelectorLabels(paradedb),
+			},
+		})
+	}
+
+	return constraints
+}
+
 // buildService creates the Service spec for ParadeDB
 func (r *ParadeDBReconciler) buildService(paradedb *databasev1alpha1.ParadeDB) *corev1.Service {
-	return &corev1.Service{
+	labels, annotations := withMetadata(paradedb.Spec.ServiceMetadata, r.getLabels(paradedb), nil)
+
+	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      paradedb.GetServiceName(),
-			Namespace: paradedb.Namespace,
-			Labels:    r.getLabels(paradedb),
+			Name:        paradedb.GetServiceName(),
+			Namespace:   paradedb.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		Spec: corev1.ServiceSpec{
 			Selector: r.getSelectorLabels(paradedb),
@@ -967,27 +2111,138 @@ func (r *ParadeDBReconciler) buildService(paradedb *databasev1alpha1.ParadeDB) *
 			Ports: []corev1.ServicePort{
 				{
 					Name:     "postgres",
-					Port:     5432,
+					Port:     paradedb.GetPort(),
 					Protocol: corev1.ProtocolTCP,
 				},
 			},
 		},
 	}
+
+	// Publish the external hostname through external-dns
+	if paradedb.Spec.Expose != nil && paradedb.Spec.Expose.Hostname != "" {
+		service.Annotations = mergeMaps(service.Annotations, map[string]string{
+			externalDNSHostnameAnnotation: paradedb.Spec.Expose.Hostname,
+		})
+	}
+
+	// Apply provider-specific settings
+	if spec := paradedb.Spec.Service; spec != nil {
+		service.Annotations = mergeMaps(service.Annotations, spec.Annotations)
+		service.Spec.LoadBalancerSourceRanges = spec.LoadBalancerSourceRanges
+		if service.Spec.Type == corev1.ServiceTypeNodePort || service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			service.Spec.ExternalTrafficPolicy = spec.ExternalTrafficPolicy
+			service.Spec.Ports[0].NodePort = spec.NodePort
+		}
+		if service.Spec.Type == corev1.ServiceTypeLoadBalancer {
+			service.Spec.LoadBalancerIP = spec.LoadBalancerIP
+		}
+	}
+
+	return service
 }
 
-// buildPoolerDeployment creates the PgBouncer Deployment spec
-func (r *ParadeDBReconciler) buildPoolerDeployment(paradedb *databasev1alpha1.ParadeDB) *appsv1.Deployment {
-	pooling := paradedb.Spec.ConnectionPooling
-	image := "bitnami/pgbouncer:latest"
-	if pooling.Image != "" {
-		image = pooling.Image
+// buildPodService creates a Service selecting a single StatefulSet pod
+func (r *ParadeDBReconciler) buildPodService(paradedb *databasev1alpha1.ParadeDB, podName string) *corev1.Service {
+	labels := r.getLabels(paradedb)
+	labels[podServiceLabel] = podName
+	labels, annotations := withMetadata(paradedb.Spec.ServiceMetadata, labels, nil)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        podName,
+			Namespace:   paradedb.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{
+				"statefulset.kubernetes.io/pod-name": podName,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "postgres",
+					Port:     paradedb.GetPort(),
+					Protocol: corev1.ProtocolTCP,
+				},
+			},
+		},
 	}
+}
 
-	credentialsSecretName := paradedb.Name + "-credentials"
-	if paradedb.Spec.Auth.SuperuserSecretRef != nil {
-		credentialsSecretName = paradedb.Spec.Auth.SuperuserSecretRef.Name
+// gatewayRouteAPIVersion is the Gateway API version of TCPRoute and TLSRoute
+const gatewayRouteAPIVersion = "gateway.networking.k8s.io/v1alpha2"
+
+// gatewayRouteKinds are the kinds of route buildGatewayRoute may create
+var gatewayRouteKinds = []string{"TCPRoute", "TLSRoute"}
+
+// getGatewayRouteKind returns TLSRoute if SNI hostnames are set, and TCPRoute otherwise
+func getGatewayRouteKind(paradedb *databasev1alpha1.ParadeDB) string {
+	if len(paradedb.Spec.Expose.GatewayAPI.Hostnames) > 0 {
+		return "TLSRoute"
+	}
+	return "TCPRoute"
+}
+
+// buildGatewayRoute creates the Gateway API route for the primary Service
+func (r *ParadeDBReconciler) buildGatewayRoute(paradedb *databasev1alpha1.ParadeDB) *unstructured.Unstructured {
+	gatewayAPI := paradedb.Spec.Expose.GatewayAPI
+
+	parentRef := map[string]interface{}{
+		"name": gatewayAPI.GatewayRef.Name,
+	}
+	if gatewayAPI.GatewayRef.Namespace != "" {
+		parentRef["namespace"] = gatewayAPI.GatewayRef.Namespace
+	}
+	if gatewayAPI.GatewayRef.SectionName != "" {
+		parentRef["sectionName"] = gatewayAPI.GatewayRef.SectionName
+	}
+
+	spec := map[string]interface{}{
+		"parentRefs": []interface{}{parentRef},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": paradedb.GetServiceName(),
+						"port": int64(paradedb.GetPort()),
+					},
+				},
+			},
+		},
+	}
+
+	kind := getGatewayRouteKind(paradedb)
+	if kind == "TLSRoute" {
+		hostnames := make([]interface{}, 0, len(gatewayAPI.Hostnames))
+		for _, hostname := range gatewayAPI.Hostnames {
+			hostnames = append(hostnames, hostname)
+		}
+		spec["hostnames"] = hostnames
 	}
 
+	route := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	route.SetAPIVersion(gatewayRouteAPIVersion)
+	route.SetKind(kind)
+	route.SetName(paradedb.Name)
+	route.SetNamespace(paradedb.Namespace)
+	route.SetLabels(r.getLabels(paradedb))
+
+	return route
+}
+
+// getPoolerImage returns the PgBouncer image of the instance
+func (r *ParadeDBReconciler) getPoolerImage(paradedb *databasev1alpha1.ParadeDB) string {
+	if image := paradedb.Spec.ConnectionPooling.Image; image != "" {
+		return image
+	}
+	return r.mirrorImage("bitnami/pgbouncer:latest")
+}
+
+// buildPoolerDeployment creates the PgBouncer Deployment spec
+func (r *ParadeDBReconciler) buildPoolerDeployment(paradedb *databasev1alpha1.ParadeDB) *appsv1.Deployment {
+	pooling := paradedb.Spec.ConnectionPooling
+	image := r.getPoolerImage(paradedb)
+
 	labels := map[string]string{
 		"app.kubernetes.io/name":       "pgbouncer",
 		"app.kubernetes.io/instance":   paradedb.Name,
@@ -995,9 +2250,46 @@ func (r *ParadeDBReconciler) buildPoolerDeployment(paradedb *databasev1alpha1.Pa
 		"app.kubernetes.io/managed-by": "paradedb-operator",
 	}
 
-	replicas := int32(1)
+	podLabels, podAnnotations := withMetadata(paradedb.Spec.PodMetadata, labels, buildServiceMeshAnnotations(paradedb, 5432))
+
+	replicas := paradedb.GetPoolerReplicas()
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "PGBOUNCER_DATABASE",
+			Value: paradedb.Spec.Auth.Database,
+		},
+		{
+			Name:  "POSTGRESQL_HOST",
+			Value: paradedb.GetServiceName(),
+		},
+		{
+			Name:  "POSTGRESQL_PORT",
+			Value: fmt.Sprintf("%d", paradedb.GetPort()),
+		},
+	}
+	env = append(env, buildSuperuserEnv(paradedb, "POSTGRESQL_USERNAME", "POSTGRESQL_PASSWORD")...)
+	if paradedb.IsSuperuserPasswordless() {
+		// The image refuses to start without a password otherwise
+		env = append(env, corev1.EnvVar{Name: "ALLOW_EMPTY_PASSWORD", Value: "yes"})
+	}
+	env = append(env, []corev1.EnvVar{
+		{
+			Name:  "PGBOUNCER_POOL_MODE",
+			Value: pooling.PoolMode,
+		},
+		{
+			Name:  "PGBOUNCER_MAX_CLIENT_CONN",
+			Value: fmt.Sprintf("%d", pooling.MaxClientConnections),
+		},
+		{
+			Name:  "PGBOUNCER_DEFAULT_POOL_SIZE",
+			Value: fmt.Sprintf("%d", pooling.DefaultPoolSize),
+		},
+	}...)
+	env = append(env, buildPoolerDatabaseEnv(paradedb)...)
 
-	return &appsv1.Deployment{
+	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      paradedb.GetPoolerDeploymentName(),
 			Namespace: paradedb.Namespace,
@@ -1010,13 +2302,15 @@ func (r *ParadeDBReconciler) buildPoolerDeployment(paradedb *databasev1alpha1.Pa
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      podLabels,
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
-							Name:  "pgbouncer",
-							Image: image,
+							Name:            "pgbouncer",
+							Image:           image,
+							ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
 							Ports: []corev1.ContainerPort{
 								{
 									Name:          "pgbouncer",
@@ -1024,46 +2318,7 @@ func (r *ParadeDBReconciler) buildPoolerDeployment(paradedb *databasev1alpha1.Pa
 									Protocol:      corev1.ProtocolTCP,
 								},
 							},
-							Env: []corev1.EnvVar{
-								{
-									Name:  "PGBOUNCER_DATABASE",
-									Value: paradedb.Spec.Auth.Database,
-								},
-								{
-									Name:  "POSTGRESQL_HOST",
-									Value: paradedb.GetServiceName(),
-								},
-								{
-									Name: "POSTGRESQL_USERNAME",
-									ValueFrom: &corev1.EnvVarSource{
-										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
-											Key:                  "username",
-										},
-									},
-								},
-								{
-									Name: "POSTGRESQL_PASSWORD",
-									ValueFrom: &corev1.EnvVarSource{
-										SecretKeyRef: &corev1.SecretKeySelector{
-											LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
-											Key:                  "password",
-										},
-									},
-								},
-								{
-									Name:  "PGBOUNCER_POOL_MODE",
-									Value: pooling.PoolMode,
-								},
-								{
-									Name:  "PGBOUNCER_MAX_CLIENT_CONN",
-									Value: fmt.Sprintf("%d", pooling.MaxClientConnections),
-								},
-								{
-									Name:  "PGBOUNCER_DEFAULT_POOL_SIZE",
-									Value: fmt.Sprintf("%d", pooling.DefaultPoolSize),
-								},
-							},
+							Env:       env,
 							Resources: pooling.Resources,
 							LivenessProbe: &corev1.Probe{
 								ProbeHandler: corev1.ProbeHandler{
@@ -1085,10 +2340,16 @@ func (r *ParadeDBReconciler) buildPoolerDeployment(paradedb *databasev1alpha1.Pa
 							},
 						},
 					},
+					ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+					Affinity:         buildAffinity(paradedb, nil, labels),
 				},
 			},
 		},
 	}
+	applyServiceAccount(paradedb, &deployment.Spec.Template.Spec)
+	deployment.Spec.Strategy = buildPoolerStrategy(paradedb)
+
+	return deployment
 }
 
 // getLabels returns labels for ParadeDB resources
@@ -1113,12 +2374,58 @@ func (r *ParadeDBReconciler) getSelectorLabels(paradedb *databasev1alpha1.Parade
 // SetupWithManager sets up the controller with the Manager
 func (r *ParadeDBReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&databasev1alpha1.ParadeDB{}).
+		// Status updates do not change the generation, so writing the status does not
+		// trigger another reconciliation
+		For(&databasev1alpha1.ParadeDB{}, builder.WithPredicates(
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&batchv1.Job{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Watches(&databasev1alpha1.ParadeDBClass{}, handler.EnqueueRequestsFromMapFunc(r.findParadeDBsForClass)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.findParadeDBsForSecret)).
 		Named("paradedb").
 		Complete(r)
 }
+
+// findParadeDBsForSecret maps a user-provided superuser Secret to the ParadeDB instances
+// referencing it
+func (r *ParadeDBReconciler) findParadeDBsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	paradedbs := &databasev1alpha1.ParadeDBList{}
+	if err := r.List(ctx, paradedbs, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list ParadeDBs for secret", "secret", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, paradedb := range paradedbs.Items {
+		if ref := paradedb.Spec.Auth.SuperuserSecretRef; ref != nil && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: paradedb.Name, Namespace: paradedb.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// findParadeDBsForClass maps a ParadeDBClass to the ParadeDB instances referencing it
+func (r *ParadeDBReconciler) findParadeDBsForClass(ctx context.Context, obj client.Object) []reconcile.Request {
+	paradedbs := &databasev1alpha1.ParadeDBList{}
+	if err := r.List(ctx, paradedbs); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list ParadeDBs for class", "class", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, paradedb := range paradedbs.Items {
+		if paradedb.Spec.ClassName == obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: paradedb.Name, Namespace: paradedb.Namespace},
+			})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// migrationLockTimeout bounds how long the lock container waits for the advisory lock
+// before it is restarted
+const migrationLockTimeout = time.Hour
+
+// ParadeDBMigrationJobReconciler reconciles a ParadeDBMigrationJob object
+type ParadeDBMigrationJobReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// ImageTagPolicy is how migrations whose image is on the latest tag are handled:
+	// Warn, Enforce, or empty to allow them
+	ImageTagPolicy string
+}
+
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbmigrationjobs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbmigrationjobs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbmigrationjobs/finalizers,verbs=update
+
+// Reconcile starts the migration once its instance is running, the migrations created
+// before it have finished and its maintenance window is open. It then takes the optional
+// backup and runs the migration Job, recording each phase in the status.
+func (r *ParadeDBMigrationJobReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	migration := &databasev1alpha1.ParadeDBMigrationJob{}
+	if err := r.Get(ctx, req.NamespacedName, migration); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if migration.Status.Phase == databasev1alpha1.MigrationPhaseSucceeded || migration.Status.Phase == databasev1alpha1.MigrationPhaseFailed {
+		return ctrl.Result{}, nil
+	}
+	if err := validateMigration(migration); err != nil {
+		return ctrl.Result{}, r.failMigration(ctx, migration, err.Error())
+	}
+
+	paradedb := &databasev1alpha1.ParadeDB{}
+	err := r.Get(ctx, types.NamespacedName{Name: migration.Spec.ParadeDBRef.Name, Namespace: migration.Namespace}, paradedb)
+	if apierrors.IsNotFound(err) && migration.IsActive() {
+		return ctrl.Result{}, r.failMigration(ctx, migration, fmt.Sprintf("ParadeDB %s was deleted", migration.Spec.ParadeDBRef.Name))
+	} else if apierrors.IsNotFound(err) {
+		return r.setMigrationPending(ctx, migration, fmt.Sprintf("ParadeDB %s not found", migration.Spec.ParadeDBRef.Name))
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	switch migration.Status.Phase {
+	case databasev1alpha1.MigrationPhaseBackingUp:
+		return ctrl.Result{}, r.reconcileMigrationBackup(ctx, migration, paradedb)
+	case databasev1alpha1.MigrationPhaseRunning:
+		return ctrl.Result{}, r.reconcileMigrationJob(ctx, migration)
+	}
+
+	if paradedb.IsStandby() {
+		return ctrl.Result{}, r.failMigration(ctx, migration, fmt.Sprintf("ParadeDB %s is a standby; migrate its primary instead", paradedb.Name))
+	}
+	if migration.Spec.Backup && !paradedb.IsLogicalBackupEnabled() {
+		return ctrl.Result{}, r.failMigration(ctx, migration, fmt.Sprintf("spec.backup needs logical backups enabled on ParadeDB %s", paradedb.Name))
+	}
+	if paradedb.Status.Phase != databasev1alpha1.ParadeDBPhaseRunning {
+		return r.setMigrationPending(ctx, migration, fmt.Sprintf("Waiting for ParadeDB %s to be running", paradedb.Name))
+	}
+	blocking, err := r.getBlockingMigration(ctx, migration)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if blocking != "" {
+		return r.setMigrationPending(ctx, migration, fmt.Sprintf("Waiting for migration %s of ParadeDB %s to finish", blocking, paradedb.Name))
+	}
+	if !inMaintenanceWindow(migration.Spec.MaintenanceWindow, time.Now()) {
+		return r.setMigrationPending(ctx, migration, "Waiting for the maintenance window")
+	}
+
+	if err := r.checkMigrationImage(migration); err != nil {
+		return ctrl.Result{}, r.failMigration(ctx, migration, err.Error())
+	}
+
+	lockKey := getMigrationLockKey(migration, paradedb)
+	migration.Status.LockKey = &lockKey
+	migration.Status.StartTime = &metav1.Time{Time: time.Now()}
+	log.Info("Starting migration", "paradedb", paradedb.Name, "database", migration.GetDatabase(paradedb), "lockKey", lockKey)
+	r.Recorder.Event(migration, corev1.EventTypeNormal, "MigrationStarted",
+		fmt.Sprintf("Migrating database %s of ParadeDB %s", migration.GetDatabase(paradedb), paradedb.Name))
+
+	if !migration.Spec.Backup {
+		return ctrl.Result{}, r.startMigrationJob(ctx, migration, paradedb)
+	}
+	err = createLogicalBackupJob(ctx, r.Client, r.Scheme, migration, paradedb, migration.GetBackupJobName())
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return ctrl.Result{}, err
+	}
+	migration.Status.Phase = databasev1alpha1.MigrationPhaseBackingUp
+	migration.Status.BackupJob = migration.GetBackupJobName()
+	migration.Status.Message = "Taking a logical backup in job " + migration.Status.BackupJob
+	return ctrl.Result{}, r.Status().Update(ctx, migration)
+}
+
+// validateMigration checks that the migration has either SQL or an image to run
+func validateMigration(migration *databasev1alpha1.ParadeDBMigrationJob) error {
+	spec := migration.Spec
+	if (spec.SQL == "") == (spec.Image == "") {
+		return errors.New("exactly one of spec.sql and spec.image must be set")
+	}
+	if spec.Image == "" && (len(spec.Command) > 0 || len(spec.Args) > 0) {
+		return errors.New("spec.command and spec.args need spec.image")
+	}
+	return nil
+}
+
+// checkMigrationImage applies the operator's image tag policy to the migration image as it
+// starts. Under Enforce it returns an error if the image is on the latest tag; under Warn
+// it reports it in an event instead.
+func (r *ParadeDBMigrationJobReconciler) checkMigrationImage(migration *databasev1alpha1.ParadeDBMigrationJob) error {
+	image := migration.Spec.Image
+	if r.ImageTagPolicy == "" || image == "" || !isFloatingTag(image) {
+		return nil
+	}
+	if r.ImageTagPolicy == ImageTagPolicyEnforce {
+		return fmt.Errorf("image %s is on the latest tag; pin a version tag or digest", image)
+	}
+	r.Recorder.Event(migration, corev1.EventTypeWarning, "FloatingImageTag", "image on the latest tag: "+image)
+	return nil
+}
+
+// getBlockingMigration returns the name of another migration of the same instance that is
+// running, or that is pending and was created first, if any. Migrations start in the
+// order they were created.
+func (r *ParadeDBMigrationJobReconciler) getBlockingMigration(ctx context.Context, migration *databasev1alpha1.ParadeDBMigrationJob) (string, error) {
+	migrations := &databasev1alpha1.ParadeDBMigrationJobList{}
+	if err := r.List(ctx, migrations, client.InNamespace(migration.Namespace)); err != nil {
+		return "", err
+	}
+	for _, other := range migrations.Items {
+		if other.Name == migration.Name || other.Spec.ParadeDBRef.Name != migration.Spec.ParadeDBRef.Name {
+			continue
+		}
+		if other.IsActive() {
+			return other.Name, nil
+		}
+		pending := other.Status.Phase == "" || other.Status.Phase == databasev1alpha1.MigrationPhasePending
+		if pending && createdBefore(&other, migration) {
+			return other.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// createdBefore orders objects by creation time, then by name
+func createdBefore(a, b metav1.Object) bool {
+	aTime, bTime := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !aTime.Equal(&bTime) {
+		return aTime.Before(&bTime)
+	}
+	return a.GetName() < b.GetName()
+}
+
+// getMigrationLockKey returns the advisory lock key of the migration, derived from the
+// instance and database unless set
+func getMigrationLockKey(migration *databasev1alpha1.ParadeDBMigrationJob, paradedb *databasev1alpha1.ParadeDB) int64 {
+	if migration.Spec.LockKey != nil {
+		return *migration.Spec.LockKey
+	}
+	hash := fnv.New64a()
+	_, _ = fmt.Fprintf(hash, "paradedb-migration/%s/%s", paradedb.Name, migration.GetDatabase(paradedb))
+	return int64(hash.Sum64())
+}
+
+// reconcileMigrationBackup starts the migration once the pre-migration backup completes
+func (r *ParadeDBMigrationJobReconciler) reconcileMigrationBackup(ctx context.Context, migration *databasev1alpha1.ParadeDBMigrationJob,
+	paradedb *databasev1alpha1.ParadeDB) error {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: migration.Status.BackupJob, Namespace: migration.Namespace}, job)
+	if apierrors.IsNotFound(err) {
+		return r.failMigration(ctx, migration, fmt.Sprintf("Backup job %s was deleted", migration.Status.BackupJob))
+	} else if err != nil {
+		return err
+	}
+
+	result := getJobResult(job)
+	if result == nil {
+		return nil
+	}
+	if result.Type == batchv1.JobFailed {
+		return r.failMigration(ctx, migration, fmt.Sprintf("Backup job %s failed: %s", job.Name, result.Message))
+	}
+	r.Recorder.Event(migration, corev1.EventTypeNormal, "MigrationBackedUp", "Logical backup taken by job "+job.Name)
+	return r.startMigrationJob(ctx, migration, paradedb)
+}
+
+// startMigrationJob creates the Job that runs the migration
+func (r *ParadeDBMigrationJobReconciler) startMigrationJob(ctx context.Context, migration *databasev1alpha1.ParadeDBMigrationJob,
+	paradedb *databasev1alpha1.ParadeDB) error {
+	job := buildMigrationJob(migration, paradedb, *migration.Status.LockKey)
+	if err := controllerutil.SetControllerReference(migration, job, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	migration.Status.Phase = databasev1alpha1.MigrationPhaseRunning
+	migration.Status.Job = job.Name
+	migration.Status.Message = "Running the migration in job " + job.Name
+	return r.Status().Update(ctx, migration)
+}
+
+// reconcileMigrationJob records the outcome of the migration Job once it finishes
+func (r *ParadeDBMigrationJobReconciler) reconcileMigrationJob(ctx context.Context, migration *databasev1alpha1.ParadeDBMigrationJob) error {
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: migration.Status.Job, Namespace: migration.Namespace}, job)
+	if apierrors.IsNotFound(err) {
+		return r.failMigration(ctx, migration, fmt.Sprintf("Migration job %s was deleted", migration.Status.Job))
+	} else if err != nil {
+		return err
+	}
+
+	result := getJobResult(job)
+	if result == nil {
+		return nil
+	}
+	if result.Type == batchv1.JobFailed {
+		return r.failMigration(ctx, migration, fmt.Sprintf("Migration job %s failed: %s", job.Name, result.Message))
+	}
+	migration.Status.Phase = databasev1alpha1.MigrationPhaseSucceeded
+	migration.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	migration.Status.Message = "Migration completed by job " + job.Name
+	r.Recorder.Event(migration, corev1.EventTypeNormal, "MigrationSucceeded", migration.Status.Message)
+	return r.Status().Update(ctx, migration)
+}
+
+// buildMigrationJob returns the Job that runs the migration. The advisory lock is held by
+// a sidecar session that the migration container waits for, so that the lock also covers
+// migration tools that cannot take it themselves. The Job is not retried, as a failed
+// migration may have been applied in part.
+func buildMigrationJob(migration *databasev1alpha1.ParadeDBMigrationJob, paradedb *databasev1alpha1.ParadeDB, lockKey int64) *batchv1.Job {
+	env := append(buildClientEnv(paradedb),
+		corev1.EnvVar{Name: "PGDATABASE", Value: migration.GetDatabase(paradedb)},
+		corev1.EnvVar{Name: "MIGRATION_LOCK_KEY", Value: fmt.Sprintf("%d", lockKey)},
+	)
+
+	always := corev1.ContainerRestartPolicyAlways
+	lockEnv := append([]corev1.EnvVar{{Name: "PGAPPNAME", Value: migration.GetJobName()}}, env...)
+	lock := corev1.Container{
+		Name:            "advisory-lock",
+		Image:           paradedb.GetImage(),
+		ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+		RestartPolicy:   &always,
+		Command: []string{"psql", "-v", "ON_ERROR_STOP=1",
+			"-c", fmt.Sprintf("SELECT pg_advisory_lock(%d)", lockKey),
+			"-c", "SELECT pg_sleep(31536000)"},
+		Env: lockEnv,
+		// The migration container starts once this session holds the lock
+		StartupProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{Command: []string{"bash", "-c",
+					`psql -tAc "SELECT count(*) FROM pg_locks l JOIN pg_stat_activity a USING (pid) ` +
+						`WHERE l.locktype = 'advisory' AND l.granted AND a.application_name = '$PGAPPNAME'" | grep -qx 1`}},
+			},
+			PeriodSeconds:    5,
+			FailureThreshold: int32(migrationLockTimeout / (5 * time.Second)),
+		},
+	}
+
+	migrate := corev1.Container{
+		Name:            "migrate",
+		Image:           migration.Spec.Image,
+		ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+		Command:         migration.Spec.Command,
+		Args:            migration.Spec.Args,
+		Env:             append(env, migration.Spec.Env...),
+	}
+	if migration.Spec.SQL != "" {
+		migrate.Image = paradedb.GetImage()
+		migrate.Command = []string{"bash", "-c",
+			`printf '%s\n' "$MIGRATION_SQL" | psql -v ON_ERROR_STOP=1 --single-transaction -f -`}
+		migrate.Env = append(migrate.Env, corev1.EnvVar{Name: "MIGRATION_SQL", Value: migration.Spec.SQL})
+	}
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy:    corev1.RestartPolicyNever,
+		InitContainers:   []corev1.Container{lock},
+		Containers:       []corev1.Container{migrate},
+		ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+		Affinity:         withArchitectureAffinity(paradedb, nil),
+	}
+	applyServiceAccount(paradedb, &podSpec)
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "paradedb",
+		"app.kubernetes.io/instance":   paradedb.Name,
+		"app.kubernetes.io/component":  "migration",
+		"app.kubernetes.io/managed-by": "paradedb-operator",
+	}
+	backoffLimit := int32(0)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      migration.GetJobName(),
+			Namespace: migration.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels, Annotations: buildJobServiceMeshAnnotations(paradedb)},
+				Spec:       podSpec,
+			},
+		},
+	}
+}
+
+// failMigration marks the migration as failed. It is not retried.
+func (r *ParadeDBMigrationJobReconciler) failMigration(ctx context.Context, migration *databasev1alpha1.ParadeDBMigrationJob, message string) error {
+	migration.Status.Phase = databasev1alpha1.MigrationPhaseFailed
+	migration.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	migration.Status.Message = message
+	r.Recorder.Event(migration, corev1.EventTypeWarning, "MigrationFailed", message)
+	return r.Status().Update(ctx, migration)
+}
+
+// setMigrationPending records why the migration has not started yet and retries later
+func (r *ParadeDBMigrationJobReconciler) setMigrationPending(ctx context.Context, migration *databasev1alpha1.ParadeDBMigrationJob, message string) (ctrl.Result, error) {
+	migration.Status.Phase = databasev1alpha1.MigrationPhasePending
+	migration.Status.Message = message
+	if err := r.Status().Update(ctx, migration); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfterError}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. A pending migration polls its
+// instance rather than watching it.
+func (r *ParadeDBMigrationJobReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&databasev1alpha1.ParadeDBMigrationJob{}).
+		Owns(&batchv1.Job{}).
+		Named("paradedbmigrationjob").
+		Complete(r)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+var _ = Describe("ParadeDBMigrationJob Controller", func() {
+	paradedb := &databasev1alpha1.ParadeDB{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       databasev1alpha1.ParadeDBSpec{Auth: databasev1alpha1.AuthSpec{Database: "paradedb"}},
+		Status:     databasev1alpha1.ParadeDBStatus{Phase: databasev1alpha1.ParadeDBPhaseRunning},
+	}
+
+	Context("When building the migration Job", func() {
+		It("should run the SQL once the sidecar holds the advisory lock", func() {
+			migration := &databasev1alpha1.ParadeDBMigrationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "add-table", Namespace: "default"},
+				Spec: databasev1alpha1.ParadeDBMigrationJobSpec{
+					ParadeDBRef: corev1.LocalObjectReference{Name: "app"},
+					SQL:         "CREATE TABLE products (id bigint)",
+				},
+			}
+			lockKey := getMigrationLockKey(migration, paradedb)
+			migration.Spec.Database = "search"
+			Expect(getMigrationLockKey(migration, paradedb)).NotTo(Equal(lockKey))
+
+			job := buildMigrationJob(migration, paradedb, 42)
+			Expect(*job.Spec.BackoffLimit).To(BeZero())
+			lock := job.Spec.Template.Spec.InitContainers[0]
+			Expect(*lock.RestartPolicy).To(Equal(corev1.ContainerRestartPolicyAlways))
+			Expect(lock.Command).To(ContainElement("SELECT pg_advisory_lock(42)"))
+			Expect(lock.StartupProbe).NotTo(BeNil())
+			migrate := job.Spec.Template.Spec.Containers[0]
+			Expect(migrate.Image).To(Equal(paradedb.GetImage()))
+			Expect(migrate.Env).To(ContainElements(
+				corev1.EnvVar{Name: "PGDATABASE", Value: "search"},
+				corev1.EnvVar{Name: "MIGRATION_LOCK_KEY", Value: "42"},
+				corev1.EnvVar{Name: "MIGRATION_SQL", Value: "CREATE TABLE products (id bigint)"},
+			))
+		})
+	})
+
+	Context("When scheduling migrations", func() {
+		It("should start migrations of an instance in the order they were created", func() {
+			migrationScheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(migrationScheme)).To(Succeed())
+			Expect(databasev1alpha1.AddToScheme(migrationScheme)).To(Succeed())
+
+			first := &databasev1alpha1.ParadeDBMigrationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "default", UID: "first-uid",
+					CreationTimestamp: metav1.NewTime(metav1.Now().Add(-time.Minute))},
+				Spec: databasev1alpha1.ParadeDBMigrationJobSpec{
+					ParadeDBRef: corev1.LocalObjectReference{Name: "app"},
+					Image:       "migrate/migrate:v4.17.0",
+					Args:        []string{"up"},
+				},
+			}
+			second := &databasev1alpha1.ParadeDBMigrationJob{
+				ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "default", UID: "second-uid",
+					CreationTimestamp: metav1.Now()},
+				Spec: databasev1alpha1.ParadeDBMigrationJobSpec{
+					ParadeDBRef: corev1.LocalObjectReference{Name: "app"},
+					SQL:         "SELECT 1",
+				},
+			}
+			reconciler := &ParadeDBMigrationJobReconciler{
+				Client: fake.NewClientBuilder().WithScheme(migrationScheme).
+					WithObjects(paradedb.DeepCopy(), first, second).WithStatusSubresource(first, second).Build(),
+				Scheme:   migrationScheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			secondRequest := ctrl.Request{NamespacedName: types.NamespacedName{Name: "second", Namespace: "default"}}
+			_, err := reconciler.Reconcile(ctx, secondRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, secondRequest.NamespacedName, second)).To(Succeed())
+			Expect(second.Status.Phase).To(Equal(databasev1alpha1.MigrationPhasePending))
+			Expect(second.Status.Message).To(ContainSubstring("first"))
+
+			firstRequest := ctrl.Request{NamespacedName: types.NamespacedName{Name: "first", Namespace: "default"}}
+			_, err = reconciler.Reconcile(ctx, firstRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, firstRequest.NamespacedName, first)).To(Succeed())
+			Expect(first.Status.Phase).To(Equal(databasev1alpha1.MigrationPhaseRunning))
+			Expect(first.Status.LockKey).NotTo(BeNil())
+			job := &batchv1.Job{}
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: first.Status.Job, Namespace: "default"}, job)).To(Succeed())
+			Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal("migrate/migrate:v4.17.0"))
+
+			job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+			Expect(reconciler.Status().Update(ctx, job)).To(Succeed())
+			_, err = reconciler.Reconcile(ctx, firstRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, firstRequest.NamespacedName, first)).To(Succeed())
+			Expect(first.Status.Phase).To(Equal(databasev1alpha1.MigrationPhaseSucceeded))
+
+			_, err = reconciler.Reconcile(ctx, secondRequest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, secondRequest.NamespacedName, second)).To(Succeed())
+			Expect(second.Status.Phase).To(Equal(databasev1alpha1.MigrationPhaseRunning))
+		})
+	})
+})
@@ -0,0 +1,202 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// ParadeDBPublicationReconciler reconciles a ParadeDBPublication object
+type ParadeDBPublicationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbpublications,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbpublications/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbpublications/finalizers,verbs=update
+
+// Reconcile creates, updates and drops the publication in the referenced ParadeDB instance
+func (r *ParadeDBPublicationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	publication := &databasev1alpha1.ParadeDBPublication{}
+	if err := r.Get(ctx, req.NamespacedName, publication); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	paradedb := &databasev1alpha1.ParadeDB{}
+	err := r.Get(ctx, types.NamespacedName{Name: publication.Spec.ParadeDBRef.Name, Namespace: publication.Namespace}, paradedb)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	paradedbGone := apierrors.IsNotFound(err) || paradedb.GetDeletionTimestamp() != nil
+
+	// Handle deletion
+	if publication.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(publication, paradedbFinalizer) {
+			// Nothing to drop once the instance itself is going away
+			if !paradedbGone {
+				if err := r.withPublicationDatabase(ctx, publication, paradedb, func(ctx context.Context, db *sql.DB) error {
+					_, err := db.ExecContext(ctx, "DROP PUBLICATION IF EXISTS "+pq.QuoteIdentifier(publication.GetPublicationName()))
+					return err
+				}); err != nil {
+					log.Error(err, "Failed to drop publication")
+					return ctrl.Result{RequeueAfter: requeueAfterError}, nil
+				}
+			}
+
+			controllerutil.RemoveFinalizer(publication, paradedbFinalizer)
+			if err := r.Update(ctx, publication); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present
+	if !controllerutil.ContainsFinalizer(publication, paradedbFinalizer) {
+		controllerutil.AddFinalizer(publication, paradedbFinalizer)
+		if err := r.Update(ctx, publication); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if paradedbGone {
+		return r.setStatus(ctx, publication, fmt.Errorf("ParadeDB %s not found", publication.Spec.ParadeDBRef.Name))
+	}
+	if publication.Spec.AllTables == (len(publication.Spec.Tables) > 0) {
+		return r.setStatus(ctx, publication, errors.New("exactly one of allTables and tables must be set"))
+	}
+	if paradedb.Status.ReadyReplicas == 0 {
+		return r.setStatus(ctx, publication, fmt.Errorf("waiting for ParadeDB %s to become ready", paradedb.Name))
+	}
+
+	err = r.withPublicationDatabase(ctx, publication, paradedb, func(ctx context.Context, db *sql.DB) error {
+		return applyPublication(ctx, db, publication)
+	})
+	return r.setStatus(ctx, publication, err)
+}
+
+// withPublicationDatabase connects to the database the publication lives in
+func (r *ParadeDBPublicationReconciler) withPublicationDatabase(ctx context.Context, publication *databasev1alpha1.ParadeDBPublication,
+	paradedb *databasev1alpha1.ParadeDB, fn func(ctx context.Context, db *sql.DB) error) error {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	database := publication.Spec.Database
+	if database == "" {
+		database = paradedb.Spec.Auth.Database
+	}
+	return withDatabase(ctx, buildDatabaseURL(paradedb, username, password, database), fn)
+}
+
+// setStatus records the outcome of applying the publication and requeues, sooner on failure
+func (r *ParadeDBPublicationReconciler) setStatus(ctx context.Context, publication *databasev1alpha1.ParadeDBPublication, err error) (ctrl.Result, error) {
+	publication.Status.Applied = err == nil
+	publication.Status.Message = "Publication is up to date"
+	if err != nil {
+		publication.Status.Message = err.Error()
+	} else {
+		publication.Status.ObservedGeneration = publication.Generation
+	}
+
+	if updateErr := r.Status().Update(ctx, publication); updateErr != nil {
+		return ctrl.Result{}, updateErr
+	}
+	if err != nil {
+		return ctrl.Result{RequeueAfter: requeueAfterError}, nil
+	}
+	return ctrl.Result{RequeueAfter: requeueAfterSuccess}, nil
+}
+
+// applyPublication creates the publication or brings its table list in line with the spec
+func applyPublication(ctx context.Context, db *sql.DB, publication *databasev1alpha1.ParadeDBPublication) error {
+	name := pq.QuoteIdentifier(publication.GetPublicationName())
+
+	var allTables bool
+	err := db.QueryRowContext(ctx, "SELECT puballtables FROM pg_publication WHERE pubname = $1",
+		publication.GetPublicationName()).Scan(&allTables)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		_, err = db.ExecContext(ctx, fmt.Sprintf("CREATE PUBLICATION %s FOR %s", name, buildPublicationTarget(publication)))
+		return err
+	case err != nil:
+		return err
+	case allTables && publication.Spec.AllTables:
+		return nil
+	case !allTables && !publication.Spec.AllTables:
+		_, err = db.ExecContext(ctx, fmt.Sprintf("ALTER PUBLICATION %s SET %s", name, buildPublicationTarget(publication)))
+		return err
+	}
+
+	// FOR ALL TABLES can't be altered in place, so switching to or from it recreates the
+	// publication. Subscribers keep their slot and pick up the new table list on refresh.
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, err := tx.ExecContext(ctx, "DROP PUBLICATION "+name); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE PUBLICATION %s FOR %s", name, buildPublicationTarget(publication))); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// buildPublicationTarget returns what the publication covers, as used by CREATE PUBLICATION ... FOR
+// and ALTER PUBLICATION ... SET
+func buildPublicationTarget(publication *databasev1alpha1.ParadeDBPublication) string {
+	if publication.Spec.AllTables {
+		return "ALL TABLES"
+	}
+
+	tables := make([]string, len(publication.Spec.Tables))
+	for i, table := range publication.Spec.Tables {
+		tables[i] = quoteQualifiedName(table)
+	}
+	return "TABLE " + strings.Join(tables, ", ")
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ParadeDBPublicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&databasev1alpha1.ParadeDBPublication{}).
+		Named("paradedbpublication").
+		Complete(r)
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+var _ = Describe("ParadeDBPublication Controller", func() {
+	Context("When building the publication target", func() {
+		It("should quote schema-qualified tables", func() {
+			publication := &databasev1alpha1.ParadeDBPublication{
+				Spec: databasev1alpha1.ParadeDBPublicationSpec{
+					Tables: []string{"public.orders", "Customers"},
+				},
+			}
+			Expect(buildPublicationTarget(publication)).To(Equal(`TABLE "public"."orders", "Customers"`))
+		})
+
+		It("should publish all tables when requested", func() {
+			publication := &databasev1alpha1.ParadeDBPublication{
+				Spec: databasev1alpha1.ParadeDBPublicationSpec{AllTables: true},
+			}
+			Expect(buildPublicationTarget(publication)).To(Equal("ALL TABLES"))
+		})
+	})
+})
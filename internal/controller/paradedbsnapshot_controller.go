@@ -0,0 +1,323 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// volumeSnapshotAPIVersion is the CSI snapshot API, which is only present on clusters
+	// running the external snapshot controller
+	volumeSnapshotAPIVersion = "snapshot.storage.k8s.io/v1"
+
+	// snapshotCutTimeout bounds how long the hooks' session is held open waiting for the
+	// volume snapshot to be cut
+	snapshotCutTimeout = 2 * time.Minute
+)
+
+// ParadeDBSnapshotReconciler reconciles a ParadeDBSnapshot object
+type ParadeDBSnapshotReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbsnapshots,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbsnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbsnapshots/finalizers,verbs=update
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
+
+// Reconcile takes the volume snapshot once, running the hooks around it, and then tracks
+// it until it is ready to restore from
+func (r *ParadeDBSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	snapshot := &databasev1alpha1.ParadeDBSnapshot{}
+	if err := r.Get(ctx, req.NamespacedName, snapshot); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	switch snapshot.Status.Phase {
+	case databasev1alpha1.SnapshotPhaseReady, databasev1alpha1.SnapshotPhaseFailed:
+		return ctrl.Result{}, nil
+	case databasev1alpha1.SnapshotPhaseRunning:
+		return r.updateSnapshotStatus(ctx, snapshot)
+	}
+
+	paradedb := &databasev1alpha1.ParadeDB{}
+	err := r.Get(ctx, types.NamespacedName{Name: snapshot.Spec.ParadeDBRef.Name, Namespace: snapshot.Namespace}, paradedb)
+	if apierrors.IsNotFound(err) {
+		return r.setPending(ctx, snapshot, fmt.Errorf("ParadeDB %s not found", snapshot.Spec.ParadeDBRef.Name))
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+	if paradedb.Status.ReadyReplicas == 0 {
+		return r.setPending(ctx, snapshot, fmt.Errorf("waiting for ParadeDB %s to become ready", paradedb.Name))
+	}
+
+	if err := r.reconcileSnapshotCredentials(ctx, snapshot, paradedb); err != nil {
+		return r.setPending(ctx, snapshot, fmt.Errorf("failed to copy credentials: %w", err))
+	}
+
+	// A VolumeSnapshot left by an attempt that failed after creating it is tracked rather
+	// than taken again, so the hooks do not run twice around one snapshot
+	_, err = r.getVolumeSnapshot(ctx, snapshot)
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		if err := r.takeSnapshot(ctx, snapshot, paradedb); err != nil {
+			return r.setPending(ctx, snapshot, err)
+		}
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	snapshot.Status.Phase = databasev1alpha1.SnapshotPhaseRunning
+	snapshot.Status.VolumeSnapshotName = snapshot.Name
+	snapshot.Status.Image = paradedb.Status.CurrentVersion
+	if snapshot.Status.Image == "" {
+		snapshot.Status.Image = paradedb.GetImage()
+	}
+	return r.updateSnapshotStatus(ctx, snapshot)
+}
+
+// reconcileSnapshotCredentials copies the superuser credentials of the instance into a
+// Secret owned by the snapshot, as the restored data directory keeps its roles
+func (r *ParadeDBSnapshotReconciler) reconcileSnapshotCredentials(ctx context.Context, snapshot *databasev1alpha1.ParadeDBSnapshot, paradedb *databasev1alpha1.ParadeDB) error {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snapshot.GetCredentialsSecretName(),
+			Namespace: snapshot.Namespace,
+		},
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{"username": username, "password": password},
+	}
+	if err := controllerutil.SetControllerReference(snapshot, secret, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, secret); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// takeSnapshot checkpoints, runs the pre hooks, creates the VolumeSnapshot and waits for it
+// to be cut before running the post hooks in the same session
+func (r *ParadeDBSnapshotReconciler) takeSnapshot(ctx context.Context, snapshot *databasev1alpha1.ParadeDBSnapshot, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+	hooks := snapshot.Spec.Hooks
+	if hooks == nil {
+		hooks = &databasev1alpha1.SnapshotHooksSpec{}
+	}
+	database := hooks.Database
+	if database == "" {
+		database = paradedb.Spec.Auth.Database
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, snapshotCutTimeout)
+	defer cancel()
+
+	db, err := sql.Open("postgres", buildDatabaseURL(paradedb, username, password, database))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	// A checkpoint right before the snapshot keeps crash recovery on restore short
+	if _, err := conn.ExecContext(ctx, "CHECKPOINT"); err != nil {
+		return fmt.Errorf("failed to checkpoint: %w", err)
+	}
+	for _, statement := range hooks.Pre {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("pre hook failed: %w", err)
+		}
+	}
+
+	snapshotErr := r.createVolumeSnapshot(ctx, snapshot, paradedb)
+	if snapshotErr == nil {
+		snapshotErr = r.waitForVolumeSnapshotCut(ctx, snapshot)
+	}
+
+	// The post hooks undo the pre hooks, so they also run when the snapshot failed
+	for _, statement := range hooks.Post {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			log.Error(err, "Post hook failed", "statement", statement)
+			if snapshotErr == nil {
+				snapshotErr = fmt.Errorf("post hook failed: %w", err)
+			}
+		}
+	}
+	return snapshotErr
+}
+
+// createVolumeSnapshot creates the VolumeSnapshot of the first pod's data volume
+func (r *ParadeDBSnapshotReconciler) createVolumeSnapshot(ctx context.Context, snapshot *databasev1alpha1.ParadeDBSnapshot, paradedb *databasev1alpha1.ParadeDB) error {
+	volumeSnapshot := buildVolumeSnapshot(snapshot, paradedb)
+	if err := controllerutil.SetControllerReference(snapshot, volumeSnapshot, r.Scheme); err != nil {
+		return err
+	}
+
+	err := r.Create(ctx, volumeSnapshot)
+	if meta.IsNoMatchError(err) {
+		return errors.New("the VolumeSnapshot API is not installed; deploy the CSI external snapshotter")
+	} else if err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create VolumeSnapshot: %w", err)
+	}
+	return nil
+}
+
+// buildVolumeSnapshot creates the VolumeSnapshot spec, named after the ParadeDBSnapshot
+func buildVolumeSnapshot(snapshot *databasev1alpha1.ParadeDBSnapshot, paradedb *databasev1alpha1.ParadeDB) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": "data-" + paradedb.GetStatefulSetName() + "-0",
+		},
+	}
+	if snapshot.Spec.VolumeSnapshotClassName != nil {
+		spec["volumeSnapshotClassName"] = *snapshot.Spec.VolumeSnapshotClassName
+	}
+
+	volumeSnapshot := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	volumeSnapshot.SetAPIVersion(volumeSnapshotAPIVersion)
+	volumeSnapshot.SetKind("VolumeSnapshot")
+	volumeSnapshot.SetName(snapshot.Name)
+	volumeSnapshot.SetNamespace(snapshot.Namespace)
+	volumeSnapshot.SetLabels(map[string]string{
+		"app.kubernetes.io/name":       "paradedb",
+		"app.kubernetes.io/instance":   paradedb.Name,
+		"app.kubernetes.io/managed-by": "paradedb-operator",
+	})
+	return volumeSnapshot
+}
+
+// getVolumeSnapshot reads the VolumeSnapshot of the ParadeDBSnapshot
+func (r *ParadeDBSnapshotReconciler) getVolumeSnapshot(ctx context.Context, snapshot *databasev1alpha1.ParadeDBSnapshot) (*unstructured.Unstructured, error) {
+	volumeSnapshot := &unstructured.Unstructured{}
+	volumeSnapshot.SetAPIVersion(volumeSnapshotAPIVersion)
+	volumeSnapshot.SetKind("VolumeSnapshot")
+	err := r.Get(ctx, types.NamespacedName{Name: snapshot.Name, Namespace: snapshot.Namespace}, volumeSnapshot)
+	return volumeSnapshot, err
+}
+
+// waitForVolumeSnapshotCut waits until the storage system has taken the snapshot. Uploading
+// it, which readyToUse waits for, does not need the application to stay quiesced.
+func (r *ParadeDBSnapshotReconciler) waitForVolumeSnapshotCut(ctx context.Context, snapshot *databasev1alpha1.ParadeDBSnapshot) error {
+	return wait.PollUntilContextCancel(ctx, 2*time.Second, true, func(ctx context.Context) (bool, error) {
+		volumeSnapshot, err := r.getVolumeSnapshot(ctx, snapshot)
+		if err != nil {
+			return false, err
+		}
+		if message, found, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "error", "message"); found {
+			return false, fmt.Errorf("volume snapshot failed: %s", message)
+		}
+		_, cut, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "creationTime")
+		return cut, nil
+	})
+}
+
+// updateSnapshotStatus copies the progress of the VolumeSnapshot into the status
+func (r *ParadeDBSnapshotReconciler) updateSnapshotStatus(ctx context.Context, snapshot *databasev1alpha1.ParadeDBSnapshot) (ctrl.Result, error) {
+	volumeSnapshot, err := r.getVolumeSnapshot(ctx, snapshot)
+	if apierrors.IsNotFound(err) {
+		snapshot.Status.Phase = databasev1alpha1.SnapshotPhaseFailed
+		snapshot.Status.Message = fmt.Sprintf("VolumeSnapshot %s was deleted", snapshot.Name)
+		return ctrl.Result{}, r.Status().Update(ctx, snapshot)
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	readyToUse, _, _ := unstructured.NestedBool(volumeSnapshot.Object, "status", "readyToUse")
+	if value, found, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "creationTime"); found {
+		if creationTime, err := time.Parse(time.RFC3339, value); err == nil {
+			snapshot.Status.CreationTime = &metav1.Time{Time: creationTime}
+		}
+	}
+	if value, found, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "restoreSize"); found {
+		if restoreSize, err := resource.ParseQuantity(value); err == nil {
+			snapshot.Status.RestoreSize = &restoreSize
+		}
+	}
+
+	result := ctrl.Result{}
+	message, failed, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "error", "message")
+	switch {
+	case failed:
+		snapshot.Status.Phase = databasev1alpha1.SnapshotPhaseFailed
+		snapshot.Status.Message = message
+	case readyToUse:
+		snapshot.Status.Phase = databasev1alpha1.SnapshotPhaseReady
+		snapshot.Status.Message = "Snapshot is ready to restore from"
+	default:
+		snapshot.Status.Message = "Waiting for the volume snapshot to become ready"
+		result.RequeueAfter = requeueAfterError
+	}
+
+	if err := r.Status().Update(ctx, snapshot); err != nil {
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// setPending records why the snapshot has not been taken yet and retries later
+func (r *ParadeDBSnapshotReconciler) setPending(ctx context.Context, snapshot *databasev1alpha1.ParadeDBSnapshot, err error) (ctrl.Result, error) {
+	snapshot.Status.Phase = databasev1alpha1.SnapshotPhasePending
+	snapshot.Status.Message = err.Error()
+	if updateErr := r.Status().Update(ctx, snapshot); updateErr != nil {
+		return ctrl.Result{}, updateErr
+	}
+	return ctrl.Result{RequeueAfter: requeueAfterError}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. VolumeSnapshots are polled
+// rather than watched, as their API is not installed on every cluster.
+func (r *ParadeDBSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&databasev1alpha1.ParadeDBSnapshot{}).
+		Named("paradedbsnapshot").
+		Complete(r)
+}
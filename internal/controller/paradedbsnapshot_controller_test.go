@@ -0,0 +1,71 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+var _ = Describe("ParadeDBSnapshot Controller", func() {
+	paradedb := &databasev1alpha1.ParadeDB{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "default"},
+	}
+
+	Context("When building the VolumeSnapshot", func() {
+		It("should snapshot the first pod's data volume with the given class", func() {
+			className := "csi-snapclass"
+			snapshot := &databasev1alpha1.ParadeDBSnapshot{
+				ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+				Spec: databasev1alpha1.ParadeDBSnapshotSpec{
+					ParadeDBRef:             corev1.LocalObjectReference{Name: "source"},
+					VolumeSnapshotClassName: &className,
+				},
+			}
+
+			volumeSnapshot := buildVolumeSnapshot(snapshot, paradedb)
+			Expect(volumeSnapshot.GetName()).To(Equal("nightly"))
+			Expect(volumeSnapshot.GetKind()).To(Equal("VolumeSnapshot"))
+			pvc, _, _ := unstructured.NestedString(volumeSnapshot.Object, "spec", "source", "persistentVolumeClaimName")
+			Expect(pvc).To(Equal("data-source-0"))
+			class, _, _ := unstructured.NestedString(volumeSnapshot.Object, "spec", "volumeSnapshotClassName")
+			Expect(class).To(Equal("csi-snapclass"))
+		})
+	})
+
+	Context("When restoring from a snapshot", func() {
+		It("should create the data volume from the VolumeSnapshot", func() {
+			restored := &databasev1alpha1.ParadeDB{
+				ObjectMeta: metav1.ObjectMeta{Name: "restored", Namespace: "default"},
+				Spec: databasev1alpha1.ParadeDBSpec{
+					Bootstrap: &databasev1alpha1.BootstrapSpec{Snapshot: &corev1.LocalObjectReference{Name: "nightly"}},
+				},
+			}
+
+			dataSource := (&ParadeDBReconciler{}).buildStatefulSet(restored).Spec.VolumeClaimTemplates[0].Spec.DataSource
+			Expect(dataSource).NotTo(BeNil())
+			Expect(*dataSource.APIGroup).To(Equal("snapshot.storage.k8s.io"))
+			Expect(dataSource.Kind).To(Equal("VolumeSnapshot"))
+			Expect(dataSource.Name).To(Equal("nightly"))
+		})
+	})
+})
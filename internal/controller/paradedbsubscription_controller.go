@@ -0,0 +1,236 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/lib/pq"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// ParadeDBSubscriptionReconciler reconciles a ParadeDBSubscription object
+type ParadeDBSubscriptionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbsubscriptions,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbsubscriptions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbsubscriptions/finalizers,verbs=update
+
+// Reconcile creates, updates and drops the subscription in the referenced ParadeDB instance
+func (r *ParadeDBSubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	subscription := &databasev1alpha1.ParadeDBSubscription{}
+	if err := r.Get(ctx, req.NamespacedName, subscription); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	paradedb := &databasev1alpha1.ParadeDB{}
+	err := r.Get(ctx, types.NamespacedName{Name: subscription.Spec.ParadeDBRef.Name, Namespace: subscription.Namespace}, paradedb)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	paradedbGone := apierrors.IsNotFound(err) || paradedb.GetDeletionTimestamp() != nil
+
+	// Handle deletion. DROP SUBSCRIPTION also drops the replication slot on the source.
+	if subscription.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(subscription, paradedbFinalizer) {
+			if !paradedbGone {
+				if err := r.withSubscriptionDatabase(ctx, subscription, paradedb, func(ctx context.Context, db *sql.DB) error {
+					_, err := db.ExecContext(ctx, "DROP SUBSCRIPTION IF EXISTS "+pq.QuoteIdentifier(subscription.GetSubscriptionName()))
+					return err
+				}); err != nil {
+					log.Error(err, "Failed to drop subscription")
+					return ctrl.Result{RequeueAfter: requeueAfterError}, nil
+				}
+			}
+
+			controllerutil.RemoveFinalizer(subscription, paradedbFinalizer)
+			if err := r.Update(ctx, subscription); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present
+	if !controllerutil.ContainsFinalizer(subscription, paradedbFinalizer) {
+		controllerutil.AddFinalizer(subscription, paradedbFinalizer)
+		if err := r.Update(ctx, subscription); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	if paradedbGone {
+		return r.setStatus(ctx, subscription, fmt.Errorf("ParadeDB %s not found", subscription.Spec.ParadeDBRef.Name))
+	}
+	if paradedb.Status.ReadyReplicas == 0 {
+		return r.setStatus(ctx, subscription, fmt.Errorf("waiting for ParadeDB %s to become ready", paradedb.Name))
+	}
+
+	connInfo, err := r.getSourceConnInfo(ctx, subscription)
+	if err != nil {
+		return r.setStatus(ctx, subscription, err)
+	}
+
+	err = r.withSubscriptionDatabase(ctx, subscription, paradedb, func(ctx context.Context, db *sql.DB) error {
+		return applySubscription(ctx, db, subscription, connInfo)
+	})
+	return r.setStatus(ctx, subscription, err)
+}
+
+// getSourceConnInfo returns the connection string of the publishing server
+func (r *ParadeDBSubscriptionReconciler) getSourceConnInfo(ctx context.Context, subscription *databasev1alpha1.ParadeDBSubscription) (string, error) {
+	source := subscription.Spec.Source
+	if (source.ParadeDBRef == nil) == (source.ConnectionSecretRef == nil) {
+		return "", errors.New("exactly one of source.paradedbRef and source.connectionSecretRef must be set")
+	}
+
+	if source.ConnectionSecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: source.ConnectionSecretRef.Name, Namespace: subscription.Namespace}, secret); err != nil {
+			return "", fmt.Errorf("failed to read connection secret: %w", err)
+		}
+		connInfo, ok := secret.Data[source.ConnectionSecretRef.Key]
+		if !ok {
+			return "", fmt.Errorf("connection secret %s has no key %s", secret.Name, source.ConnectionSecretRef.Key)
+		}
+		return string(connInfo), nil
+	}
+
+	sourceDB := &databasev1alpha1.ParadeDB{}
+	if err := r.Get(ctx, types.NamespacedName{Name: source.ParadeDBRef.Name, Namespace: subscription.Namespace}, sourceDB); err != nil {
+		return "", fmt.Errorf("failed to get source ParadeDB %s: %w", source.ParadeDBRef.Name, err)
+	}
+	username, password, err := getSuperuserCredentials(ctx, r.Client, sourceDB)
+	if err != nil {
+		return "", fmt.Errorf("failed to read source credentials: %w", err)
+	}
+	database := source.Database
+	if database == "" {
+		database = sourceDB.Spec.Auth.Database
+	}
+	return buildConnInfo(sourceDB, username, password, database), nil
+}
+
+// withSubscriptionDatabase connects to the database the subscription lives in
+func (r *ParadeDBSubscriptionReconciler) withSubscriptionDatabase(ctx context.Context, subscription *databasev1alpha1.ParadeDBSubscription,
+	paradedb *databasev1alpha1.ParadeDB, fn func(ctx context.Context, db *sql.DB) error) error {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	database := subscription.Spec.Database
+	if database == "" {
+		database = paradedb.Spec.Auth.Database
+	}
+	return withDatabase(ctx, buildDatabaseURL(paradedb, username, password, database), fn)
+}
+
+// setStatus records the outcome of applying the subscription and requeues, sooner on failure
+func (r *ParadeDBSubscriptionReconciler) setStatus(ctx context.Context, subscription *databasev1alpha1.ParadeDBSubscription, err error) (ctrl.Result, error) {
+	subscription.Status.Applied = err == nil
+	subscription.Status.Message = "Subscription is up to date"
+	if err != nil {
+		subscription.Status.Message = err.Error()
+	} else {
+		subscription.Status.ObservedGeneration = subscription.Generation
+	}
+
+	if updateErr := r.Status().Update(ctx, subscription); updateErr != nil {
+		return ctrl.Result{}, updateErr
+	}
+	if err != nil {
+		return ctrl.Result{RequeueAfter: requeueAfterError}, nil
+	}
+	return ctrl.Result{RequeueAfter: requeueAfterSuccess}, nil
+}
+
+// applySubscription creates the subscription or brings its connection, publications and
+// enabled state in line with the spec. CREATE SUBSCRIPTION cannot run in a transaction,
+// so each statement is executed on its own.
+func applySubscription(ctx context.Context, db *sql.DB, subscription *databasev1alpha1.ParadeDBSubscription, connInfo string) error {
+	name := pq.QuoteIdentifier(subscription.GetSubscriptionName())
+	publications := quoteIdentifiers(subscription.Spec.Publications)
+
+	var (
+		enabled         bool
+		currentConnInfo string
+		current         []string
+	)
+	err := db.QueryRowContext(ctx, `SELECT subenabled, subconninfo, subpublications FROM pg_subscription
+WHERE subname = $1 AND subdbid = (SELECT oid FROM pg_database WHERE datname = current_database())`,
+		subscription.GetSubscriptionName()).Scan(&enabled, &currentConnInfo, pq.Array(&current))
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = db.ExecContext(ctx, fmt.Sprintf("CREATE SUBSCRIPTION %s CONNECTION %s PUBLICATION %s WITH (enabled = %t)",
+			name, pq.QuoteLiteral(connInfo), publications, subscription.IsEnabled()))
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	var statements []string
+	if currentConnInfo != connInfo {
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s CONNECTION %s", name, pq.QuoteLiteral(connInfo)))
+	}
+	if !slices.Equal(current, subscription.Spec.Publications) {
+		// Refreshing copies the tables of newly added publications, which needs an
+		// enabled subscription
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s SET PUBLICATION %s WITH (refresh = %t)",
+			name, publications, enabled))
+	}
+	if enabled != subscription.IsEnabled() {
+		if subscription.IsEnabled() {
+			statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s ENABLE", name))
+		} else {
+			statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s DISABLE", name))
+		}
+	}
+
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ParadeDBSubscriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&databasev1alpha1.ParadeDBSubscription{}).
+		Named("paradedbsubscription").
+		Complete(r)
+}
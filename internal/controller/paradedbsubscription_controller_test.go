@@ -0,0 +1,38 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+var _ = Describe("ParadeDBSubscription Controller", func() {
+	Context("When connecting to a source ParadeDB", func() {
+		It("should quote credentials in the connection string", func() {
+			source := &databasev1alpha1.ParadeDB{
+				ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "apps"},
+			}
+
+			connInfo := buildConnInfo(source, "postgres", `it's\secret`, "search")
+			Expect(connInfo).To(Equal(`host=source.apps.svc port=5432 user='postgres' password='it\'s\\secret' dbname='search' sslmode=disable`))
+		})
+	})
+})
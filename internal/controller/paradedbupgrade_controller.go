@@ -0,0 +1,413 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// upgradePollInterval is how often an upgrade checks on a step that is waiting for the
+// instance
+const upgradePollInterval = 10 * time.Second
+
+// upgradeSteps are the steps of every upgrade, in the order they run
+var upgradeSteps = []databasev1alpha1.UpgradeStepName{
+	databasev1alpha1.UpgradeStepBackup,
+	databasev1alpha1.UpgradeStepReplicas,
+	databasev1alpha1.UpgradeStepPrimary,
+	databasev1alpha1.UpgradeStepExtensions,
+	databasev1alpha1.UpgradeStepVerification,
+}
+
+// ParadeDBUpgradeReconciler reconciles a ParadeDBUpgrade object
+type ParadeDBUpgradeReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbupgrades,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbupgrades/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=database.paradedb.io,resources=paradedbupgrades/finalizers,verbs=update
+
+// Reconcile starts the upgrade once its instance is free and its maintenance window is
+// open, then carries out the steps in order. Each step's outcome is saved in the status
+// before the next one starts, so an interrupted upgrade resumes where it stopped.
+func (r *ParadeDBUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	upgrade := &databasev1alpha1.ParadeDBUpgrade{}
+	if err := r.Get(ctx, req.NamespacedName, upgrade); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if upgrade.Status.Phase == databasev1alpha1.UpgradePhaseSucceeded || upgrade.Status.Phase == databasev1alpha1.UpgradePhaseFailed {
+		return ctrl.Result{}, nil
+	}
+
+	paradedb := &databasev1alpha1.ParadeDB{}
+	err := r.Get(ctx, types.NamespacedName{Name: upgrade.Spec.ParadeDBRef.Name, Namespace: upgrade.Namespace}, paradedb)
+	if apierrors.IsNotFound(err) && upgrade.Status.Phase == databasev1alpha1.UpgradePhaseRunning {
+		return ctrl.Result{}, r.failUpgrade(ctx, upgrade, fmt.Sprintf("ParadeDB %s was deleted", upgrade.Spec.ParadeDBRef.Name))
+	} else if apierrors.IsNotFound(err) {
+		return r.setUpgradePending(ctx, upgrade, fmt.Sprintf("ParadeDB %s not found", upgrade.Spec.ParadeDBRef.Name))
+	} else if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if upgrade.Status.Phase != databasev1alpha1.UpgradePhaseRunning {
+		running, err := r.getRunningUpgrade(ctx, upgrade)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if running != "" {
+			return r.setUpgradePending(ctx, upgrade, fmt.Sprintf("Waiting for upgrade %s of ParadeDB %s to finish", running, paradedb.Name))
+		}
+		if !inMaintenanceWindow(upgrade.Spec.MaintenanceWindow, time.Now()) {
+			return r.setUpgradePending(ctx, upgrade, "Waiting for the maintenance window")
+		}
+		startUpgrade(upgrade, paradedb)
+		log.Info("Starting upgrade", "paradedb", paradedb.Name, "from", upgrade.Status.FromImage, "to", upgrade.Spec.Image)
+		r.Recorder.Event(upgrade, corev1.EventTypeNormal, "UpgradeStarted",
+			fmt.Sprintf("Upgrading ParadeDB %s from %s to %s", paradedb.Name, upgrade.Status.FromImage, upgrade.Spec.Image))
+	}
+
+	for _, name := range upgradeSteps {
+		step := upgrade.GetStep(name)
+		if step.Phase == databasev1alpha1.UpgradeStepSucceeded || step.Phase == databasev1alpha1.UpgradeStepSkipped {
+			continue
+		}
+
+		upgrade.Status.CurrentStep = name
+		if step.Phase == databasev1alpha1.UpgradeStepPending {
+			step.Phase = databasev1alpha1.UpgradeStepRunning
+			step.StartTime = &metav1.Time{Time: time.Now()}
+		}
+
+		phase, message, err := r.runUpgradeStep(ctx, upgrade, paradedb, name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		step.Phase = phase
+		step.Message = message
+		upgrade.Status.Message = message
+		switch phase {
+		case databasev1alpha1.UpgradeStepRunning:
+			if err := r.Status().Update(ctx, upgrade); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: upgradePollInterval}, nil
+		case databasev1alpha1.UpgradeStepFailed:
+			step.CompletionTime = &metav1.Time{Time: time.Now()}
+			return ctrl.Result{}, r.failUpgrade(ctx, upgrade, fmt.Sprintf("%s step failed: %s", name, message))
+		}
+
+		step.CompletionTime = &metav1.Time{Time: time.Now()}
+		r.Recorder.Event(upgrade, corev1.EventTypeNormal, "UpgradeStep"+string(phase), fmt.Sprintf("%s: %s", name, message))
+		// Save each completed step, so that it is not repeated if the next one is interrupted
+		if err := r.Status().Update(ctx, upgrade); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	upgrade.Status.Phase = databasev1alpha1.UpgradePhaseSucceeded
+	upgrade.Status.CurrentStep = ""
+	upgrade.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	upgrade.Status.Message = fmt.Sprintf("Upgraded from %s to %s", upgrade.Status.FromImage, upgrade.Spec.Image)
+	r.Recorder.Event(upgrade, corev1.EventTypeNormal, "UpgradeSucceeded", upgrade.Status.Message)
+	return ctrl.Result{}, r.Status().Update(ctx, upgrade)
+}
+
+// startUpgrade records the image the instance runs and lists every step as pending
+func startUpgrade(upgrade *databasev1alpha1.ParadeDBUpgrade, paradedb *databasev1alpha1.ParadeDB) {
+	upgrade.Status.Phase = databasev1alpha1.UpgradePhaseRunning
+	upgrade.Status.StartTime = &metav1.Time{Time: time.Now()}
+	upgrade.Status.FromImage = paradedb.Status.CurrentVersion
+	if upgrade.Status.FromImage == "" {
+		upgrade.Status.FromImage = paradedb.GetImage()
+	}
+	upgrade.Status.Steps = nil
+	for _, name := range upgradeSteps {
+		upgrade.Status.Steps = append(upgrade.Status.Steps, databasev1alpha1.UpgradeStepStatus{
+			Name:  name,
+			Phase: databasev1alpha1.UpgradeStepPending,
+		})
+	}
+}
+
+// getRunningUpgrade returns the name of another upgrade of the same instance that is
+// running, if any
+func (r *ParadeDBUpgradeReconciler) getRunningUpgrade(ctx context.Context, upgrade *databasev1alpha1.ParadeDBUpgrade) (string, error) {
+	upgrades := &databasev1alpha1.ParadeDBUpgradeList{}
+	if err := r.List(ctx, upgrades, client.InNamespace(upgrade.Namespace)); err != nil {
+		return "", err
+	}
+	for _, other := range upgrades.Items {
+		if other.Name != upgrade.Name && other.Spec.ParadeDBRef.Name == upgrade.Spec.ParadeDBRef.Name &&
+			other.Status.Phase == databasev1alpha1.UpgradePhaseRunning {
+			return other.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// runUpgradeStep carries out a step as far as it can and returns its phase: Running while
+// it waits for the instance, or Succeeded, Skipped or Failed. Errors are retried.
+func (r *ParadeDBUpgradeReconciler) runUpgradeStep(ctx context.Context, upgrade *databasev1alpha1.ParadeDBUpgrade,
+	paradedb *databasev1alpha1.ParadeDB, name databasev1alpha1.UpgradeStepName) (databasev1alpha1.UpgradeStepPhase, string, error) {
+	switch name {
+	case databasev1alpha1.UpgradeStepBackup:
+		return r.runUpgradeBackup(ctx, upgrade, paradedb)
+	case databasev1alpha1.UpgradeStepReplicas:
+		return r.runUpgradeReplicas(ctx, upgrade, paradedb)
+	case databasev1alpha1.UpgradeStepPrimary:
+		return r.runUpgradePrimary(ctx, upgrade, paradedb)
+	case databasev1alpha1.UpgradeStepExtensions:
+		return r.runUpgradeExtensions(ctx, paradedb)
+	default:
+		return r.runUpgradeVerification(ctx, paradedb)
+	}
+}
+
+// runUpgradeBackup takes a logical backup from the instance's backup CronJob before the
+// image changes
+func (r *ParadeDBUpgradeReconciler) runUpgradeBackup(ctx context.Context, upgrade *databasev1alpha1.ParadeDBUpgrade,
+	paradedb *databasev1alpha1.ParadeDB) (databasev1alpha1.UpgradeStepPhase, string, error) {
+	if !paradedb.IsLogicalBackupEnabled() {
+		return databasev1alpha1.UpgradeStepSkipped, "Logical backups are not enabled on the instance", nil
+	}
+
+	job := &batchv1.Job{}
+	jobName := upgrade.Name + "-backup"
+	err := r.Get(ctx, types.NamespacedName{Name: jobName, Namespace: upgrade.Namespace}, job)
+	if apierrors.IsNotFound(err) {
+		if err := createLogicalBackupJob(ctx, r.Client, r.Scheme, upgrade, paradedb, jobName); err != nil {
+			return "", "", err
+		}
+		return databasev1alpha1.UpgradeStepRunning, "Taking a logical backup in job " + jobName, nil
+	} else if err != nil {
+		return "", "", err
+	}
+
+	if result := getJobResult(job); result != nil && result.Type == batchv1.JobFailed {
+		return databasev1alpha1.UpgradeStepFailed, fmt.Sprintf("Backup job %s failed: %s", jobName, result.Message), nil
+	} else if result != nil {
+		return databasev1alpha1.UpgradeStepSucceeded, "Logical backup taken by job " + jobName, nil
+	}
+	return databasev1alpha1.UpgradeStepRunning, "Taking a logical backup in job " + jobName, nil
+}
+
+// runUpgradeReplicas sets the image on the instance and waits for the replicas to run it.
+// A staged upgrade holds the primary back with a partition until this step is done.
+func (r *ParadeDBUpgradeReconciler) runUpgradeReplicas(ctx context.Context, upgrade *databasev1alpha1.ParadeDBUpgrade,
+	paradedb *databasev1alpha1.ParadeDB) (databasev1alpha1.UpgradeStepPhase, string, error) {
+	if paradedb.GetReplicas() <= 1 {
+		return databasev1alpha1.UpgradeStepSkipped, "The instance has no replicas", nil
+	}
+
+	if paradedb.Spec.Image != upgrade.Spec.Image {
+		patch := client.MergeFrom(paradedb.DeepCopy())
+		paradedb.Spec.Image = upgrade.Spec.Image
+		if upgrade.GetStrategy() == "Staged" {
+			previous := buildUpdateStrategy(paradedb)
+			upgrade.Status.PreviousUpdateStrategy = &previous
+			partition := int32(1)
+			paradedb.Spec.UpdateStrategy = &appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+			}
+		}
+		if err := r.Patch(ctx, paradedb, patch); err != nil {
+			return "", "", fmt.Errorf("failed to set the image: %w", err)
+		}
+	}
+
+	return r.waitForUpdatedPods(ctx, upgrade, paradedb, paradedb.GetReplicas()-1, "replicas")
+}
+
+// runUpgradePrimary releases the primary for update, restoring the updateStrategy a staged
+// upgrade replaced, and waits for every pod to run the image
+func (r *ParadeDBUpgradeReconciler) runUpgradePrimary(ctx context.Context, upgrade *databasev1alpha1.ParadeDBUpgrade,
+	paradedb *databasev1alpha1.ParadeDB) (databasev1alpha1.UpgradeStepPhase, string, error) {
+	restore := upgrade.Status.PreviousUpdateStrategy != nil &&
+		!equality.Semantic.DeepEqual(paradedb.Spec.UpdateStrategy, upgrade.Status.PreviousUpdateStrategy)
+	if paradedb.Spec.Image != upgrade.Spec.Image || restore {
+		patch := client.MergeFrom(paradedb.DeepCopy())
+		paradedb.Spec.Image = upgrade.Spec.Image
+		if restore {
+			paradedb.Spec.UpdateStrategy = upgrade.Status.PreviousUpdateStrategy.DeepCopy()
+		}
+		if err := r.Patch(ctx, paradedb, patch); err != nil {
+			return "", "", fmt.Errorf("failed to update the primary: %w", err)
+		}
+	}
+
+	return r.waitForUpdatedPods(ctx, upgrade, paradedb, paradedb.GetReplicas(), "pods")
+}
+
+// waitForUpdatedPods returns Succeeded once the StatefulSet runs the image on at least
+// count pods and all of its pods are ready
+func (r *ParadeDBUpgradeReconciler) waitForUpdatedPods(ctx context.Context, upgrade *databasev1alpha1.ParadeDBUpgrade,
+	paradedb *databasev1alpha1.ParadeDB, count int32, what string) (databasev1alpha1.UpgradeStepPhase, string, error) {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetStatefulSetName(), Namespace: paradedb.Namespace}, statefulSet); err != nil {
+		return "", "", err
+	}
+
+	// The ParadeDB controller may pin the image to a digest, so compare against the image
+	// it reports to have resolved from the one in the upgrade
+	image := getContainerImage(&statefulSet.Spec.Template)
+	if paradedb.Status.Image != upgrade.Spec.Image || image != paradedb.Status.CurrentVersion {
+		return databasev1alpha1.UpgradeStepRunning, "Waiting for the operator to apply the image", nil
+	}
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation ||
+		statefulSet.Status.UpdatedReplicas < count || statefulSet.Status.ReadyReplicas < paradedb.GetReplicas() {
+		return databasev1alpha1.UpgradeStepRunning, fmt.Sprintf("%d of %d %s updated, %d of %d pods ready",
+			min(statefulSet.Status.UpdatedReplicas, count), count, what, statefulSet.Status.ReadyReplicas, paradedb.GetReplicas()), nil
+	}
+	return databasev1alpha1.UpgradeStepSucceeded, fmt.Sprintf("%d %s run %s", count, what, upgrade.Spec.Image), nil
+}
+
+// runUpgradeExtensions updates the extensions in auth.database and auth.databases to the
+// default versions shipped in the new image
+func (r *ParadeDBUpgradeReconciler) runUpgradeExtensions(ctx context.Context,
+	paradedb *databasev1alpha1.ParadeDB) (databasev1alpha1.UpgradeStepPhase, string, error) {
+	if paradedb.IsStandby() {
+		return databasev1alpha1.UpgradeStepSkipped, "A standby receives extension updates from its primary", nil
+	}
+
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	databases := []string{paradedb.Spec.Auth.Database}
+	for _, database := range paradedb.Spec.Auth.Databases {
+		databases = append(databases, database.Name)
+	}
+	var updated []string
+	for _, database := range databases {
+		err := withDatabase(ctx, buildDatabaseURL(paradedb, username, password, database), func(ctx context.Context, db *sql.DB) error {
+			extensions, err := listOutdatedExtensions(ctx, db)
+			if err != nil {
+				return err
+			}
+			for _, extension := range extensions {
+				if _, err := db.ExecContext(ctx, "ALTER EXTENSION "+pq.QuoteIdentifier(extension)+" UPDATE"); err != nil {
+					return fmt.Errorf("extension %s: %w", extension, err)
+				}
+				updated = append(updated, database+"."+extension)
+			}
+			return nil
+		})
+		if err != nil {
+			// Retried, as the database may still be starting on the new image
+			return databasev1alpha1.UpgradeStepRunning, fmt.Sprintf("Failed to update extensions in %s: %v", database, err), nil
+		}
+	}
+
+	if len(updated) == 0 {
+		return databasev1alpha1.UpgradeStepSucceeded, "All extensions are up to date", nil
+	}
+	return databasev1alpha1.UpgradeStepSucceeded, "Updated extensions " + strings.Join(updated, ", "), nil
+}
+
+// listOutdatedExtensions returns the installed extensions whose default version differs
+// from the installed one
+func listOutdatedExtensions(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT e.extname FROM pg_extension e
+  JOIN pg_available_extensions a ON a.name = e.extname
+  WHERE a.default_version IS DISTINCT FROM e.extversion ORDER BY e.extname`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var extensions []string
+	for rows.Next() {
+		var extension string
+		if err := rows.Scan(&extension); err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, extension)
+	}
+	return extensions, rows.Err()
+}
+
+// runUpgradeVerification checks that the primary answers queries on the new image
+func (r *ParadeDBUpgradeReconciler) runUpgradeVerification(ctx context.Context,
+	paradedb *databasev1alpha1.ParadeDB) (databasev1alpha1.UpgradeStepPhase, string, error) {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	stats, err := queryDatabaseStats(ctx, buildConnectionURL(paradedb, username, password))
+	if err != nil {
+		return databasev1alpha1.UpgradeStepRunning, fmt.Sprintf("Waiting for the database to accept connections: %v", err), nil
+	}
+	return databasev1alpha1.UpgradeStepSucceeded, fmt.Sprintf("The database answered in %s with %d extensions installed",
+		stats.Latency.Round(time.Millisecond), len(stats.Extensions)), nil
+}
+
+// failUpgrade marks the upgrade as failed. Completed steps are not rolled back.
+func (r *ParadeDBUpgradeReconciler) failUpgrade(ctx context.Context, upgrade *databasev1alpha1.ParadeDBUpgrade, message string) error {
+	upgrade.Status.Phase = databasev1alpha1.UpgradePhaseFailed
+	upgrade.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	upgrade.Status.Message = message
+	r.Recorder.Event(upgrade, corev1.EventTypeWarning, "UpgradeFailed", message)
+	return r.Status().Update(ctx, upgrade)
+}
+
+// setUpgradePending records why the upgrade has not started yet and retries later
+func (r *ParadeDBUpgradeReconciler) setUpgradePending(ctx context.Context, upgrade *databasev1alpha1.ParadeDBUpgrade, message string) (ctrl.Result, error) {
+	upgrade.Status.Phase = databasev1alpha1.UpgradePhasePending
+	upgrade.Status.Message = message
+	if err := r.Status().Update(ctx, upgrade); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfterError}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. The instance is polled while
+// a step waits for it rather than watched.
+func (r *ParadeDBUpgradeReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&databasev1alpha1.ParadeDBUpgrade{}).
+		Owns(&batchv1.Job{}).
+		Named("paradedbupgrade").
+		Complete(r)
+}
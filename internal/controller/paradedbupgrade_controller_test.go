@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+var _ = Describe("ParadeDBUpgrade Controller", func() {
+	Context("When running a staged upgrade", func() {
+		It("should update the replicas before releasing the primary", func() {
+			upgradeScheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(upgradeScheme)).To(Succeed())
+			Expect(databasev1alpha1.AddToScheme(upgradeScheme)).To(Succeed())
+
+			replicas := int32(3)
+			paradedb := &databasev1alpha1.ParadeDB{
+				ObjectMeta: metav1.ObjectMeta{Name: "staged", Namespace: "default"},
+				Spec:       databasev1alpha1.ParadeDBSpec{Replicas: &replicas, Image: "paradedb/paradedb:0.14.3-pg16"},
+			}
+			upgrade := &databasev1alpha1.ParadeDBUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "to-0-15", Namespace: "default", UID: "upgrade-uid"},
+				Spec: databasev1alpha1.ParadeDBUpgradeSpec{
+					ParadeDBRef: corev1.LocalObjectReference{Name: "staged"},
+					Image:       "paradedb/paradedb:0.15.0-pg16",
+				},
+			}
+			reconciler := &ParadeDBUpgradeReconciler{
+				Client: fake.NewClientBuilder().WithScheme(upgradeScheme).
+					WithObjects(paradedb, upgrade).WithStatusSubresource(paradedb, upgrade).Build(),
+				Scheme:   upgradeScheme,
+				Recorder: record.NewFakeRecorder(10),
+			}
+			request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "to-0-15", Namespace: "default"}}
+			statefulSet := (&ParadeDBReconciler{}).buildStatefulSet(paradedb)
+			Expect(reconciler.Create(ctx, statefulSet)).To(Succeed())
+
+			_, err := reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, request.NamespacedName, upgrade)).To(Succeed())
+			Expect(upgrade.Status.Phase).To(Equal(databasev1alpha1.UpgradePhaseRunning))
+			Expect(upgrade.GetStep(databasev1alpha1.UpgradeStepBackup).Phase).To(Equal(databasev1alpha1.UpgradeStepSkipped))
+			Expect(upgrade.Status.CurrentStep).To(Equal(databasev1alpha1.UpgradeStepReplicas))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "staged", Namespace: "default"}, paradedb)).To(Succeed())
+			Expect(paradedb.Spec.Image).To(Equal("paradedb/paradedb:0.15.0-pg16"))
+			Expect(*paradedb.Spec.UpdateStrategy.RollingUpdate.Partition).To(Equal(int32(1)))
+
+			// Until the ParadeDB controller applies the image, the step waits
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, request.NamespacedName, upgrade)).To(Succeed())
+			Expect(upgrade.GetStep(databasev1alpha1.UpgradeStepReplicas).Message).To(ContainSubstring("Waiting for the operator"))
+
+			// The ParadeDB controller pins the image to a digest and rolls the replicas onto it
+			pinned := withDigest("paradedb/paradedb:0.15.0-pg16", "sha256:0123")
+			statefulSet.Spec.Template.Spec.Containers[0].Image = pinned
+			Expect(reconciler.Update(ctx, statefulSet)).To(Succeed())
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "staged", Namespace: "default"}, paradedb)).To(Succeed())
+			paradedb.Status.Image = "paradedb/paradedb:0.15.0-pg16"
+			paradedb.Status.CurrentVersion = pinned
+			Expect(reconciler.Status().Update(ctx, paradedb)).To(Succeed())
+			statefulSet.Status = appsv1.StatefulSetStatus{ObservedGeneration: statefulSet.Generation, UpdatedReplicas: 2, ReadyReplicas: 3}
+			Expect(reconciler.Status().Update(ctx, statefulSet)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reconciler.Get(ctx, request.NamespacedName, upgrade)).To(Succeed())
+			Expect(upgrade.GetStep(databasev1alpha1.UpgradeStepReplicas).Phase).To(Equal(databasev1alpha1.UpgradeStepSucceeded))
+			Expect(upgrade.Status.CurrentStep).To(Equal(databasev1alpha1.UpgradeStepPrimary))
+			Expect(reconciler.Get(ctx, types.NamespacedName{Name: "staged", Namespace: "default"}, paradedb)).To(Succeed())
+			Expect(paradedb.Spec.UpdateStrategy.RollingUpdate).To(BeNil())
+		})
+	})
+})
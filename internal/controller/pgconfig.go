@@ -0,0 +1,257 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// configHistoryLimit bounds the revisions kept in the configuration history ConfigMap
+const configHistoryLimit = 10
+
+// postgresConfigTemplate renders postgresql.conf from postgresConfigValues
+var postgresConfigTemplate = template.Must(template.New("postgresql.conf").
+	Funcs(template.FuncMap{"join": strings.Join}).
+	Parse(`# ParadeDB PostgreSQL Configuration
+# Generated by paradedb-operator
+
+listen_addresses = '*'
+port = {{ .Port }}
+
+max_connections = {{ .MaxConnections }}
+superuser_reserved_connections = 3
+
+shared_buffers = 128MB
+effective_cache_size = 512MB
+maintenance_work_mem = 64MB
+work_mem = 4MB
+
+wal_level = {{ .WALLevel }}
+max_wal_senders = 10
+max_replication_slots = 10
+wal_keep_size = 1GB
+
+logging_collector = on
+log_directory = 'log'
+log_filename = 'postgresql-%Y-%m-%d_%H%M%S.log'
+log_rotation_age = 1d
+log_rotation_size = 100MB
+log_min_messages = warning
+log_min_error_statement = error
+
+checkpoint_timeout = 5min
+checkpoint_completion_target = 0.9
+
+# ParadeDB Extensions
+{{ if .PreloadLibraries -}}
+shared_preload_libraries = '{{ join .PreloadLibraries "," }}'
+
+{{ end -}}
+{{ if .TLS -}}
+# TLS Configuration
+ssl = on
+ssl_cert_file = '/etc/postgresql/tls/tls.crt'
+ssl_key_file = '/etc/postgresql/tls/tls.key'
+ssl_ca_file = '/etc/postgresql/tls/ca.crt'
+
+{{ end -}}
+{{ if .Custom -}}
+# Custom Configuration
+{{ range .Custom -}}
+{{ .Name }} = {{ .Value }}
+{{ end -}}
+{{ end -}}
+`))
+
+// postgresConfigParameter is a setting from spec.postgresConfig
+type postgresConfigParameter struct {
+	Name  string
+	Value string
+}
+
+// postgresConfigValues are the spec-derived values postgresConfigTemplate is rendered with
+type postgresConfigValues struct {
+	Port             int32
+	MaxConnections   int32
+	WALLevel         string
+	PreloadLibraries []string
+	TLS              bool
+	Custom           []postgresConfigParameter
+}
+
+// buildPostgresConfig renders the PostgreSQL configuration. Custom settings are sorted by
+// name so that the same spec always renders the same file.
+func buildPostgresConfig(paradedb *databasev1alpha1.ParadeDB) string {
+	values := postgresConfigValues{
+		Port:           paradedb.GetPort(),
+		MaxConnections: paradedb.GetMaxConnections(),
+		WALLevel:       "replica",
+		TLS:            paradedb.IsTLSEnabled(),
+	}
+	if paradedb.IsCDCEnabled() {
+		values.WALLevel = "logical"
+	}
+
+	// Shared preload libraries for ParadeDB extensions
+	if paradedb.Spec.Extensions.PgSearch {
+		values.PreloadLibraries = append(values.PreloadLibraries, "pg_search")
+	}
+	if paradedb.Spec.Extensions.PgAnalytics {
+		values.PreloadLibraries = append(values.PreloadLibraries, "pg_analytics")
+	}
+	if paradedb.Spec.Extensions.PgVector {
+		values.PreloadLibraries = append(values.PreloadLibraries, "vector")
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(paradedb.Spec.PostgresConfig)) {
+		values.Custom = append(values.Custom, postgresConfigParameter{Name: name, Value: paradedb.Spec.PostgresConfig[name]})
+	}
+
+	var config strings.Builder
+	// The template is fixed and its values are plain strings and numbers, so it cannot fail
+	_ = postgresConfigTemplate.Execute(&config, values)
+	return config.String()
+}
+
+// buildConfigDiff returns the settings removed from and added to a configuration file, as
+// "-" and "+" lines in the order they appear. Comments and blank lines are ignored, so a
+// changed setting shows up as its old line removed and its new line added.
+func buildConfigDiff(previous, current string) string {
+	settings := func(config string) []string {
+		var lines []string
+		for line := range strings.SplitSeq(config, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+	before, after := settings(previous), settings(current)
+
+	var diff strings.Builder
+	for _, line := range before {
+		if !slices.Contains(after, line) {
+			diff.WriteString("- " + line + "\n")
+		}
+	}
+	for _, line := range after {
+		if !slices.Contains(before, line) {
+			diff.WriteString("+ " + line + "\n")
+		}
+	}
+	return diff.String()
+}
+
+// getConfigRevision returns the revision number of a history key such as revision-3.conf
+func getConfigRevision(key string) (int64, bool) {
+	number, ok := strings.CutPrefix(strings.TrimSuffix(key, ".conf"), "revision-")
+	if !ok || !strings.HasSuffix(key, ".conf") {
+		return 0, false
+	}
+	revision, err := strconv.ParseInt(number, 10, 64)
+	return revision, err == nil
+}
+
+// reconcileConfigHistory records the rendered postgresql.conf in the history ConfigMap
+// whenever it changes, as revision-<n>.conf with revision-<n>.diff against the revision
+// before it, keeping the last configHistoryLimit revisions. The current revision is
+// reported in status.configRevision.
+func (r *ParadeDBReconciler) reconcileConfigHistory(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, config string) error {
+	log := logf.FromContext(ctx)
+
+	history := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetConfigHistoryConfigMapName(), Namespace: paradedb.Namespace}, history)
+	if apierrors.IsNotFound(err) {
+		history = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      paradedb.GetConfigHistoryConfigMapName(),
+				Namespace: paradedb.Namespace,
+				Labels:    r.getLabels(paradedb),
+			},
+		}
+		if err := controllerutil.SetControllerReference(paradedb, history, r.Scheme); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	var revisions []int64
+	for key := range history.Data {
+		if revision, ok := getConfigRevision(key); ok {
+			revisions = append(revisions, revision)
+		}
+	}
+	slices.Sort(revisions)
+
+	var latest int64
+	if len(revisions) > 0 {
+		latest = revisions[len(revisions)-1]
+		previous := history.Data[fmt.Sprintf("revision-%d.conf", latest)]
+		if previous == config {
+			paradedb.Status.ConfigRevision = latest
+			return nil
+		}
+	}
+
+	revision := latest + 1
+	if history.Data == nil {
+		history.Data = map[string]string{}
+	}
+	history.Data[fmt.Sprintf("revision-%d.conf", revision)] = config
+	if latest > 0 {
+		diff := buildConfigDiff(history.Data[fmt.Sprintf("revision-%d.conf", latest)], config)
+		history.Data[fmt.Sprintf("revision-%d.diff", revision)] = diff
+		message := fmt.Sprintf("postgresql.conf revision %d:\n%s", revision, diff)
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "ConfigRevised", message)
+	}
+	for _, old := range revisions {
+		if old > revision-configHistoryLimit {
+			break
+		}
+		delete(history.Data, fmt.Sprintf("revision-%d.conf", old))
+		delete(history.Data, fmt.Sprintf("revision-%d.diff", old))
+	}
+
+	if history.ResourceVersion == "" {
+		log.Info("Creating configuration history ConfigMap", "name", history.Name)
+		err = r.Create(ctx, history)
+	} else {
+		err = r.Update(ctx, history)
+	}
+	if err != nil {
+		return err
+	}
+	paradedb.Status.ConfigRevision = revision
+	return nil
+}
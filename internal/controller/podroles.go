@@ -0,0 +1,79 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// roleLabel marks each pod as the primary or a replica
+	roleLabel = "database.paradedb.io/role"
+
+	rolePrimary = "primary"
+	roleReplica = "replica"
+)
+
+// getPrimaryPodName returns the pod that runs the primary, or "" for a standby cluster,
+// whose pods all replay from another primary. Until replication within an instance is
+// supported, the first pod is the primary.
+func getPrimaryPodName(paradedb *databasev1alpha1.ParadeDB) string {
+	if paradedb.IsStandby() {
+		return ""
+	}
+	return paradedb.GetStatefulSetName() + "-0"
+}
+
+// reconcilePodRoles labels each pod with its role and records the primary pod in the
+// status. The labels are patched on the pods rather than set in the pod template, so a
+// promotion does not roll the pods.
+func (r *ParadeDBReconciler) reconcilePodRoles(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(paradedb.Namespace), client.MatchingLabels(r.getSelectorLabels(paradedb))); err != nil {
+		return err
+	}
+
+	primary := getPrimaryPodName(paradedb)
+	paradedb.Status.PrimaryPod = ""
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		role := roleReplica
+		if pod.Name == primary {
+			role = rolePrimary
+			paradedb.Status.PrimaryPod = pod.Name
+		}
+		if pod.Labels[roleLabel] == role {
+			continue
+		}
+
+		patch := client.MergeFrom(pod.DeepCopy())
+		if pod.Labels == nil {
+			pod.Labels = map[string]string{}
+		}
+		pod.Labels[roleLabel] = role
+		if err := r.Patch(ctx, pod, patch); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
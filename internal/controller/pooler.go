@@ -0,0 +1,303 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// poolerAdminDatabase is PgBouncer's virtual admin console database
+const poolerAdminDatabase = "pgbouncer"
+
+// validatePoolerRoutes rejects routes whose names are not unique or that shadow a database
+// the pooler already serves
+func validatePoolerRoutes(paradedb *databasev1alpha1.ParadeDB) error {
+	served := map[string]bool{paradedb.Spec.Auth.Database: true, poolerAdminDatabase: true}
+	for _, database := range paradedb.GetPoolerDatabases() {
+		served[database.Name] = true
+	}
+	for _, route := range paradedb.GetPoolerRoutes() {
+		if served[route.Name] {
+			return fmt.Errorf("spec.connectionPooling.routes: %q is already a database served by the pooler", route.Name)
+		}
+		served[route.Name] = true
+	}
+	return nil
+}
+
+// buildPoolerRouteConnString returns the PgBouncer connection string of a route
+func buildPoolerRouteConnString(paradedb *databasev1alpha1.ParadeDB, route databasev1alpha1.PoolerRouteSpec) string {
+	host, port, database := route.Host, route.Port, route.Database
+	if host == "" {
+		host = paradedb.GetServiceName()
+	}
+	if port == 0 {
+		port = paradedb.GetPort()
+	}
+	if database == "" {
+		database = route.Name
+	}
+	entry := fmt.Sprintf("host=%s port=%d dbname=%s", host, port, database)
+	if route.PoolSize > 0 {
+		entry += fmt.Sprintf(" pool_size=%d", route.PoolSize)
+	}
+	if route.PoolMode != "" {
+		entry += " pool_mode=" + route.PoolMode
+	}
+	return entry
+}
+
+// buildPoolerStrategy returns the rollout strategy of the PgBouncer Deployment. With more
+// than one replica, a new pod has to be ready before an old one is stopped, so a rollout
+// never takes down more than one pooler's clients at a time.
+func buildPoolerStrategy(paradedb *databasev1alpha1.ParadeDB) appsv1.DeploymentStrategy {
+	if paradedb.GetPoolerReplicas() < 2 {
+		return appsv1.DeploymentStrategy{}
+	}
+	maxUnavailable := intstr.FromInt32(0)
+	maxSurge := intstr.FromInt32(1)
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &maxUnavailable,
+			MaxSurge:       &maxSurge,
+		},
+	}
+}
+
+// buildPoolerPDB returns the PodDisruptionBudget that lets voluntary disruptions, such as
+// node drains, evict only one PgBouncer pod at a time
+func (r *ParadeDBReconciler) buildPoolerPDB(paradedb *databasev1alpha1.ParadeDB) *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt32(1)
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      paradedb.GetPoolerPDBName(),
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+				"app.kubernetes.io/name":      "pgbouncer",
+				"app.kubernetes.io/instance":  paradedb.Name,
+				"app.kubernetes.io/component": "pooler",
+			}},
+		},
+	}
+}
+
+// reconcilePoolerPDB keeps the pooler's PodDisruptionBudget up to date while there is more
+// than one pooler. A single pooler gets none, since it would block node drains; the
+// orphan collection removes the budget after scaling down.
+func (r *ParadeDBReconciler) reconcilePoolerPDB(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	if paradedb.GetPoolerReplicas() < 2 {
+		return nil
+	}
+
+	desired := r.buildPoolerPDB(paradedb)
+	pdb := &policyv1.PodDisruptionBudget{}
+	err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: paradedb.Namespace}, pdb)
+	if apierrors.IsNotFound(err) {
+		if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, desired)
+	} else if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(pdb.Spec, desired.Spec) {
+		return nil
+	}
+	pdb.Spec = desired.Spec
+	return r.Update(ctx, pdb)
+}
+
+// updatePoolerStatus sums up the pools of every ready pooler pod in status.pooler. The
+// superuser is PgBouncer's admin user. A pod that cannot be queried leaves the previous
+// summary in place.
+func (r *ParadeDBReconciler) updatePoolerStatus(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	log := logf.FromContext(ctx)
+
+	if !paradedb.IsConnectionPoolingEnabled() {
+		paradedb.Status.Pooler = nil
+		return
+	}
+
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		log.Error(err, "Failed to read credentials for the pooler")
+		return
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(paradedb.Namespace), client.MatchingLabels{
+		"app.kubernetes.io/name":      "pgbouncer",
+		"app.kubernetes.io/instance":  paradedb.Name,
+		"app.kubernetes.io/component": "pooler",
+	}); err != nil {
+		log.Error(err, "Failed to list pooler pods")
+		return
+	}
+
+	var totals poolerPools
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.PodIP == "" || !isPodReady(pod) {
+			continue
+		}
+		u := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(username, password),
+			Host:     net.JoinHostPort(pod.Status.PodIP, "5432"),
+			Path:     "/" + poolerAdminDatabase,
+			RawQuery: url.Values{"sslmode": {"disable"}, "connect_timeout": {fmt.Sprintf("%d", int(databaseConnectTimeout.Seconds()))}}.Encode(),
+		}
+		err := withDatabase(ctx, u.String(), func(ctx context.Context, db *sql.DB) error {
+			return totals.add(ctx, db)
+		})
+		if err != nil {
+			log.Info("Failed to query pooler pools", "pod", pod.Name, "reason", err.Error())
+			return
+		}
+	}
+
+	now := metav1.Now()
+	paradedb.Status.Pooler = totals.status(now)
+}
+
+// poolerPools sums up the pools of one or more PgBouncers
+type poolerPools struct {
+	activeClients  int32
+	waitingClients int32
+	activeServers  int32
+	servers        int32
+	poolSize       int32
+	maxWait        int32
+}
+
+// status returns the summary for status.pooler
+func (p *poolerPools) status(now metav1.Time) *databasev1alpha1.PoolerStatus {
+	status := &databasev1alpha1.PoolerStatus{
+		ActiveClients:     p.activeClients,
+		WaitingClients:    p.waitingClients,
+		ServerConnections: p.servers,
+		PoolSize:          p.poolSize,
+		MaxWaitSeconds:    p.maxWait,
+		Saturated:         p.waitingClients > 0,
+		LastUpdateTime:    &now,
+	}
+	if p.poolSize > 0 {
+		status.UtilizationPercent = int32(int64(p.activeServers) * 100 / int64(p.poolSize))
+	}
+	return status
+}
+
+// add queries the pools of one PgBouncer. The admin console only speaks the simple query
+// protocol, which lib/pq uses for queries without arguments, and its columns vary between
+// PgBouncer versions, so they are read by name.
+func (p *poolerPools) add(ctx context.Context, db *sql.DB) error {
+	databases, err := queryAdminConsole(ctx, db, "SHOW DATABASES")
+	if err != nil {
+		return err
+	}
+	poolSizes := map[string]int32{}
+	for _, database := range databases {
+		poolSizes[database["name"]] = parseAdminInt(database["pool_size"])
+	}
+
+	pools, err := queryAdminConsole(ctx, db, "SHOW POOLS")
+	if err != nil {
+		return err
+	}
+	p.addPools(pools, poolSizes)
+	return nil
+}
+
+// addPools adds SHOW POOLS rows, skipping the admin console's own pool
+func (p *poolerPools) addPools(pools []map[string]string, poolSizes map[string]int32) {
+	for _, pool := range pools {
+		if pool["database"] == poolerAdminDatabase {
+			continue
+		}
+		p.activeClients += parseAdminInt(pool["cl_active"])
+		p.waitingClients += parseAdminInt(pool["cl_waiting"])
+		p.activeServers += parseAdminInt(pool["sv_active"])
+		p.servers += parseAdminInt(pool["sv_active"]) + parseAdminInt(pool["sv_idle"]) +
+			parseAdminInt(pool["sv_used"]) + parseAdminInt(pool["sv_tested"]) + parseAdminInt(pool["sv_login"])
+		p.poolSize += poolSizes[pool["database"]]
+		p.maxWait = max(p.maxWait, parseAdminInt(pool["maxwait"]))
+	}
+}
+
+// queryAdminConsole runs a SHOW command on the PgBouncer admin console and returns its
+// rows keyed by column name
+func queryAdminConsole(ctx context.Context, db *sql.DB, command string) ([]map[string]string, error) {
+	rows, err := db.QueryContext(ctx, command)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", command, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var result []map[string]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("%s: %w", command, err)
+		}
+		row := make(map[string]string, len(columns))
+		for i, column := range columns {
+			row[column] = values[i].String
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// parseAdminInt parses a counter from the admin console, treating anything else as zero
+func parseAdminInt(value string) int32 {
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(n)
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// databaseConnectTimeout bounds how long the operator waits on a database connection
+	databaseConnectTimeout = 5 * time.Second
+
+	// databaseStatementTimeout bounds how long the operator waits on DDL such as
+	// CREATE SUBSCRIPTION, which has to reach the remote server
+	databaseStatementTimeout = 30 * time.Second
+
+	// passwordlessSuperuser is the superuser created when no Secret holds credentials
+	passwordlessSuperuser = "postgres"
+)
+
+// getSuperuserCredentials reads the username and password the operator connects with
+func getSuperuserCredentials(ctx context.Context, c client.Reader, paradedb *databasev1alpha1.ParadeDB) (string, string, error) {
+	credentialsSecretName := paradedb.GetSuperuserSecretName()
+	if credentialsSecretName == "" {
+		return passwordlessSuperuser, "", nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: credentialsSecretName, Namespace: paradedb.Namespace}, secret); err != nil {
+		return "", "", err
+	}
+	return string(secret.Data["username"]), string(secret.Data["password"]), nil
+}
+
+// getCredentialsHash returns a hash of the superuser credentials, set on the pod templates
+// of components that read them at startup so that they restart when the Secret changes
+func getCredentialsHash(ctx context.Context, c client.Reader, paradedb *databasev1alpha1.ParadeDB) (string, error) {
+	username, password, err := getSuperuserCredentials(ctx, c, paradedb)
+	if err != nil {
+		return "", err
+	}
+	return shortHash(username, password), nil
+}
+
+// buildClientEnv returns the libpq environment that points client tools such as psql
+// and pg_dump at the primary Service as the superuser
+func buildClientEnv(paradedb *databasev1alpha1.ParadeDB) []corev1.EnvVar {
+	return append([]corev1.EnvVar{
+		{Name: "PGHOST", Value: fmt.Sprintf("%s.%s.svc", paradedb.GetServiceName(), paradedb.Namespace)},
+		{Name: "PGPORT", Value: fmt.Sprintf("%d", paradedb.GetPort())},
+		{Name: "PGSSLMODE", Value: getSSLMode(paradedb)},
+	}, buildSuperuserEnv(paradedb, "PGUSER", "PGPASSWORD")...)
+}
+
+// buildSuperuserEnv returns the variables holding the superuser name and password, read
+// from the credentials Secret. A passwordless superuser only gets the name.
+func buildSuperuserEnv(paradedb *databasev1alpha1.ParadeDB, usernameVar, passwordVar string) []corev1.EnvVar {
+	credentialsSecretName := paradedb.GetSuperuserSecretName()
+	if credentialsSecretName == "" {
+		return []corev1.EnvVar{{Name: usernameVar, Value: passwordlessSuperuser}}
+	}
+
+	return []corev1.EnvVar{
+		{
+			Name: usernameVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
+					Key:                  "username",
+				},
+			},
+		},
+		{
+			Name: passwordVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: credentialsSecretName},
+					Key:                  "password",
+				},
+			},
+		},
+	}
+}
+
+// getSSLMode returns the libpq sslmode used to reach the instance. Traffic routed through
+// mesh mTLS is not encrypted a second time.
+func getSSLMode(paradedb *databasev1alpha1.ParadeDB) string {
+	if paradedb.IsTLSEnabled() && !paradedb.IsMeshEncrypted() {
+		return "require"
+	}
+	return "disable"
+}
+
+// buildConnectionURL returns the URL the operator uses to connect to the primary Service
+func buildConnectionURL(paradedb *databasev1alpha1.ParadeDB, username, password string) string {
+	return buildDatabaseURL(paradedb, username, password, paradedb.Spec.Auth.Database)
+}
+
+// buildDatabaseURL returns the URL of the given database behind the primary Service
+func buildDatabaseURL(paradedb *databasev1alpha1.ParadeDB, username, password, database string) string {
+	return buildHostURL(paradedb, fmt.Sprintf("%s.%s.svc", paradedb.GetServiceName(), paradedb.Namespace), username, password, database)
+}
+
+// buildHostURL returns the URL of the given database on a specific host, such as a pod IP
+func buildHostURL(paradedb *databasev1alpha1.ParadeDB, host, username, password, database string) string {
+	sslMode := getSSLMode(paradedb)
+
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(username, password),
+		Host:   net.JoinHostPort(host, fmt.Sprintf("%d", paradedb.GetPort())),
+		Path:   "/" + database,
+		RawQuery: url.Values{
+			"sslmode":         {sslMode},
+			"connect_timeout": {fmt.Sprintf("%d", int(databaseConnectTimeout.Seconds()))},
+		}.Encode(),
+	}
+	return u.String()
+}
+
+// buildConnInfo returns a libpq key/value connection string for the given database
+// behind the primary Service, as used by CREATE SUBSCRIPTION
+func buildConnInfo(paradedb *databasev1alpha1.ParadeDB, username, password, database string) string {
+	quote := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return fmt.Sprintf("host=%s.%s.svc port=%d user='%s' password='%s' dbname='%s' sslmode=%s",
+		paradedb.GetServiceName(), paradedb.Namespace, paradedb.GetPort(),
+		quote.Replace(username), quote.Replace(password), quote.Replace(database), getSSLMode(paradedb))
+}
+
+// withDatabase opens a connection, runs fn and closes the connection again
+func withDatabase(ctx context.Context, connectionURL string, fn func(ctx context.Context, db *sql.DB) error) error {
+	ctx, cancel := context.WithTimeout(ctx, databaseStatementTimeout)
+	defer cancel()
+
+	db, err := sql.Open("postgres", connectionURL)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	return fn(ctx, db)
+}
+
+// quoteQualifiedName quotes each part of an optionally schema-qualified name
+func quoteQualifiedName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = pq.QuoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// quoteIdentifiers quotes and comma-separates a list of identifiers
+func quoteIdentifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = pq.QuoteIdentifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// databaseStats is what the operator learns from querying the primary
+type databaseStats struct {
+	// Latency of the initial trivial query
+	Latency time.Duration
+	// SizeBytes is the total size of all databases
+	SizeBytes int64
+	// WALBytes is the size of the WAL directory
+	WALBytes int64
+	// Connections is the number of client backends
+	Connections int32
+	// Extensions installed in the connected database
+	Extensions []databasev1alpha1.ExtensionStatus
+	// WALArchive is nil unless the connected server archives WAL
+	WALArchive *databasev1alpha1.WALArchiveStatus
+	// Replication is what the connected server knows about streaming replication
+	Replication replicationStats
+}
+
+// replicationStats are the replication figures of the connected server
+type replicationStats struct {
+	// InRecovery is true on a standby
+	InRecovery bool
+	// Receiving is true while a standby's WAL receiver is streaming
+	Receiving bool
+	// Senders is the number of WAL senders that are streaming
+	Senders int32
+	// InactiveSlots is the number of replication slots without a consumer
+	InactiveSlots int32
+	// LagSeconds is how far WAL replay is behind on a standby
+	LagSeconds int32
+}
+
+// queryDatabaseStats opens a connection, checks it with a trivial query and collects
+// capacity figures and installed extensions from the catalog
+func queryDatabaseStats(ctx context.Context, connectionURL string) (*databaseStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, databaseConnectTimeout)
+	defer cancel()
+
+	db, err := sql.Open("postgres", connectionURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = db.Close() }()
+
+	stats := &databaseStats{}
+
+	start := time.Now()
+	var result int
+	if err := db.QueryRowContext(ctx, "SELECT 1").Scan(&result); err != nil {
+		return nil, err
+	}
+	stats.Latency = time.Since(start)
+
+	err = db.QueryRowContext(ctx, `SELECT
+  (SELECT coalesce(sum(pg_database_size(datname)), 0) FROM pg_database),
+  (SELECT coalesce(sum(size), 0) FROM pg_ls_waldir()),
+  (SELECT count(*) FROM pg_stat_activity WHERE backend_type = 'client backend' AND pid <> pg_backend_pid())`,
+	).Scan(&stats.SizeBytes, &stats.WALBytes, &stats.Connections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect database statistics: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT extname, extversion, current_database() FROM pg_extension ORDER BY extname")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list extensions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var extension databasev1alpha1.ExtensionStatus
+		if err := rows.Scan(&extension.Name, &extension.Version, &extension.Database); err != nil {
+			return nil, fmt.Errorf("failed to list extensions: %w", err)
+		}
+		stats.Extensions = append(stats.Extensions, extension)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list extensions: %w", err)
+	}
+
+	if stats.WALArchive, err = queryWALArchive(ctx, db); err != nil {
+		return nil, fmt.Errorf("failed to read archiver statistics: %w", err)
+	}
+
+	replication := &stats.Replication
+	err = db.QueryRowContext(ctx, `SELECT pg_is_in_recovery(),
+  EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status = 'streaming'),
+  (SELECT count(*) FROM pg_stat_replication WHERE state = 'streaming'),
+  (SELECT count(*) FROM pg_replication_slots WHERE NOT active),
+  CASE WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+    ELSE coalesce(extract(epoch FROM now() - pg_last_xact_replay_timestamp()), 0)::int END`,
+	).Scan(&replication.InRecovery, &replication.Receiving, &replication.Senders, &replication.InactiveSlots, &replication.LagSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replication statistics: %w", err)
+	}
+
+	return stats, nil
+}
+
+// queryWALArchive reads pg_stat_archiver, returning nil if the server does not archive
+// WAL. A standby only archives with archive_mode set to always.
+func queryWALArchive(ctx context.Context, db *sql.DB) (*databasev1alpha1.WALArchiveStatus, error) {
+	var lastArchivedWAL, lastFailedWAL string
+	var lastArchivedTime, lastFailedTime sql.NullTime
+	err := db.QueryRowContext(ctx, `SELECT coalesce(last_archived_wal, ''), last_archived_time,
+  coalesce(last_failed_wal, ''), last_failed_time
+FROM pg_stat_archiver
+WHERE current_setting('archive_mode') = 'always'
+  OR (current_setting('archive_mode') = 'on' AND NOT pg_is_in_recovery())`,
+	).Scan(&lastArchivedWAL, &lastArchivedTime, &lastFailedWAL, &lastFailedTime)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	status := &databasev1alpha1.WALArchiveStatus{LastArchivedWAL: lastArchivedWAL, LastFailedWAL: lastFailedWAL}
+	if lastArchivedTime.Valid {
+		status.LastArchivedTime = &metav1.Time{Time: lastArchivedTime.Time}
+	}
+	if lastFailedTime.Valid {
+		status.LastFailedTime = &metav1.Time{Time: lastFailedTime.Time}
+		status.Failing = !lastArchivedTime.Valid || lastFailedTime.Time.After(lastArchivedTime.Time)
+	}
+	return status, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// RenderManifests returns the objects the operator would create for the ParadeDB,
+// without applying anything. The spec is resolved as during reconciliation, with the
+// reconciler's operator configuration and client, which only reads. Secrets holding
+// generated credentials are left out.
+func (r *ParadeDBReconciler) RenderManifests(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) ([]client.Object, error) {
+	// Events of resolving the spec describe a reconciliation, not a preview
+	preview := *r
+	preview.Recorder = &record.FakeRecorder{}
+	r = &preview
+
+	paradedb = paradedb.DeepCopy()
+	if _, err := r.resolveSpec(ctx, paradedb); err != nil {
+		return nil, err
+	}
+
+	configMap, err := r.buildConfigMap(paradedb)
+	if err != nil {
+		return nil, err
+	}
+	statefulSet := r.buildStatefulSet(paradedb)
+	if err := applyPodTemplateOverrides(paradedb, &statefulSet.Spec.Template, r.getSelectorLabels(paradedb)); err != nil {
+		return nil, err
+	}
+
+	// Components reading the credentials at startup record them in their pod templates.
+	// The credentials of an instance that does not exist yet are generated on creation.
+	var credentialsAnnotations map[string]string
+	if credentialsHash, err := getCredentialsHash(ctx, r.Client, paradedb); err == nil {
+		credentialsAnnotations = map[string]string{credentialsHashAnnotation: credentialsHash}
+	} else if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if paradedb.IsMonitoringEnabled() {
+		statefulSet.Spec.Template.Annotations = mergeMaps(statefulSet.Spec.Template.Annotations, credentialsAnnotations)
+	}
+	objects := []client.Object{
+		configMap,
+		statefulSet,
+		r.buildService(paradedb),
+		r.buildHeadlessService(paradedb),
+	}
+	if paradedb.IsServiceAccountManaged() {
+		var annotations map[string]string
+		if paradedb.Spec.ServiceAccount != nil {
+			annotations = paradedb.Spec.ServiceAccount.Annotations
+		}
+		objects = append(objects, r.buildServiceAccount(paradedb, paradedb.GetServiceAccountName(), annotations))
+	}
+	if paradedb.Spec.PerPodServices {
+		for i := int32(0); i < paradedb.GetReplicas(); i++ {
+			objects = append(objects, r.buildPodService(paradedb, fmt.Sprintf("%s-%d", paradedb.GetStatefulSetName(), i)))
+		}
+	}
+	if paradedb.IsGatewayAPIEnabled() {
+		objects = append(objects, r.buildGatewayRoute(paradedb))
+	}
+	if paradedb.IsConnectionPoolingEnabled() {
+		pooler := r.buildPoolerDeployment(paradedb)
+		pooler.Spec.Template.Annotations = mergeMaps(pooler.Spec.Template.Annotations, credentialsAnnotations)
+		objects = append(objects, pooler)
+		if paradedb.GetPoolerReplicas() > 1 {
+			objects = append(objects, r.buildPoolerPDB(paradedb))
+		}
+	}
+	if paradedb.IsExporterDeployment() {
+		exporter := r.buildExporterDeployment(paradedb)
+		exporter.Spec.Template.Annotations = mergeMaps(exporter.Spec.Template.Annotations, credentialsAnnotations)
+		objects = append(objects, exporter)
+	}
+	if paradedb.IsLogicalBackupEnabled() {
+		cronJob, err := r.buildLogicalBackupCronJob(paradedb)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, cronJob)
+	}
+	if paradedb.GetExternalBootstrap() != nil {
+		objects = append(objects, r.buildImportJob(paradedb, nil))
+	}
+	if !paradedb.IsStandby() {
+		for _, index := range paradedb.GetVectorIndexes() {
+			objects = append(objects, r.buildVectorIndexJob(paradedb, index))
+			if index.RebuildSchedule != "" {
+				objects = append(objects, r.buildVectorReindexCronJob(paradedb, index))
+			}
+		}
+		if len(paradedb.GetMaintenanceTables()) > 0 {
+			if err := validateMaintenance(paradedb); err != nil {
+				return nil, err
+			}
+			objects = append(objects, r.buildMaintenanceCronJob(paradedb))
+		}
+	}
+
+	// Typed objects carry no kind, which readers of the rendered manifests need
+	for _, object := range objects {
+		gvk, err := apiutil.GVKForObject(object, r.Scheme)
+		if err != nil {
+			return nil, err
+		}
+		object.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+	return objects, nil
+}
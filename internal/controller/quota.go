@@ -0,0 +1,195 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// ConditionTypeQuotaExceeded reports an instance held back by the operator's namespace
+// quotas
+const ConditionTypeQuotaExceeded = "QuotaExceeded"
+
+// quotaRecheckInterval is how often an instance over quota is checked again, as deleting
+// or shrinking the other instances of the namespace frees quota
+const quotaRecheckInterval = time.Minute
+
+// NamespaceQuota limits the ParadeDB instances of a namespace. Unset limits are not
+// enforced.
+type NamespaceQuota struct {
+	// MaxInstances is the number of ParadeDB instances the namespace may have
+	MaxInstances *int32 `json:"maxInstances,omitempty"`
+
+	// MaxStorage is the total data and WAL storage, over all replicas, the instances of
+	// the namespace may request
+	MaxStorage *resource.Quantity `json:"maxStorage,omitempty"`
+
+	// MaxReplicas is the number of replicas each instance of the namespace may have
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+}
+
+// OperatorQuotas are the per-namespace limits, read from the file given by
+// --quota-config, that let several tenants share the operator
+type OperatorQuotas struct {
+	// Default applies to every namespace, unless overridden in namespaces
+	Default NamespaceQuota `json:"default,omitempty"`
+
+	// Namespaces override the default limits of individual namespaces. Limits they leave
+	// unset are taken from the default.
+	Namespaces map[string]NamespaceQuota `json:"namespaces,omitempty"`
+}
+
+// LoadOperatorQuotas reads the namespace quotas from a YAML file, typically a mounted
+// ConfigMap key. Unknown fields are rejected so that a typo does not go unnoticed.
+func LoadOperatorQuotas(path string) (*OperatorQuotas, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	quotas := &OperatorQuotas{}
+	if err := yaml.UnmarshalStrict(data, quotas); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return quotas, nil
+}
+
+// getNamespaceQuota returns the limits of a namespace
+func (q *OperatorQuotas) getNamespaceQuota(namespace string) NamespaceQuota {
+	quota := q.Default
+	override, ok := q.Namespaces[namespace]
+	if !ok {
+		return quota
+	}
+	if override.MaxInstances != nil {
+		quota.MaxInstances = override.MaxInstances
+	}
+	if override.MaxStorage != nil {
+		quota.MaxStorage = override.MaxStorage
+	}
+	if override.MaxReplicas != nil {
+		quota.MaxReplicas = override.MaxReplicas
+	}
+	return quota
+}
+
+// getRequestedStorage returns the data and WAL storage the instance requests over all
+// of its replicas
+func getRequestedStorage(paradedb *databasev1alpha1.ParadeDB) resource.Quantity {
+	perReplica := paradedb.Spec.Storage.Size.DeepCopy()
+	if wal := paradedb.Spec.Storage.WalStorage; wal != nil {
+		perReplica.Add(wal.Size)
+	}
+	total := resource.Quantity{Format: perReplica.Format}
+	for range paradedb.GetReplicas() {
+		total.Add(perReplica)
+	}
+	return total
+}
+
+// checkQuota returns why the instance does not fit the quota of its namespace, or an
+// empty string. Instances are admitted in the order they were created, so an instance
+// only counts the instances created before it and a new instance cannot push an existing
+// one over quota.
+func (r *ParadeDBReconciler) checkQuota(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (string, error) {
+	if r.Quotas == nil {
+		return "", nil
+	}
+	quota := r.Quotas.getNamespaceQuota(paradedb.Namespace)
+
+	if quota.MaxReplicas != nil && paradedb.GetReplicas() > *quota.MaxReplicas {
+		return fmt.Sprintf("spec.replicas is %d, but namespace %s allows at most %d replicas per instance",
+			paradedb.GetReplicas(), paradedb.Namespace, *quota.MaxReplicas), nil
+	}
+	if quota.MaxInstances == nil && quota.MaxStorage == nil {
+		return "", nil
+	}
+
+	instances := &databasev1alpha1.ParadeDBList{}
+	if err := r.List(ctx, instances, client.InNamespace(paradedb.Namespace)); err != nil {
+		return "", err
+	}
+	count := int32(1)
+	storage := getRequestedStorage(paradedb)
+	for i := range instances.Items {
+		other := &instances.Items[i]
+		if other.UID == paradedb.UID || other.DeletionTimestamp != nil || !createdBefore(other, paradedb) {
+			continue
+		}
+		count++
+		storage.Add(getRequestedStorage(other))
+	}
+
+	if quota.MaxInstances != nil && count > *quota.MaxInstances {
+		return fmt.Sprintf("namespace %s allows at most %d ParadeDB instances", paradedb.Namespace, *quota.MaxInstances), nil
+	}
+	if quota.MaxStorage != nil && storage.Cmp(*quota.MaxStorage) > 0 {
+		return fmt.Sprintf("instances in namespace %s would request %s of storage, over the quota of %s",
+			paradedb.Namespace, storage.String(), quota.MaxStorage.String()), nil
+	}
+	return "", nil
+}
+
+// handleQuotaExceeded keeps the instance from using more than it does until it fits the
+// quota of its namespace. An instance that was never provisioned is left Pending, and true
+// is returned to stop the reconciliation. One that runs keeps its replicas and storage for
+// this reconcile, and is otherwise updated as usual.
+func (r *ParadeDBReconciler) handleQuotaExceeded(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, message string) (bool, error) {
+	if current := meta.FindStatusCondition(paradedb.Status.Conditions, ConditionTypeQuotaExceeded); current == nil || current.Message != message {
+		r.Recorder.Event(paradedb, corev1.EventTypeWarning, "QuotaExceeded", message)
+	}
+	setCondition(paradedb, ConditionTypeQuotaExceeded, metav1.ConditionTrue, "QuotaExceeded", message)
+
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetStatefulSetName(), Namespace: paradedb.Namespace}, statefulSet)
+	if errors.IsNotFound(err) {
+		paradedb.Status.Phase = databasev1alpha1.ParadeDBPhasePending
+		paradedb.Status.Message = "Quota exceeded: " + message
+		return true, r.Status().Update(ctx, paradedb)
+	} else if err != nil {
+		return false, err
+	}
+
+	// Neither scale up nor request more storage for new volume claims
+	if statefulSet.Spec.Replicas != nil && paradedb.GetReplicas() > *statefulSet.Spec.Replicas {
+		replicas := *statefulSet.Spec.Replicas
+		paradedb.Spec.Replicas = &replicas
+	}
+	for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+		if template.Name != "data" {
+			continue
+		}
+		if size, ok := template.Spec.Resources.Requests[corev1.ResourceStorage]; ok && paradedb.Spec.Storage.Size.Cmp(size) > 0 {
+			paradedb.Spec.Storage.Size = size
+		}
+	}
+	return false, nil
+}
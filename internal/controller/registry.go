@@ -0,0 +1,358 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// dockerHubRegistry serves images without a registry host in their reference
+	dockerHubRegistry = "registry-1.docker.io"
+
+	// registryTimeout bounds each request to a container registry
+	registryTimeout = 10 * time.Second
+)
+
+// manifestMediaTypes are accepted when resolving a tag, so that multi-arch images resolve
+// to the digest of their index rather than of one platform's manifest
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// registryCredentials authenticate against a registry, as read from an image pull secret
+type registryCredentials struct {
+	Username string
+	Password string
+}
+
+// pinImageDigest runs the instance by digest so that a tag moving on does not leave pods
+// on different images. The digest a tag resolved to is kept in status.currentVersion
+// until the image in the spec changes. Instances already running the tag are pinned to
+// the digest their pods run rather than to whatever the tag points at today. Like class
+// defaults, the pinned reference is only set for this reconcile.
+func (r *ParadeDBReconciler) pinImageDigest(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	log := logf.FromContext(ctx)
+
+	image := paradedb.GetImage()
+	if hasDigest(image) {
+		paradedb.Status.Image = image
+		return
+	}
+	if paradedb.Status.Image == image && hasDigest(paradedb.Status.CurrentVersion) {
+		paradedb.Spec.Image = paradedb.Status.CurrentVersion
+		return
+	}
+	if !r.ResolveImageDigests {
+		paradedb.Status.Image = image
+		return
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.Get(ctx, types.NamespacedName{Name: paradedb.GetStatefulSetName(), Namespace: paradedb.Namespace}, statefulSet)
+	if err == nil && getContainerImage(&statefulSet.Spec.Template) == image {
+		digest, err := r.getRunningDigest(ctx, paradedb)
+		if err != nil {
+			log.Error(err, "Failed to read the running image digest, running by tag", "image", image)
+			paradedb.Status.Image = image
+			return
+		}
+		if digest == "" {
+			log.Info("Pods do not agree on an image digest yet, running by tag", "image", image)
+			paradedb.Status.Image = image
+			return
+		}
+		paradedb.Spec.Image = withDigest(image, digest)
+		paradedb.Status.Image = image
+		return
+	}
+
+	credentials, err := r.getRegistryCredentials(ctx, paradedb, imageRegistry(image))
+	if err != nil {
+		log.Error(err, "Failed to read image pull secrets")
+	}
+	digest, err := resolveImageDigest(ctx, image, credentials)
+	if err != nil {
+		// Run by tag rather than block the instance on an unreachable registry
+		log.Error(err, "Failed to resolve image digest, running by tag", "image", image)
+		paradedb.Status.Image = image
+		return
+	}
+
+	paradedb.Spec.Image = withDigest(image, digest)
+	paradedb.Status.Image = image
+}
+
+// getRunningDigest returns the digest all pods of the instance run, or an empty string if
+// a pod has not reported one or the pods run different images
+func (r *ParadeDBReconciler) getRunningDigest(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (string, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(paradedb.Namespace), client.MatchingLabels(r.getSelectorLabels(paradedb))); err != nil {
+		return "", err
+	}
+
+	running := ""
+	for _, pod := range pods.Items {
+		digest := ""
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == "paradedb" {
+				_, digest, _ = strings.Cut(status.ImageID, "@")
+			}
+		}
+		if digest == "" || (running != "" && digest != running) {
+			return "", nil
+		}
+		running = digest
+	}
+	return running, nil
+}
+
+// hasDigest returns true if the image reference is pinned by digest
+func hasDigest(image string) bool {
+	return strings.Contains(image, "@sha256:")
+}
+
+// withDigest adds a digest to an image reference, keeping its tag so that the version
+// stays visible
+func withDigest(image, digest string) string {
+	name, tag := splitImageTag(image)
+	return name + ":" + tag + "@" + digest
+}
+
+// splitImageTag splits an image reference into its name and tag, defaulting to latest
+func splitImageTag(image string) (string, string) {
+	image, _, _ = strings.Cut(image, "@")
+	slash := strings.LastIndex(image, "/")
+	if colon := strings.LastIndex(image, ":"); colon > slash {
+		return image[:colon], image[colon+1:]
+	}
+	return image, "latest"
+}
+
+// withRegistry moves an image to another registry, such as an air-gapped mirror, keeping
+// its repository path and tag
+func withRegistry(image, registry string) string {
+	if registry == "" {
+		return image
+	}
+	_, repository := splitImageName(image)
+	_, tag := splitImageTag(image)
+	return strings.TrimSuffix(registry, "/") + "/" + repository + ":" + tag
+}
+
+// mirrorImage returns one of the operator's built-in default images, pulled from the
+// registry given by --image-registry-override if set
+func (r *ParadeDBReconciler) mirrorImage(image string) string {
+	return withRegistry(image, r.ImageRegistryOverride)
+}
+
+// imageRegistry returns the registry host an image is pulled from
+func imageRegistry(image string) string {
+	registry, _ := splitImageName(image)
+	return registry
+}
+
+// splitImageName splits an image reference into its registry host and repository path,
+// applying Docker Hub's defaults
+func splitImageName(image string) (string, string) {
+	name, _ := splitImageTag(image)
+
+	registry, repository := dockerHubRegistry, name
+	if first, rest, found := strings.Cut(name, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		registry, repository = first, rest
+	}
+	if registry == "docker.io" || registry == "index.docker.io" {
+		registry = dockerHubRegistry
+	}
+	if registry == dockerHubRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+	return registry, repository
+}
+
+// resolveImageDigest asks the registry which manifest the image's tag points at, using
+// the registry's token service if it requires one
+func resolveImageDigest(ctx context.Context, image string, credentials *registryCredentials) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, registryTimeout)
+	defer cancel()
+
+	_, tag := splitImageTag(image)
+	registry, repository := splitImageName(image)
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repository, tag)
+
+	response, err := headManifest(ctx, manifestURL, "")
+	if err != nil {
+		return "", err
+	}
+	if response.StatusCode == http.StatusUnauthorized {
+		token, err := fetchRegistryToken(ctx, response.Header.Get("WWW-Authenticate"), credentials)
+		if err != nil {
+			return "", err
+		}
+		if response, err = headManifest(ctx, manifestURL, "Bearer "+token); err != nil {
+			return "", err
+		}
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned %s for %s", response.Status, image)
+	}
+
+	digest := response.Header.Get("Docker-Content-Digest")
+	if !strings.HasPrefix(digest, "sha256:") {
+		return "", fmt.Errorf("registry returned no digest for %s", image)
+	}
+	return digest, nil
+}
+
+// headManifest requests the manifest headers with the given Authorization header
+func headManifest(ctx context.Context, manifestURL, authorization string) (*http.Response, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, manifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	_ = response.Body.Close()
+	return response, nil
+}
+
+// fetchRegistryToken obtains a pull token from the realm named in a Bearer challenge
+func fetchRegistryToken(ctx context.Context, challenge string, credentials *registryCredentials) (string, error) {
+	params := parseBearerChallenge(challenge)
+	if params["realm"] == "" {
+		return "", fmt.Errorf("unsupported registry authentication challenge %q", challenge)
+	}
+
+	query := url.Values{}
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	if credentials != nil {
+		request.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry token service returned %s", response.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseBearerChallenge parses the parameters of a WWW-Authenticate Bearer challenge
+func parseBearerChallenge(challenge string) map[string]string {
+	params := map[string]string{}
+	rest, found := strings.CutPrefix(challenge, "Bearer ")
+	if !found {
+		return params
+	}
+	for rest != "" {
+		key, value, found := strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if !found {
+			break
+		}
+		value, rest, _ = strings.Cut(strings.TrimPrefix(value, `"`), `"`)
+		params[key] = value
+	}
+	return params
+}
+
+// getRegistryCredentials returns the credentials for the registry from the instance's
+// image pull secrets, or nil if none of them has an entry for it
+func (r *ParadeDBReconciler) getRegistryCredentials(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, registry string) (*registryCredentials, error) {
+	hosts := []string{registry}
+	if registry == dockerHubRegistry {
+		hosts = []string{"https://index.docker.io/v1/", "index.docker.io", "docker.io", registry}
+	}
+
+	for _, ref := range paradedb.Spec.ImagePullSecrets {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: paradedb.Namespace}, secret); err != nil {
+			return nil, err
+		}
+		var config struct {
+			Auths map[string]struct {
+				Username string `json:"username"`
+				Password string `json:"password"`
+				Auth     string `json:"auth"`
+			} `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+			continue
+		}
+		for _, host := range hosts {
+			auth, ok := config.Auths[host]
+			if !ok {
+				continue
+			}
+			if auth.Auth != "" {
+				decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+				if err != nil {
+					return nil, fmt.Errorf("invalid auth in image pull secret %s: %w", ref.Name, err)
+				}
+				auth.Username, auth.Password, _ = strings.Cut(string(decoded), ":")
+			}
+			return &registryCredentials{Username: auth.Username, Password: auth.Password}, nil
+		}
+	}
+	return nil, nil
+}
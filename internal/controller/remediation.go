@@ -0,0 +1,198 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// reconcileRemediation deletes pods that are crash-looping or stuck on an unreachable
+// node so the StatefulSet can recreate them
+func (r *ParadeDBReconciler) reconcileRemediation(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(paradedb.Namespace), client.MatchingLabels(r.getSelectorLabels(paradedb))); err != nil {
+		return err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+
+		stuck, err := r.isPodStuck(ctx, pod, paradedb.GetStuckPodTimeout())
+		if err != nil {
+			return err
+		}
+		if stuck {
+			// The node may only have lost contact and still run the pod, so replacing it
+			// could leave two writers on the data volume. Wait until the Node is deleted
+			// or tainted out of service.
+			down, err := r.isNodeDown(ctx, pod.Spec.NodeName)
+			if err != nil {
+				return err
+			}
+			if !down {
+				log.Info("Pod stuck on a node that is not confirmed down", "pod", pod.Name, "node", pod.Spec.NodeName)
+				message := fmt.Sprintf("Pod %s is stuck on node %s; delete the Node or taint it %s to replace the pod",
+					pod.Name, pod.Spec.NodeName, corev1.TaintNodeOutOfService)
+				r.Recorder.Event(paradedb, corev1.EventTypeWarning, "StuckPodOnNode", message)
+				continue
+			}
+
+			// A pod on a lost node keeps its ReadWriteOnce volumes attached there, which
+			// would stop the replacement pod from starting elsewhere
+			if err := r.detachPodVolumes(ctx, pod); err != nil {
+				return err
+			}
+			if err := r.Delete(ctx, pod, client.GracePeriodSeconds(0)); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			log.Info("Force deleted stuck pod", "pod", pod.Name, "node", pod.Spec.NodeName)
+			message := fmt.Sprintf("Force deleted pod %s stuck on unreachable node %s", pod.Name, pod.Spec.NodeName)
+			r.Recorder.Event(paradedb, corev1.EventTypeWarning, "ForceDeletedPod", message)
+			recordOperation(paradedb, databasev1alpha1.OperationRemediation, databasev1alpha1.OperationSucceeded, message)
+			continue
+		}
+
+		if pod.DeletionTimestamp == nil && isCrashLooping(pod, paradedb.GetCrashLoopRestartThreshold()) {
+			if err := r.Delete(ctx, pod); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			log.Info("Deleted crash-looping pod", "pod", pod.Name)
+			message := fmt.Sprintf("Deleted pod %s after %d restarts in CrashLoopBackOff", pod.Name, paradedb.GetCrashLoopRestartThreshold())
+			r.Recorder.Event(paradedb, corev1.EventTypeWarning, "DeletedCrashLoopingPod", message)
+			recordOperation(paradedb, databasev1alpha1.OperationRemediation, databasev1alpha1.OperationSucceeded, message)
+		}
+	}
+
+	return nil
+}
+
+// isPodStuck returns true if the pod has been in the Unknown phase, or terminating on a
+// node that is not ready, for longer than the timeout
+func (r *ParadeDBReconciler) isPodStuck(ctx context.Context, pod *corev1.Pod, timeout time.Duration) (bool, error) {
+	if pod.Status.Phase == corev1.PodUnknown {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady {
+				return time.Since(condition.LastTransitionTime.Time) > timeout, nil
+			}
+		}
+		return false, nil
+	}
+
+	if pod.DeletionTimestamp == nil || time.Since(pod.DeletionTimestamp.Time) <= timeout || pod.Spec.NodeName == "" {
+		return false, nil
+	}
+
+	node := &corev1.Node{}
+	err := r.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node)
+	if errors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status != corev1.ConditionTrue, nil
+		}
+	}
+	return true, nil
+}
+
+// isNodeDown returns true if the node no longer exists or has been tainted out of
+// service, meaning nothing can run on it anymore
+func (r *ParadeDBReconciler) isNodeDown(ctx context.Context, nodeName string) (bool, error) {
+	if nodeName == "" {
+		return true, nil
+	}
+
+	node := &corev1.Node{}
+	err := r.Get(ctx, types.NamespacedName{Name: nodeName}, node)
+	if errors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Key == corev1.TaintNodeOutOfService {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// detachPodVolumes deletes the VolumeAttachments that bind the pod's persistent volumes
+// to its node
+func (r *ParadeDBReconciler) detachPodVolumes(ctx context.Context, pod *corev1.Pod) error {
+	volumeNames := map[string]bool{}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc := &corev1.PersistentVolumeClaim{}
+		err := r.Get(ctx, types.NamespacedName{Name: volume.PersistentVolumeClaim.ClaimName, Namespace: pod.Namespace}, pvc)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if pvc.Spec.VolumeName != "" {
+			volumeNames[pvc.Spec.VolumeName] = true
+		}
+	}
+	if len(volumeNames) == 0 {
+		return nil
+	}
+
+	attachments := &storagev1.VolumeAttachmentList{}
+	if err := r.List(ctx, attachments); err != nil {
+		return err
+	}
+	for i := range attachments.Items {
+		attachment := &attachments.Items[i]
+		source := attachment.Spec.Source.PersistentVolumeName
+		if attachment.Spec.NodeName != pod.Spec.NodeName || source == nil || !volumeNames[*source] {
+			continue
+		}
+		if err := r.Delete(ctx, attachment); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// isCrashLooping returns true if a container of the pod is in CrashLoopBackOff and has
+// restarted at least threshold times
+func isCrashLooping(pod *corev1.Pod, threshold int32) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" && status.RestartCount >= threshold {
+			return true
+		}
+	}
+	return false
+}
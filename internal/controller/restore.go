@@ -0,0 +1,334 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// validateRestore checks that spec.restore selects something to restore from a backup
+// target the instance can read
+func validateRestore(paradedb *databasev1alpha1.ParadeDB) error {
+	restore := paradedb.Spec.Restore
+	if restore == nil {
+		return nil
+	}
+
+	backup := paradedb.Spec.Backup
+	if backup == nil || (backup.S3 == nil && backup.PVC == nil) {
+		return errors.New("spec.restore reads logical backups from spec.backup.s3 or spec.backup.pvc, but neither is set")
+	}
+	if backup.PVC != nil && paradedb.GetRestoreSourceInstance() != paradedb.Name {
+		return fmt.Errorf("spec.restore.source.instance %q can only be read from S3; backups on a PVC are only restored into the instance that wrote them",
+			restore.Source.Instance)
+	}
+	if backup.PVC == nil && !backup.S3.ServiceAccountAuth && backup.S3.SecretRef.Name == "" {
+		return errors.New("spec.backup.s3 needs a secretRef or serviceAccountAuth")
+	}
+	if len(restore.Target.Databases) == 0 && len(restore.Target.Tables) == 0 {
+		return errors.New("spec.restore.target needs at least one database or table")
+	}
+	return nil
+}
+
+// getRestoreDatabases returns every database a backup file is needed for
+func getRestoreDatabases(restore *databasev1alpha1.LogicalRestoreSpec) []string {
+	var databases []string
+	seen := map[string]bool{}
+	for _, database := range restore.Target.Databases {
+		if !seen[database] {
+			seen[database] = true
+			databases = append(databases, database)
+		}
+	}
+	for _, table := range restore.Target.Tables {
+		if !seen[table.Database] {
+			seen[table.Database] = true
+			databases = append(databases, table.Database)
+		}
+	}
+	return databases
+}
+
+// buildRestoreBackupDir returns the shell statement that sets dir to the backup directory,
+// the given one or else the most recent one
+func buildRestoreBackupDir(restore *databasev1alpha1.LogicalRestoreSpec) string {
+	if restore.Source.Backup != "" {
+		return "dir=" + backupMountPath + "/logical/" + shellQuote(restore.Source.Backup) + "\n"
+	}
+	return "dir=$(ls -1d " + backupMountPath + "/logical/*/ | sort | tail -n 1)\n"
+}
+
+// restoreTableListProgram is the awk program that selects the entries of a table from a
+// backup's table of contents, as listed by pg_restore -l -v: the table and its data, and
+// the indexes, constraints, triggers, column defaults and sequences that depend on it,
+// which pg_restore -t leaves out.
+const restoreTableListProgram = `
+BEGIN { nkinds = split("TABLE DATA,FK CONSTRAINT,SEQUENCE OWNED BY,SEQUENCE SET,CONSTRAINT,INDEX,TRIGGER,DEFAULT,SEQUENCE,TABLE", kinds, ",") }
+/^[0-9]+;/ {
+  id = $1 + 0; ids[++n] = id; line[id] = $0
+  rest = $0; sub(/^[0-9]+; [0-9]+ [0-9]+ /, "", rest)
+  for (k = 1; k <= nkinds; k++) if (index(rest, kinds[k] " ") == 1) { kind[id] = kinds[k]; break }
+  if (index(rest, "TABLE " schema " " table " ") == 1) { sel[id] = 1; found = 1 }
+  if (index(rest, "TABLE DATA " schema " " table " ") == 1) sel[id] = 1
+  next
+}
+/^;[ 	]*depends on:/ { for (i = 4; i <= NF; i++) deps[id] = deps[id] " " $i }
+END {
+  if (!found) { print "Table " schema "." table " not found in the backup" > "/dev/stderr"; exit 1 }
+  do {
+    changed = 0
+    for (j = 1; j <= n; j++) {
+      id = ids[j]; m = split(deps[id], d, " ")
+      for (i = 1; i <= m; i++) {
+        if (!sel[id] && sel[d[i]] && kind[id] != "" && kind[id] !~ /^TABLE/) { sel[id] = 1; changed = 1 }
+        if (sel[id] && kind[id] == "SEQUENCE OWNED BY" && kind[d[i]] == "SEQUENCE" && !sel[d[i]]) { sel[d[i]] = 1; changed = 1 }
+      }
+    }
+  } while (changed)
+  for (j = 1; j <= n; j++) if (sel[ids[j]]) print line[ids[j]]
+}`
+
+// buildRestoreScript returns the shell script that pg_restores the target databases, which
+// replaces the objects they hold, and then the target tables, which must not exist. A
+// backup is a custom format file or, when dumped with parallel workers, a directory.
+func buildRestoreScript(paradedb *databasev1alpha1.ParadeDB) string {
+	restore := paradedb.Spec.Restore
+
+	jobs := ""
+	if parallelism := paradedb.GetBackupParallelism(); parallelism > 1 {
+		jobs = fmt.Sprintf(" -j %d", parallelism)
+	}
+
+	var script strings.Builder
+	script.WriteString("set -euo pipefail\n")
+	script.WriteString("until pg_isready -q -d postgres; do sleep 2; done\n")
+	script.WriteString(buildRestoreBackupDir(restore))
+	script.WriteString("src() { if [ -d \"$dir/$1\" ]; then echo \"$dir/$1\"; else echo \"$dir/$1.dump\"; fi; }\n")
+	if len(restore.Target.Tables) > 0 {
+		fmt.Fprintf(&script, "select_table() { awk -v schema=\"$1\" -v table=\"$2\" %s; }\n", shellQuote(restoreTableListProgram))
+	}
+	for _, database := range restore.Target.Databases {
+		fmt.Fprintf(&script, "psql -d postgres -tAc %s | grep -q 1 || createdb %s\n",
+			shellQuote("SELECT 1 FROM pg_database WHERE datname = "+pq.QuoteLiteral(database)), shellQuote(database))
+		fmt.Fprintf(&script, "pg_restore --clean --if-exists --exit-on-error%s -d %s \"$(src %s)\"\n",
+			jobs, shellQuote(database), shellQuote(database))
+	}
+	for _, table := range restore.Target.Tables {
+		schema := table.Schema
+		if schema == "" {
+			schema = "public"
+		}
+		// Restoring over an existing table would fail halfway on its first object, and a
+		// renamed one still holds the names of its indexes and sequences
+		exists := fmt.Sprintf("SELECT 1 FROM pg_tables WHERE schemaname = %s AND tablename = %s",
+			pq.QuoteLiteral(schema), pq.QuoteLiteral(table.Name))
+		fmt.Fprintf(&script, "if psql -d %s -tAc %s | grep -q 1; then echo %s >&2; exit 1; fi\n",
+			shellQuote(table.Database), shellQuote(exists),
+			shellQuote(fmt.Sprintf("Table %s.%s exists in database %s; drop it to restore it", schema, table.Name, table.Database)))
+		fmt.Fprintf(&script, "list=$(pg_restore -l -v \"$(src %s)\" | select_table %s %s)\n",
+			shellQuote(table.Database), shellQuote(schema), shellQuote(table.Name))
+		fmt.Fprintf(&script, "pg_restore --exit-on-error -d %s -L <(echo \"$list\") \"$(src %s)\"\n",
+			shellQuote(table.Database), shellQuote(table.Database))
+	}
+	return script.String()
+}
+
+// buildRestoreDownloadContainer returns the init container that copies the target
+// databases' files of the backup from S3 into the scratch volume
+func (r *ParadeDBReconciler) buildRestoreDownloadContainer(paradedb *databasev1alpha1.ParadeDB) corev1.Container {
+	restore := paradedb.Spec.Restore
+	s3 := paradedb.Spec.Backup.S3
+	source := getBackupPrefix(paradedb, paradedb.GetRestoreSourceInstance()) + "/logical"
+
+	secretName := s3.SecretRef.Name
+	if s3.ServiceAccountAuth {
+		secretName = ""
+	}
+
+	var script strings.Builder
+	script.WriteString("set -eu\n")
+	fmt.Fprintf(&script, "source=%s\n", shellQuote(source))
+	if restore.Source.Backup != "" {
+		fmt.Fprintf(&script, "backup=%s\n", shellQuote(restore.Source.Backup))
+	} else {
+		script.WriteString("backup=$(aws s3 ls --endpoint-url \"$AWS_ENDPOINT_URL\" \"$source/\" | awk '$1 == \"PRE\" {print $2}' | sort | tail -n 1 | tr -d /)\n")
+		script.WriteString("[ -n \"$backup\" ] || { echo \"No logical backups found in $source\" >&2; exit 1; }\n")
+	}
+	script.WriteString("aws s3 cp --recursive --endpoint-url \"$AWS_ENDPOINT_URL\" --exclude '*'")
+	for _, database := range getRestoreDatabases(restore) {
+		fmt.Fprintf(&script, " --include %s --include %s", shellQuote(database+".dump"), shellQuote(database+"/*"))
+	}
+	fmt.Fprintf(&script, " \"$source/$backup\" %s/logical/\"$backup\"\n", backupMountPath)
+
+	return corev1.Container{
+		Name:         "download",
+		Image:        r.getBackupImage(),
+		Command:      []string{"sh", "-c", script.String()},
+		Env:          append(buildAWSEnv(s3.Region, secretName), corev1.EnvVar{Name: "AWS_ENDPOINT_URL", Value: s3.Endpoint}),
+		VolumeMounts: []corev1.VolumeMount{{Name: "backup", MountPath: backupMountPath}},
+	}
+}
+
+// getRestoreJobName returns the name of the Job that runs the current restore spec
+func getRestoreJobName(paradedb *databasev1alpha1.ParadeDB) string {
+	return fmt.Sprintf("%s-restore-%s", paradedb.Name,
+		shortHash(paradedb.GetRestoreSourceInstance(), buildRestoreScript(paradedb))[:10])
+}
+
+// buildRestoreJob returns the Job that restores from the backup PVC directly, or from a
+// scratch volume the backup is first downloaded to from S3
+func (r *ParadeDBReconciler) buildRestoreJob(paradedb *databasev1alpha1.ParadeDB) *batchv1.Job {
+	backoffLimit := int32(2)
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy:    corev1.RestartPolicyNever,
+		ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+		Affinity:         withArchitectureAffinity(paradedb, nil),
+		Containers: []corev1.Container{{
+			Name:            "pg-restore",
+			Image:           paradedb.GetImage(),
+			ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+			Command:         []string{"bash", "-c", buildRestoreScript(paradedb)},
+			Env:             buildClientEnv(paradedb),
+			VolumeMounts:    []corev1.VolumeMount{{Name: "backup", MountPath: backupMountPath}},
+		}},
+	}
+	applyServiceAccount(paradedb, &podSpec)
+	applyDNS(paradedb, &podSpec)
+	if paradedb.Spec.Backup.PVC != nil {
+		podSpec.Volumes = []corev1.Volume{{
+			Name: "backup",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: paradedb.GetBackupPVCName(), ReadOnly: true},
+			},
+		}}
+	} else {
+		if paradedb.IsS3ServiceAccountAuthEnabled() {
+			podSpec.ServiceAccountName = paradedb.GetBackupServiceAccountName()
+		}
+		podSpec.InitContainers = []corev1.Container{r.buildRestoreDownloadContainer(paradedb)}
+		podSpec.Volumes = []corev1.Volume{{
+			Name:         "backup",
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		}}
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getRestoreJobName(paradedb),
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"app.kubernetes.io/component": "restore"},
+					Annotations: buildJobServiceMeshAnnotations(paradedb),
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+// reconcileRestore starts the restore Job once the database is reachable and reports its
+// outcome. A finished restore is not repeated until the restore spec changes.
+func (r *ParadeDBReconciler) reconcileRestore(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	if paradedb.IsStandby() {
+		return nil
+	}
+
+	name := getRestoreJobName(paradedb)
+	status := paradedb.Status.Restore
+	if status != nil && status.JobName == name && status.Phase != databasev1alpha1.RestorePhaseRunning {
+		return nil
+	}
+
+	job := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: paradedb.Namespace}, job)
+	if apierrors.IsNotFound(err) {
+		if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+			return nil
+		}
+		job = r.buildRestoreJob(paradedb)
+		if err := controllerutil.SetControllerReference(paradedb, job, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, job); err != nil {
+			return err
+		}
+
+		log.Info("Started restore from logical backup", "job", job.Name)
+		now := metav1.Now()
+		paradedb.Status.Restore = &databasev1alpha1.RestoreStatus{
+			JobName:   job.Name,
+			Phase:     databasev1alpha1.RestorePhaseRunning,
+			Message:   "Restoring from the logical backups of " + paradedb.GetRestoreSourceInstance(),
+			StartTime: &now,
+		}
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "RestoreStarted",
+			fmt.Sprintf("Restoring from logical backup in Job %s", job.Name))
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if status == nil || status.JobName != name {
+		status = &databasev1alpha1.RestoreStatus{JobName: name, StartTime: job.Status.StartTime}
+		paradedb.Status.Restore = status
+	}
+	status.Phase = databasev1alpha1.RestorePhaseRunning
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobFailed:
+			status.Phase = databasev1alpha1.RestorePhaseFailed
+			status.Message = fmt.Sprintf("Restore job %s failed: %s", job.Name, condition.Message)
+			status.CompletionTime = job.Status.CompletionTime
+			r.Recorder.Event(paradedb, corev1.EventTypeWarning, "RestoreFailed", status.Message)
+			recordOperation(paradedb, databasev1alpha1.OperationRestore, databasev1alpha1.OperationFailed, status.Message)
+		case batchv1.JobComplete:
+			status.Phase = databasev1alpha1.RestorePhaseCompleted
+			status.Message = "Restored from the logical backups of " + paradedb.GetRestoreSourceInstance()
+			status.CompletionTime = job.Status.CompletionTime
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "RestoreCompleted", status.Message)
+			recordOperation(paradedb, databasev1alpha1.OperationRestore, databasev1alpha1.OperationSucceeded, status.Message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// ConditionTypeOperationBlocked reports whether the safeguards hold back a pod rollout
+	ConditionTypeOperationBlocked = "OperationBlocked"
+
+	// approveMajorUpgradeAnnotation on a ParadeDB approves a change to the postgresVersion
+	// it is set to
+	approveMajorUpgradeAnnotation = "database.paradedb.io/approve-major-upgrade"
+)
+
+// checkSafeguards returns the reason and message if spec.safeguards hold back rolling
+// the pods from the before template to the after template, or an empty reason if the
+// rollout may go ahead
+func checkSafeguards(paradedb *databasev1alpha1.ParadeDB, before, after *corev1.PodTemplateSpec, now time.Time) (string, string) {
+	safeguards := paradedb.Spec.Safeguards
+	if safeguards == nil || equality.Semantic.DeepEqual(before, after) {
+		return "", ""
+	}
+	current, target := paradedb.Status.PostgresVersion, paradedb.Spec.PostgresVersion
+	if safeguards.RequireMajorUpgradeApproval && current != "" && current != target &&
+		paradedb.Annotations[approveMajorUpgradeAnnotation] != target {
+		return "MajorUpgradeNotApproved", fmt.Sprintf("Changing postgresVersion from %s to %s needs the %s=%s annotation",
+			current, target, approveMajorUpgradeAnnotation, target)
+	}
+
+	if maxOperations, period := paradedb.GetDisruptionBudget(); maxOperations > 0 {
+		var started int32
+		var oldest time.Time
+		for _, record := range paradedb.Status.OperationsHistory {
+			if !isDisruptiveOperation(record.Type) || now.Sub(record.StartTime.Time) >= period {
+				continue
+			}
+			if started == 0 || record.StartTime.Time.Before(oldest) {
+				oldest = record.StartTime.Time
+			}
+			started++
+		}
+		if started >= maxOperations {
+			return "DisruptionBudgetExhausted", fmt.Sprintf("%d rollouts started in the last %s; the next may start at %s",
+				started, period, oldest.Add(period).UTC().Format(time.RFC3339))
+		}
+	}
+	return "", ""
+}
+
+// isDisruptiveOperation returns true for operations that roll the pods and count against
+// the disruption budget
+func isDisruptiveOperation(operationType databasev1alpha1.OperationType) bool {
+	switch operationType {
+	case databasev1alpha1.OperationUpgrade, databasev1alpha1.OperationRestart, databasev1alpha1.OperationRollout:
+		return true
+	}
+	return false
+}
+
+// setOperationBlocked reports the outcome of checkSafeguards in the OperationBlocked
+// condition, emitting an Event when a rollout is first held back
+func (r *ParadeDBReconciler) setOperationBlocked(paradedb *databasev1alpha1.ParadeDB, reason, message string) {
+	if paradedb.Spec.Safeguards == nil {
+		meta.RemoveStatusCondition(&paradedb.Status.Conditions, ConditionTypeOperationBlocked)
+		return
+	}
+	if reason == "" {
+		setCondition(paradedb, ConditionTypeOperationBlocked, metav1.ConditionFalse, "Allowed", "No rollout is held back")
+		return
+	}
+	previous := meta.FindStatusCondition(paradedb.Status.Conditions, ConditionTypeOperationBlocked)
+	if previous == nil || previous.Status != metav1.ConditionTrue || previous.Reason != reason {
+		r.Recorder.Event(paradedb, corev1.EventTypeWarning, reason, message)
+	}
+	setCondition(paradedb, ConditionTypeOperationBlocked, metav1.ConditionTrue, reason, message)
+}
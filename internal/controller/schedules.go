@@ -0,0 +1,220 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// cronMacros are the shorthands accepted in place of a five-field cron expression
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSchedule is a parsed cron expression, with one bit set per matching value
+type cronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	// A day matches either day field when both are restricted, as in cron
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// parseCronSchedule parses a five-field cron expression with numeric values
+func parseCronSchedule(expr string) (*cronSchedule, error) {
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have five fields", expr)
+	}
+
+	schedule := &cronSchedule{
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	if schedule.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfMonth, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfWeek, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	// Sunday is both 0 and 7
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+	return schedule, nil
+}
+
+// parseCronField parses a comma-separated list of values, ranges and steps
+func parseCronField(field string, minValue, maxValue int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		valueRange, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			valueRange = part[:i]
+			if step, err = strconv.Atoi(part[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in cron field %q", field)
+			}
+		}
+
+		low, high := minValue, maxValue
+		if valueRange != "*" {
+			bounds := strings.SplitN(valueRange, "-", 2)
+			var err error
+			if low, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in cron field %q", field)
+			}
+			switch {
+			case len(bounds) == 2:
+				if high, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value in cron field %q", field)
+				}
+			case step == 1:
+				high = low
+			}
+		}
+		if low < minValue || high > maxValue || low > high {
+			return 0, fmt.Errorf("cron field %q is outside %d-%d", field, minValue, maxValue)
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+// matchesDay returns true if the schedule fires on the day of t
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	if s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dayOfMonth := s.dayOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := s.dayOfWeek&(1<<int(t.Weekday())) != 0
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// lastFired returns the most recent time at or before t that the schedule fired, looking
+// back at most a year
+func (s *cronSchedule) lastFired(t time.Time) (time.Time, bool) {
+	t = t.UTC().Truncate(time.Minute)
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	for days := 0; days <= 366; days++ {
+		day := today.AddDate(0, 0, -days)
+		if !s.matchesDay(day) {
+			continue
+		}
+		for hour := 23; hour >= 0; hour-- {
+			if s.hour&(1<<hour) == 0 {
+				continue
+			}
+			for minute := 59; minute >= 0; minute-- {
+				if s.minute&(1<<minute) == 0 {
+					continue
+				}
+				fired := day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+				if !fired.After(t) {
+					return fired, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// findActiveSchedule returns the schedule that fired most recently, or nil if none has
+func findActiveSchedule(schedules []databasev1alpha1.ScalingScheduleSpec, now time.Time) (*databasev1alpha1.ScalingScheduleSpec, error) {
+	var active *databasev1alpha1.ScalingScheduleSpec
+	var activeSince time.Time
+	for i := range schedules {
+		cron, err := parseCronSchedule(schedules[i].Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", schedules[i].Name, err)
+		}
+		fired, ok := cron.lastFired(now)
+		if ok && (active == nil || !fired.Before(activeSince)) {
+			active, activeSince = &schedules[i], fired
+		}
+	}
+	return active, nil
+}
+
+// applySchedules overrides the replicas and resources in the spec with those of the
+// schedule in effect. The StatefulSet then rolls the pods per its update strategy.
+func (r *ParadeDBReconciler) applySchedules(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	active, err := findActiveSchedule(paradedb.Spec.Schedules, time.Now())
+	if err != nil {
+		return err
+	}
+
+	name := ""
+	if active != nil {
+		name = active.Name
+		if active.Replicas != nil {
+			replicas := *active.Replicas
+			paradedb.Spec.Replicas = &replicas
+		}
+		if active.Resources != nil {
+			paradedb.Spec.Resources = *active.Resources.DeepCopy()
+		}
+	}
+
+	if name != paradedb.Status.ActiveSchedule {
+		log.Info("Switching schedule", "from", paradedb.Status.ActiveSchedule, "to", name)
+		if name != "" {
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "ScheduleApplied",
+				fmt.Sprintf("Scaling for schedule %s", name))
+		} else {
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "ScheduleApplied", "Scaling back to the spec")
+		}
+		paradedb.Status.ActiveSchedule = name
+	}
+	return nil
+}
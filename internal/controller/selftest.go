@@ -0,0 +1,238 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// selfTestAnnotation requests a self-test of the instance. Each new value, such as a
+	// timestamp, runs the checks once more.
+	selfTestAnnotation = "database.paradedb.io/self-test"
+
+	// selfTestTable is created and dropped again in a rolled back transaction
+	selfTestTable = "paradedb_operator_self_test"
+)
+
+// runSelfTest runs the self-test requested with the self-test annotation, unless it
+// already ran for the annotation's value, and records the result in the status
+func (r *ParadeDBReconciler) runSelfTest(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	log := logf.FromContext(ctx)
+
+	request := paradedb.Annotations[selfTestAnnotation]
+	if request == "" || (paradedb.Status.SelfTest != nil && paradedb.Status.SelfTest.Request == request) {
+		return
+	}
+
+	var checks []databasev1alpha1.SelfTestCheck
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		checks = append(checks, failedCheck("service", fmt.Errorf("failed to read credentials: %w", err)))
+	} else {
+		checks = runServiceChecks(ctx, paradedb, username, password)
+		if paradedb.IsConnectionPoolingEnabled() {
+			checks = append(checks, runPoolerCheck(ctx, paradedb, username, password))
+		}
+	}
+
+	passed := true
+	var failures []string
+	for _, check := range checks {
+		if !check.Passed {
+			passed = false
+			failures = append(failures, check.Name+": "+check.Message)
+		}
+	}
+	paradedb.Status.SelfTest = &databasev1alpha1.SelfTestStatus{
+		Request: request,
+		Time:    metav1.Now(),
+		Passed:  passed,
+		Checks:  checks,
+	}
+
+	if passed {
+		log.Info("Self-test passed", "checks", len(checks))
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "SelfTestPassed", fmt.Sprintf("All %d self-test checks passed", len(checks)))
+	} else {
+		log.Info("Self-test failed", "failures", failures)
+		r.Recorder.Event(paradedb, corev1.EventTypeWarning, "SelfTestFailed", "Self-test failed: "+strings.Join(failures, "; "))
+	}
+}
+
+// runServiceChecks connects through the primary Service, verifies TLS when it is
+// enabled and, on a primary, writes, reads and searches a test table
+func runServiceChecks(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, username, password string) []databasev1alpha1.SelfTestCheck {
+	var checks []databasev1alpha1.SelfTestCheck
+	err := withDatabase(ctx, buildConnectionURL(paradedb, username, password), func(ctx context.Context, db *sql.DB) error {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = conn.Close() }()
+
+		var version string
+		if err := conn.QueryRowContext(ctx, "SHOW server_version").Scan(&version); err != nil {
+			return err
+		}
+		checks = append(checks, passedCheck("service", fmt.Sprintf("Connected to PostgreSQL %s through %s", version, paradedb.GetServiceName())))
+
+		if getSSLMode(paradedb) == "require" {
+			checks = append(checks, runTLSCheck(ctx, conn))
+		}
+		if !paradedb.IsStandby() {
+			checks = append(checks, runWriteChecks(ctx, paradedb, conn)...)
+		}
+		return nil
+	})
+	if err != nil {
+		checks = append(checks, failedCheck("service", err))
+	}
+	return checks
+}
+
+// runTLSCheck verifies that the connection is encrypted
+func runTLSCheck(ctx context.Context, conn *sql.Conn) databasev1alpha1.SelfTestCheck {
+	var ssl bool
+	var protocol sql.NullString
+	err := conn.QueryRowContext(ctx, "SELECT ssl, version FROM pg_stat_ssl WHERE pid = pg_backend_pid()").Scan(&ssl, &protocol)
+	if err != nil {
+		return failedCheck("tls", err)
+	}
+	if !ssl {
+		return failedCheck("tls", fmt.Errorf("the connection is not encrypted"))
+	}
+	return passedCheck("tls", "Connection encrypted with "+protocol.String)
+}
+
+// runWriteChecks writes and reads a test table, then runs a BM25 search and a vector
+// query on it as far as the extensions are enabled. Everything is rolled back, so the
+// checks leave nothing behind.
+func runWriteChecks(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, conn *sql.Conn) []databasev1alpha1.SelfTestCheck {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return []databasev1alpha1.SelfTestCheck{failedCheck("readWrite", err)}
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	extensions := paradedb.Spec.Extensions
+	columns, rows := "id integer PRIMARY KEY, body text NOT NULL", "(1, 'wireless keyboard'), (2, 'running shoes'), (3, 'coffee mug')"
+	if extensions.PgVector {
+		columns += ", embedding vector(3)"
+		rows = "(1, 'wireless keyboard', '[1,0,0]'), (2, 'running shoes', '[0,1,0]'), (3, 'coffee mug', '[0,0,1]')"
+	}
+	var count int
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (%s)", selfTestTable, columns)); err != nil {
+		return []databasev1alpha1.SelfTestCheck{failedCheck("readWrite", err)}
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s VALUES %s", selfTestTable, rows)); err != nil {
+		return []databasev1alpha1.SelfTestCheck{failedCheck("readWrite", err)}
+	}
+	if err := tx.QueryRowContext(ctx, "SELECT count(*) FROM "+selfTestTable).Scan(&count); err != nil {
+		return []databasev1alpha1.SelfTestCheck{failedCheck("readWrite", err)}
+	}
+	if count != 3 {
+		return []databasev1alpha1.SelfTestCheck{failedCheck("readWrite", fmt.Errorf("read %d of 3 written rows", count))}
+	}
+	checks := []databasev1alpha1.SelfTestCheck{passedCheck("readWrite", "Wrote and read 3 rows")}
+
+	// A failed search aborts the transaction; roll back to the savepoint so the other
+	// search still runs
+	search := func(name, savepoint string, statements []string, query string, want int) {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+			checks = append(checks, failedCheck(name, err))
+			return
+		}
+		var id int
+		for _, statement := range statements {
+			if _, err = tx.ExecContext(ctx, statement); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = tx.QueryRowContext(ctx, query).Scan(&id)
+		}
+		if err == nil && id != want {
+			err = fmt.Errorf("returned row %d instead of %d", id, want)
+		}
+		if err != nil {
+			checks = append(checks, failedCheck(name, err))
+			_, _ = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint)
+			return
+		}
+		checks = append(checks, passedCheck(name, fmt.Sprintf("Found row %d", id)))
+	}
+	if extensions.PgSearch {
+		search("bm25Search", "bm25",
+			[]string{fmt.Sprintf("CREATE INDEX %[1]s_bm25 ON %[1]s USING bm25 (id, body) WITH (key_field = 'id')", selfTestTable)},
+			fmt.Sprintf("SELECT id FROM %s WHERE body @@@ 'keyboard'", selfTestTable), 1)
+	}
+	if extensions.PgVector {
+		search("vectorSearch", "vector", nil,
+			fmt.Sprintf("SELECT id FROM %s ORDER BY embedding <-> '[0,1,0]' LIMIT 1", selfTestTable), 2)
+	}
+	return checks
+}
+
+// runPoolerCheck connects through the PgBouncer Service
+func runPoolerCheck(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, username, password string) databasev1alpha1.SelfTestCheck {
+	err := withDatabase(ctx, buildPoolerURL(paradedb, username, password), func(ctx context.Context, db *sql.DB) error {
+		var one int
+		return db.QueryRowContext(ctx, "SELECT 1").Scan(&one)
+	})
+	if err != nil {
+		return failedCheck("pooler", err)
+	}
+	return passedCheck("pooler", "Connected through "+paradedb.GetPoolerServiceName())
+}
+
+// buildPoolerURL returns the URL of the application database behind the PgBouncer
+// Service, which does not terminate TLS
+func buildPoolerURL(paradedb *databasev1alpha1.ParadeDB, username, password string) string {
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(username, password),
+		Host:   net.JoinHostPort(fmt.Sprintf("%s.%s.svc", paradedb.GetPoolerServiceName(), paradedb.Namespace), "5432"),
+		Path:   "/" + paradedb.Spec.Auth.Database,
+		RawQuery: url.Values{
+			"sslmode":         {"disable"},
+			"connect_timeout": {fmt.Sprintf("%d", int(databaseConnectTimeout.Seconds()))},
+		}.Encode(),
+	}
+	return u.String()
+}
+
+// passedCheck returns a passed self-test check
+func passedCheck(name, message string) databasev1alpha1.SelfTestCheck {
+	return databasev1alpha1.SelfTestCheck{Name: name, Passed: true, Message: message}
+}
+
+// failedCheck returns a failed self-test check
+func failedCheck(name string, err error) databasev1alpha1.SelfTestCheck {
+	return databasev1alpha1.SelfTestCheck{Name: name, Message: err.Error()}
+}
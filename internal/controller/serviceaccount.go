@@ -0,0 +1,89 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// reconcileServiceAccount creates or updates the ServiceAccount the instance's pods run
+// as, unless spec.serviceAccount names an existing one
+func (r *ParadeDBReconciler) reconcileServiceAccount(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	if !paradedb.IsServiceAccountManaged() {
+		return nil
+	}
+
+	var annotations map[string]string
+	if paradedb.Spec.ServiceAccount != nil {
+		annotations = paradedb.Spec.ServiceAccount.Annotations
+	}
+	return r.reconcileManagedServiceAccount(ctx, paradedb, paradedb.GetServiceAccountName(), annotations)
+}
+
+// reconcileManagedServiceAccount creates the named ServiceAccount or adds the given
+// annotations to it
+func (r *ParadeDBReconciler) reconcileManagedServiceAccount(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, name string, annotations map[string]string) error {
+	serviceAccount := &corev1.ServiceAccount{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: paradedb.Namespace}, serviceAccount)
+	if err != nil && apierrors.IsNotFound(err) {
+		serviceAccount = r.buildServiceAccount(paradedb, name, annotations)
+
+		if err := controllerutil.SetControllerReference(paradedb, serviceAccount, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, serviceAccount)
+	} else if err != nil {
+		return err
+	}
+
+	// Keep the workload identity annotations in sync without dropping ones added by others
+	merged := mergeMaps(serviceAccount.Annotations, annotations)
+	if !maps.Equal(merged, serviceAccount.Annotations) {
+		serviceAccount.Annotations = merged
+		return r.Update(ctx, serviceAccount)
+	}
+	return nil
+}
+
+// buildServiceAccount creates a ServiceAccount spec
+func (r *ParadeDBReconciler) buildServiceAccount(paradedb *databasev1alpha1.ParadeDB, name string, annotations map[string]string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   paradedb.Namespace,
+			Labels:      r.getLabels(paradedb),
+			Annotations: annotations,
+		},
+	}
+}
+
+// applyServiceAccount runs the pod as the instance's ServiceAccount, without its API
+// token unless asked for
+func applyServiceAccount(paradedb *databasev1alpha1.ParadeDB, podSpec *corev1.PodSpec) {
+	automountToken := paradedb.GetAutomountServiceAccountToken()
+	podSpec.ServiceAccountName = paradedb.GetServiceAccountName()
+	podSpec.AutomountServiceAccountToken = &automountToken
+}
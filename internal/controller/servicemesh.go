@@ -0,0 +1,87 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strconv"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	istioExcludeInboundPortsAnnotation  = "traffic.sidecar.istio.io/excludeInboundPorts"
+	istioExcludeOutboundPortsAnnotation = "traffic.sidecar.istio.io/excludeOutboundPorts"
+	istioProxyConfigAnnotation          = "proxy.istio.io/config"
+	istioInjectAnnotation               = "sidecar.istio.io/inject"
+
+	linkerdSkipInboundPortsAnnotation  = "config.linkerd.io/skip-inbound-ports"
+	linkerdSkipOutboundPortsAnnotation = "config.linkerd.io/skip-outbound-ports"
+	linkerdProxyAwaitAnnotation        = "config.linkerd.io/proxy-await"
+	linkerdInjectAnnotation            = "linkerd.io/inject"
+)
+
+// buildServiceMeshAnnotations returns the pod annotations that adapt a long-running pod
+// to the service mesh. In Bypass mode the pod's listening port (inboundPort, 0 for none)
+// and the database port it connects to are excluded from sidecar interception.
+func buildServiceMeshAnnotations(paradedb *databasev1alpha1.ParadeDB, inboundPort int32) map[string]string {
+	mesh := paradedb.Spec.ServiceMesh
+	if mesh == nil {
+		return nil
+	}
+
+	bypass := !paradedb.IsMeshEncrypted()
+	outbound := strconv.Itoa(int(paradedb.GetPort()))
+	annotations := map[string]string{}
+	switch mesh.Provider {
+	case databasev1alpha1.ServiceMeshIstio:
+		if bypass {
+			if inboundPort != 0 {
+				annotations[istioExcludeInboundPortsAnnotation] = strconv.Itoa(int(inboundPort))
+			}
+			annotations[istioExcludeOutboundPortsAnnotation] = outbound
+		}
+		if paradedb.ShouldHoldUntilProxyReady() {
+			annotations[istioProxyConfigAnnotation] = `{"holdApplicationUntilProxyStarts": true}`
+		}
+	case databasev1alpha1.ServiceMeshLinkerd:
+		if bypass {
+			if inboundPort != 0 {
+				annotations[linkerdSkipInboundPortsAnnotation] = strconv.Itoa(int(inboundPort))
+			}
+			annotations[linkerdSkipOutboundPortsAnnotation] = outbound
+		}
+		if paradedb.ShouldHoldUntilProxyReady() {
+			annotations[linkerdProxyAwaitAnnotation] = "enabled"
+		}
+	}
+	return annotations
+}
+
+// buildJobServiceMeshAnnotations returns the pod annotations that keep the mesh from
+// injecting a sidecar into Job pods, whose proxy would otherwise keep them from completing
+func buildJobServiceMeshAnnotations(paradedb *databasev1alpha1.ParadeDB) map[string]string {
+	if paradedb.Spec.ServiceMesh == nil {
+		return nil
+	}
+	switch paradedb.Spec.ServiceMesh.Provider {
+	case databasev1alpha1.ServiceMeshIstio:
+		return map[string]string{istioInjectAnnotation: "false"}
+	case databasev1alpha1.ServiceMeshLinkerd:
+		return map[string]string{linkerdInjectAnnotation: "disabled"}
+	}
+	return nil
+}
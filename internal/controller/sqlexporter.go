@@ -0,0 +1,175 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// sqlExporterContainerName is the name of the sql_exporter container
+	sqlExporterContainerName = "sql-exporter"
+
+	// sqlExporterConfigKey is the key of the sql_exporter configuration in the ConfigMap
+	sqlExporterConfigKey = "sql-exporter.yml"
+
+	// sqlExporterMountPath is where sql_exporter mounts the ConfigMap
+	sqlExporterMountPath = "/etc/sql-exporter"
+
+	// sqlExporterPort serves the metrics defined in spec.monitoring.sqlMetrics
+	sqlExporterPort = 9399
+
+	// sqlExporterConfigHashAnnotation on a pod template records the sql_exporter
+	// configuration its container was started with, as sql_exporter reads it only once
+	sqlExporterConfigHashAnnotation = "database.paradedb.io/sql-exporter-config-hash"
+)
+
+// sqlExporterConfig is the sql_exporter configuration file, written as JSON, which YAML
+// parsers read as well
+type sqlExporterConfig struct {
+	Target     sqlExporterTarget      `json:"target"`
+	Collectors []sqlExporterCollector `json:"collectors"`
+}
+
+type sqlExporterTarget struct {
+	DataSourceName string   `json:"data_source_name"`
+	Collectors     []string `json:"collectors"`
+}
+
+type sqlExporterCollector struct {
+	Name    string              `json:"collector_name"`
+	Metrics []sqlExporterMetric `json:"metrics"`
+}
+
+type sqlExporterMetric struct {
+	Name      string   `json:"metric_name"`
+	Type      string   `json:"type"`
+	Help      string   `json:"help"`
+	KeyLabels []string `json:"key_labels,omitempty"`
+	Values    []string `json:"values"`
+	Query     string   `json:"query"`
+}
+
+// buildSQLExporterConfig returns the sql_exporter configuration for spec.monitoring.sqlMetrics.
+// It connects to the database next to the exporter, or through the primary Service when the
+// exporter runs as a Deployment. The credentials come from the libpq environment.
+func buildSQLExporterConfig(paradedb *databasev1alpha1.ParadeDB) string {
+	host, sslMode := "localhost", "disable"
+	if paradedb.IsExporterDeployment() {
+		host, sslMode = fmt.Sprintf("%s.%s.svc", paradedb.GetServiceName(), paradedb.Namespace), getSSLMode(paradedb)
+	}
+
+	collector := sqlExporterCollector{Name: "paradedb"}
+	for _, metric := range paradedb.Spec.Monitoring.SQLMetrics {
+		exported := sqlExporterMetric{
+			Name:      metric.Name,
+			Type:      metric.Type,
+			Help:      metric.Help,
+			KeyLabels: metric.Labels,
+			Values:    []string{metric.Value},
+			Query:     metric.Query,
+		}
+		if exported.Type == "" {
+			exported.Type = "gauge"
+		}
+		if exported.Help == "" {
+			exported.Help = fmt.Sprintf("%s from spec.monitoring.sqlMetrics", metric.Name)
+		}
+		if metric.Value == "" {
+			exported.Values = []string{"value"}
+		}
+		collector.Metrics = append(collector.Metrics, exported)
+	}
+
+	config := sqlExporterConfig{
+		Target: sqlExporterTarget{
+			DataSourceName: fmt.Sprintf("postgres://%s:%d/%s?sslmode=%s", host, paradedb.GetPort(), paradedb.Spec.Auth.Database, sslMode),
+			Collectors:     []string{collector.Name},
+		},
+		Collectors: []sqlExporterCollector{collector},
+	}
+	// Marshalling plain strings and slices cannot fail
+	data, _ := json.MarshalIndent(config, "", "  ")
+	return string(data) + "\n"
+}
+
+// getSQLExporterImage returns the sql_exporter image of the instance
+func (r *ParadeDBReconciler) getSQLExporterImage(paradedb *databasev1alpha1.ParadeDB) string {
+	if image := paradedb.Spec.Monitoring.SQLExporterImage; image != "" {
+		return image
+	}
+	return r.mirrorImage("burningalchemist/sql_exporter:0.17.0")
+}
+
+// buildSQLExporterContainer returns the sql_exporter container serving the metrics in
+// spec.monitoring.sqlMetrics, over TLS like postgres_exporter when metrics TLS is enabled.
+// It mounts the config volume, and the metrics-tls volume when serving over TLS.
+func (r *ParadeDBReconciler) buildSQLExporterContainer(paradedb *databasev1alpha1.ParadeDB) corev1.Container {
+	exporter := corev1.Container{
+		Name:            sqlExporterContainerName,
+		Image:           r.getSQLExporterImage(paradedb),
+		ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+		Args: []string{
+			"--config.file=" + sqlExporterMountPath + "/" + sqlExporterConfigKey,
+			fmt.Sprintf("--web.listen-address=:%d", sqlExporterPort),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "sql-metrics",
+				ContainerPort: sqlExporterPort,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Env:          buildSuperuserEnv(paradedb, "PGUSER", "PGPASSWORD"),
+		Resources:    paradedb.Spec.Monitoring.Resources,
+		VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: sqlExporterMountPath, ReadOnly: true}},
+	}
+	if paradedb.GetMetricsTLSSecretName() != "" {
+		exporter.Args = append(exporter.Args, "--web.config.file="+sqlExporterMountPath+"/"+exporterWebConfigKey)
+		exporter.VolumeMounts = append(exporter.VolumeMounts,
+			corev1.VolumeMount{Name: "metrics-tls", MountPath: exporterTLSMountPath, ReadOnly: true})
+	}
+	return exporter
+}
+
+// buildMetricsServicePorts returns the ports of the metrics Service
+func buildMetricsServicePorts(paradedb *databasev1alpha1.ParadeDB) []corev1.ServicePort {
+	ports := []corev1.ServicePort{{Name: "metrics", Port: paradedb.GetMetricsPort(), Protocol: corev1.ProtocolTCP}}
+	if paradedb.HasSQLMetrics() {
+		ports = append(ports, corev1.ServicePort{Name: "sql-metrics", Port: sqlExporterPort, Protocol: corev1.ProtocolTCP})
+	}
+	return ports
+}
+
+// metricsServicePortsChanged returns true if the Service's ports differ in name or number
+// from the desired ones
+func metricsServicePortsChanged(existing, desired []corev1.ServicePort) bool {
+	if len(existing) != len(desired) {
+		return true
+	}
+	for i := range desired {
+		if existing[i].Name != desired[i].Name || existing[i].Port != desired[i].Port {
+			return true
+		}
+	}
+	return false
+}
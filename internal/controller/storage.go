@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// validateStorage rejects access modes the data volume cannot use. PostgreSQL needs to
+// write to the volume, and Kubernetes does not allow ReadWriteOncePod alongside other
+// modes, which would otherwise only surface as a failing StatefulSet.
+func validateStorage(paradedb *databasev1alpha1.ParadeDB) error {
+	modes := paradedb.Spec.Storage.AccessModes
+	if slices.Contains(modes, corev1.ReadOnlyMany) {
+		return fmt.Errorf("storage.accessModes cannot include ReadOnlyMany, as PostgreSQL writes to the data volume")
+	}
+	if slices.Contains(modes, corev1.ReadWriteOncePod) && len(modes) > 1 {
+		return fmt.Errorf("storage.accessModes cannot combine ReadWriteOncePod with other access modes")
+	}
+	return nil
+}
+
+const (
+	// zoneAnnotation on a data volume claim records the zone the operator provisioned it in
+	zoneAnnotation = "database.paradedb.io/zone"
+
+	// selectedNodeAnnotation on a claim makes the provisioner create the volume in the
+	// topology of that node, as the scheduler does for WaitForFirstConsumer classes
+	selectedNodeAnnotation = "volume.kubernetes.io/selected-node"
+)
+
+// reconcileZonalClaims creates the missing volume claims of replicas pinned to a zone ahead
+// of the StatefulSet, which then adopts them by name. Each claim selects a ready node in
+// its zone, so the volume is provisioned there and the pod is scheduled alongside it.
+func (r *ParadeDBReconciler) reconcileZonalClaims(ctx context.Context, paradedb *databasev1alpha1.ParadeDB, templates []corev1.PersistentVolumeClaim) error {
+	log := logf.FromContext(ctx)
+
+	var nodes *corev1.NodeList
+	for ordinal := range paradedb.GetReplicas() {
+		zone := paradedb.GetZone(ordinal)
+		if zone == "" {
+			return nil
+		}
+
+		for _, template := range templates {
+			name := fmt.Sprintf("%s-%s-%d", template.Name, paradedb.GetStatefulSetName(), ordinal)
+			err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: paradedb.Namespace}, &corev1.PersistentVolumeClaim{})
+			if err == nil {
+				continue
+			} else if !errors.IsNotFound(err) {
+				return err
+			}
+
+			if nodes == nil {
+				nodes = &corev1.NodeList{}
+				if err := r.List(ctx, nodes); err != nil {
+					return err
+				}
+			}
+			node := selectZoneNode(nodes.Items, zone, paradedb.Spec.NodeSelector)
+			if node == "" {
+				return fmt.Errorf("no ready node in zone %s to provision volume claim %s for", zone, name)
+			}
+
+			claim := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: paradedb.Namespace,
+					Labels:    mergeMaps(template.Labels, r.getSelectorLabels(paradedb)),
+					Annotations: mergeMaps(template.Annotations, map[string]string{
+						zoneAnnotation:         zone,
+						selectedNodeAnnotation: node,
+					}),
+				},
+				Spec: *template.Spec.DeepCopy(),
+			}
+			if err := r.Create(ctx, claim); err != nil {
+				return err
+			}
+			log.Info("Created volume claim in zone", "claim", name, "zone", zone, "node", node)
+		}
+	}
+	return nil
+}
+
+// selectZoneNode returns the first ready, schedulable node in the zone that matches the
+// node selector, or an empty string if there is none
+func selectZoneNode(nodes []corev1.Node, zone string, nodeSelector map[string]string) string {
+	selector := labels.SelectorFromSet(nodeSelector)
+	var candidates []string
+	for _, node := range nodes {
+		if node.Labels[corev1.LabelTopologyZone] != zone || node.Spec.Unschedulable || !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				candidates = append(candidates, node.Name)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Strings(candidates)
+	return candidates[0]
+}
+
+// diagnosePendingPods explains why a database pod has not been scheduled, such as a data
+// volume waiting to be bound. Returns an empty reason if every pod has been scheduled.
+func (r *ParadeDBReconciler) diagnosePendingPods(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (string, string, error) {
+	for ordinal := range paradedb.GetReplicas() {
+		pod := &corev1.Pod{}
+		name := fmt.Sprintf("%s-%d", paradedb.GetStatefulSetName(), ordinal)
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: paradedb.Namespace}, pod)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return "", "", err
+		}
+
+		var scheduled *corev1.PodCondition
+		for i := range pod.Status.Conditions {
+			if pod.Status.Conditions[i].Type == corev1.PodScheduled {
+				scheduled = &pod.Status.Conditions[i]
+			}
+		}
+		if scheduled == nil || scheduled.Status == corev1.ConditionTrue {
+			continue
+		}
+
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim == nil {
+				continue
+			}
+			reason, message, err := r.diagnosePendingClaim(ctx, pod, volume.PersistentVolumeClaim.ClaimName)
+			if err != nil || reason != "" {
+				return reason, message, err
+			}
+		}
+		return "Unschedulable", fmt.Sprintf("Pod %s cannot be scheduled: %s", pod.Name, scheduled.Message), nil
+	}
+	return "", "", nil
+}
+
+// diagnosePendingClaim explains why a claim of an unscheduled pod is not bound yet.
+// Returns an empty reason if the claim is bound.
+func (r *ParadeDBReconciler) diagnosePendingClaim(ctx context.Context, pod *corev1.Pod, claimName string) (string, string, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.Get(ctx, types.NamespacedName{Name: claimName, Namespace: pod.Namespace}, pvc)
+	if errors.IsNotFound(err) {
+		return "WaitingForVolumeBinding", fmt.Sprintf("Waiting for PVC %s to be created", claimName), nil
+	} else if err != nil {
+		return "", "", err
+	}
+	if pvc.Status.Phase != corev1.ClaimPending {
+		return "", "", nil
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return "WaitingForVolumeBinding", fmt.Sprintf("Waiting for PVC %s to bind to a pre-provisioned volume", claimName), nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	err = r.Get(ctx, types.NamespacedName{Name: *pvc.Spec.StorageClassName}, storageClass)
+	if errors.IsNotFound(err) {
+		return "StorageClassNotFound", fmt.Sprintf("PVC %s uses StorageClass %s, which does not exist",
+			claimName, *pvc.Spec.StorageClassName), nil
+	} else if err != nil {
+		return "", "", err
+	}
+
+	// With WaitForFirstConsumer the volume is only provisioned once the scheduler has
+	// picked a node, so the pod's scheduling message is what explains the wait
+	if storageClass.VolumeBindingMode != nil && *storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer {
+		message := fmt.Sprintf("Waiting for PVC %s to bind: StorageClass %s provisions volumes once pod %s is scheduled",
+			claimName, storageClass.Name, pod.Name)
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Message != "" {
+				message += ": " + condition.Message
+			}
+		}
+		return "WaitingForVolumeBinding", message, nil
+	}
+	return "WaitingForVolumeBinding", fmt.Sprintf("Waiting for PVC %s to bind: %s has not provisioned a volume yet",
+		claimName, storageClass.Provisioner), nil
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// catalogRelease is a ParadeDB release listed in a version catalog
+type catalogRelease struct {
+	// Version is the ParadeDB release, e.g. 0.15.2
+	Version string `json:"version"`
+	// PostgresVersion is the major PostgreSQL version the image is built for
+	PostgresVersion string `json:"postgresVersion"`
+	// Image is the image reference, ideally pinned by digest
+	Image string `json:"image"`
+}
+
+// versionCatalog maps channel names to their releases
+type versionCatalog map[string][]catalogRelease
+
+// applyUpdatePolicy resolves the image from the version catalog when spec.updatePolicy is
+// set and no image is given. Like class defaults, the image is only set for this reconcile.
+// A newer release allowed by the policy is adopted in the maintenance window and recorded
+// in status.catalogVersion.
+func (r *ParadeDBReconciler) applyUpdatePolicy(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	policy := paradedb.Spec.UpdatePolicy
+	if policy == nil || paradedb.Spec.Image != "" {
+		return nil
+	}
+
+	catalog, err := r.getVersionCatalog(ctx, paradedb)
+	if err != nil {
+		return err
+	}
+	releases := catalog[paradedb.GetUpdateChannel()]
+
+	// An instance without a catalog release yet starts on the newest one for its
+	// PostgreSQL version. Once running, it never moves to another PostgreSQL version.
+	var current *catalogRelease
+	if paradedb.Status.CatalogVersion != "" {
+		current = findCatalogRelease(releases, paradedb.Status.CatalogVersion, paradedb.Spec.PostgresVersion)
+		if current == nil {
+			return fmt.Errorf("release %s for PostgreSQL %s is not in channel %q of the version catalog",
+				paradedb.Status.CatalogVersion, paradedb.Spec.PostgresVersion, paradedb.GetUpdateChannel())
+		}
+	}
+	candidate, err := selectCatalogRelease(releases, paradedb.Spec.PostgresVersion, current, paradedb.GetAutoUpdate())
+	if err != nil {
+		return err
+	}
+	if candidate == nil && current == nil {
+		return fmt.Errorf("channel %q of the version catalog has no release for PostgreSQL %s",
+			paradedb.GetUpdateChannel(), paradedb.Spec.PostgresVersion)
+	}
+	if candidate != nil && (current == nil || inMaintenanceWindow(policy.MaintenanceWindow, time.Now())) {
+		if current != nil {
+			log.Info("Updating to catalog release", "from", current.Version, "to", candidate.Version)
+			r.Recorder.Event(paradedb, corev1.EventTypeNormal, "VersionUpdated",
+				fmt.Sprintf("Updating from %s to %s from channel %s", current.Version, candidate.Version, paradedb.GetUpdateChannel()))
+		}
+		current = candidate
+		paradedb.Status.CatalogVersion = candidate.Version
+	}
+
+	paradedb.Spec.Image = current.Image
+	return nil
+}
+
+// getVersionCatalog reads and parses the catalog ConfigMap key
+func (r *ParadeDBReconciler) getVersionCatalog(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) (versionCatalog, error) {
+	ref := paradedb.Spec.UpdatePolicy.CatalogRef
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: paradedb.Namespace}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get version catalog: %w", err)
+	}
+	data, ok := configMap.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("version catalog ConfigMap %s has no key %s", ref.Name, ref.Key)
+	}
+
+	catalog := versionCatalog{}
+	if err := yaml.Unmarshal([]byte(data), &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse version catalog: %w", err)
+	}
+	return catalog, nil
+}
+
+// findCatalogRelease returns the release with the given version for the PostgreSQL version
+func findCatalogRelease(releases []catalogRelease, releaseVersion, postgresVersion string) *catalogRelease {
+	for i := range releases {
+		if releases[i].Version == releaseVersion && releases[i].PostgresVersion == postgresVersion {
+			return &releases[i]
+		}
+	}
+	return nil
+}
+
+// selectCatalogRelease returns the newest release for the PostgreSQL version that is newer
+// than current and within the change allowed by auto, or nil if there is none
+func selectCatalogRelease(releases []catalogRelease, postgresVersion string, current *catalogRelease, auto string) (*catalogRelease, error) {
+	if auto == "none" && current != nil {
+		return nil, nil
+	}
+
+	var currentVersion *version.Version
+	if current != nil {
+		v, err := version.ParseSemantic(current.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid catalog version %q: %w", current.Version, err)
+		}
+		currentVersion = v
+	}
+
+	var newest *catalogRelease
+	var newestVersion *version.Version
+	for i := range releases {
+		release := &releases[i]
+		if release.PostgresVersion != postgresVersion {
+			continue
+		}
+		v, err := version.ParseSemantic(release.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid catalog version %q: %w", release.Version, err)
+		}
+		if currentVersion != nil {
+			if !v.GreaterThan(currentVersion) || v.Major() != currentVersion.Major() ||
+				(auto == "patch" && v.Minor() != currentVersion.Minor()) {
+				continue
+			}
+		}
+		if newestVersion == nil || v.GreaterThan(newestVersion) {
+			newest, newestVersion = release, v
+		}
+	}
+	return newest, nil
+}
+
+// inMaintenanceWindow returns true if now falls in the daily window, or if there is none
+func inMaintenanceWindow(window *databasev1alpha1.MaintenanceWindowSpec, now time.Time) bool {
+	if window == nil {
+		return true
+	}
+	start, err := time.Parse("15:04", window.StartTime)
+	if err != nil {
+		return false
+	}
+	duration := window.Duration.Duration
+	if duration == 0 {
+		duration = 2 * time.Hour
+	}
+
+	now = now.UTC()
+	// The window may have started yesterday and run past midnight
+	for _, day := range []time.Time{now.AddDate(0, 0, -1), now} {
+		windowStart := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, time.UTC)
+		if !now.Before(windowStart) && now.Before(windowStart.Add(duration)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// vectorIndexMarker prefixes the comment that records the definition an index was built from
+const vectorIndexMarker = "paradedb-operator:"
+
+// validateVectorSpec rejects vector index settings that do not apply to the index method
+func validateVectorSpec(paradedb *databasev1alpha1.ParadeDB) error {
+	if paradedb.Spec.Vector == nil {
+		return nil
+	}
+	if !paradedb.Spec.Extensions.PgVector {
+		return fmt.Errorf("spec.vector requires spec.extensions.pgVector")
+	}
+	for _, index := range paradedb.GetVectorIndexes() {
+		if getVectorIndexMethod(index) == "hnsw" && index.Lists != nil {
+			return fmt.Errorf("vector index %s: lists only applies to ivfflat", index.Name)
+		}
+		if getVectorIndexMethod(index) == "ivfflat" && (index.M != nil || index.EfConstruction != nil) {
+			return fmt.Errorf("vector index %s: m and efConstruction only apply to hnsw", index.Name)
+		}
+		if index.RebuildSchedule != "" {
+			if _, err := parseCronSchedule(index.RebuildSchedule); err != nil {
+				return fmt.Errorf("vector index %s: invalid rebuildSchedule: %w", index.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// getVectorIndexMethod returns the access method of the index
+func getVectorIndexMethod(index databasev1alpha1.VectorIndexSpec) string {
+	if index.Method == "" {
+		return "hnsw"
+	}
+	return index.Method
+}
+
+// getVectorIndexDatabase returns the database the index is built in
+func getVectorIndexDatabase(paradedb *databasev1alpha1.ParadeDB, index databasev1alpha1.VectorIndexSpec) string {
+	if index.Database == "" {
+		return paradedb.Spec.Auth.Database
+	}
+	return index.Database
+}
+
+// buildCreateVectorIndex returns the CREATE INDEX statement for the index under the given name
+func buildCreateVectorIndex(index databasev1alpha1.VectorIndexSpec, name string) string {
+	operatorClass := index.OperatorClass
+	if operatorClass == "" {
+		operatorClass = "vector_l2_ops"
+	}
+
+	var parameters []string
+	if index.M != nil {
+		parameters = append(parameters, fmt.Sprintf("m = %d", *index.M))
+	}
+	if index.EfConstruction != nil {
+		parameters = append(parameters, fmt.Sprintf("ef_construction = %d", *index.EfConstruction))
+	}
+	if index.Lists != nil {
+		parameters = append(parameters, fmt.Sprintf("lists = %d", *index.Lists))
+	}
+
+	statement := fmt.Sprintf("CREATE INDEX CONCURRENTLY %s ON %s USING %s (%s %s)",
+		pq.QuoteIdentifier(name), quoteQualifiedName(index.Table), getVectorIndexMethod(index),
+		pq.QuoteIdentifier(index.Column), pq.QuoteIdentifier(operatorClass))
+	if len(parameters) > 0 {
+		statement += " WITH (" + strings.Join(parameters, ", ") + ")"
+	}
+	return statement
+}
+
+// qualifyVectorIndexName returns the quoted name of an index in the schema of the index's table
+func qualifyVectorIndexName(index databasev1alpha1.VectorIndexSpec, name string) string {
+	if i := strings.LastIndex(index.Table, "."); i >= 0 {
+		return quoteQualifiedName(index.Table[:i]) + "." + pq.QuoteIdentifier(name)
+	}
+	return pq.QuoteIdentifier(name)
+}
+
+// buildVectorSessionSettings returns the SET statements for index builds and rebuilds
+func buildVectorSessionSettings(paradedb *databasev1alpha1.ParadeDB) string {
+	var settings strings.Builder
+	if workMem := paradedb.Spec.Vector.MaintenanceWorkMem; workMem != "" {
+		fmt.Fprintf(&settings, "SET maintenance_work_mem = %s;\n", pq.QuoteLiteral(workMem))
+	}
+	if workers := paradedb.Spec.Vector.MaxParallelMaintenanceWorkers; workers != nil {
+		fmt.Fprintf(&settings, "SET max_parallel_maintenance_workers = %d;\n", *workers)
+	}
+	return settings.String()
+}
+
+// buildVectorIndexScript returns the psql script that builds the index unless its comment
+// shows it was already built from the current definition. The index is built under a
+// temporary name and swapped in, so a changed definition replaces the old index.
+func buildVectorIndexScript(paradedb *databasev1alpha1.ParadeDB, index databasev1alpha1.VectorIndexSpec) string {
+	marker := pq.QuoteLiteral(vectorIndexMarker + shortHash(buildCreateVectorIndex(index, index.Name)))
+	qualified := qualifyVectorIndexName(index, index.Name)
+	qualifiedNew := qualifyVectorIndexName(index, index.Name+"_new")
+
+	var script strings.Builder
+	script.WriteString(buildVectorSessionSettings(paradedb))
+	fmt.Fprintf(&script, "SELECT coalesce(obj_description(to_regclass(%s), 'pg_class') = %s, false) AS current \\gset\n",
+		pq.QuoteLiteral(qualified), marker)
+	script.WriteString("\\if :current\n")
+	fmt.Fprintf(&script, "\\echo Index %s is up to date\n", index.Name)
+	script.WriteString("\\else\n")
+	fmt.Fprintf(&script, "DROP INDEX CONCURRENTLY IF EXISTS %s;\n", qualifiedNew)
+	fmt.Fprintf(&script, "%s;\n", buildCreateVectorIndex(index, index.Name+"_new"))
+	fmt.Fprintf(&script, "DROP INDEX CONCURRENTLY IF EXISTS %s;\n", qualified)
+	fmt.Fprintf(&script, "ALTER INDEX %s RENAME TO %s;\n", qualifiedNew, pq.QuoteIdentifier(index.Name))
+	fmt.Fprintf(&script, "COMMENT ON INDEX %s IS %s;\n", qualified, marker)
+	script.WriteString("\\endif\n")
+	return script.String()
+}
+
+// buildVectorReindexScript returns the psql script that rebuilds the index in place
+func buildVectorReindexScript(paradedb *databasev1alpha1.ParadeDB, index databasev1alpha1.VectorIndexSpec) string {
+	return buildVectorSessionSettings(paradedb) +
+		fmt.Sprintf("REINDEX INDEX CONCURRENTLY %s;\n", qualifyVectorIndexName(index, index.Name))
+}
+
+// getVectorIndexJobName returns the name of the Job that builds the current definition of the index
+func getVectorIndexJobName(paradedb *databasev1alpha1.ParadeDB, index databasev1alpha1.VectorIndexSpec) string {
+	return fmt.Sprintf("%s-vidx-%s", paradedb.Name, shortHash(buildVectorIndexScript(paradedb, index))[:10])
+}
+
+// getVectorReindexCronJobName returns the name of the CronJob that rebuilds the index
+func getVectorReindexCronJobName(paradedb *databasev1alpha1.ParadeDB, index databasev1alpha1.VectorIndexSpec) string {
+	return fmt.Sprintf("%s-reindex-%s", paradedb.Name, shortHash(index.Name)[:10])
+}
+
+// buildVectorIndexPodTemplate returns the pod template that runs the psql script against
+// the index's database
+func (r *ParadeDBReconciler) buildVectorIndexPodTemplate(paradedb *databasev1alpha1.ParadeDB,
+	index databasev1alpha1.VectorIndexSpec, script string) corev1.PodTemplateSpec {
+	env := append(buildClientEnv(paradedb),
+		corev1.EnvVar{Name: "PGDATABASE", Value: getVectorIndexDatabase(paradedb, index)},
+		corev1.EnvVar{Name: "SCRIPT", Value: script},
+	)
+
+	podSpec := corev1.PodSpec{
+		RestartPolicy:    corev1.RestartPolicyOnFailure,
+		ImagePullSecrets: paradedb.Spec.ImagePullSecrets,
+		Affinity:         withArchitectureAffinity(paradedb, nil),
+		Containers: []corev1.Container{{
+			Name:            "psql",
+			Image:           paradedb.GetImage(),
+			ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+			Command:         []string{"sh", "-c", `printf '%s\n' "$SCRIPT" | psql -X -v ON_ERROR_STOP=1 -f -`},
+			Env:             env,
+		}},
+	}
+	applyServiceAccount(paradedb, &podSpec)
+
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"app.kubernetes.io/component": "vector-index"},
+			Annotations: buildJobServiceMeshAnnotations(paradedb),
+		},
+		Spec: podSpec,
+	}
+}
+
+// buildVectorIndexJob returns the Job that builds the index
+func (r *ParadeDBReconciler) buildVectorIndexJob(paradedb *databasev1alpha1.ParadeDB, index databasev1alpha1.VectorIndexSpec) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getVectorIndexJobName(paradedb, index),
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: batchv1.JobSpec{
+			Template: r.buildVectorIndexPodTemplate(paradedb, index, buildVectorIndexScript(paradedb, index)),
+		},
+	}
+}
+
+// buildVectorReindexCronJob returns the CronJob that rebuilds the index on its schedule
+func (r *ParadeDBReconciler) buildVectorReindexCronJob(paradedb *databasev1alpha1.ParadeDB, index databasev1alpha1.VectorIndexSpec) *batchv1.CronJob {
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getVectorReindexCronJobName(paradedb, index),
+			Namespace: paradedb.Namespace,
+			Labels:    r.getLabels(paradedb),
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          index.RebuildSchedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: r.getLabels(paradedb)},
+				Spec: batchv1.JobSpec{
+					Template: r.buildVectorIndexPodTemplate(paradedb, index, buildVectorReindexScript(paradedb, index)),
+				},
+			},
+		},
+	}
+}
+
+// reconcileVectorIndexes starts a Job for each index whose current definition has not been
+// built yet and keeps the rebuild CronJobs up to date. Builds wait until the database is
+// reachable, and a standby gets its indexes from the primary.
+func (r *ParadeDBReconciler) reconcileVectorIndexes(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	if paradedb.IsStandby() {
+		return nil
+	}
+
+	for _, index := range paradedb.GetVectorIndexes() {
+		if meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+			job := r.buildVectorIndexJob(paradedb, index)
+			err := r.Get(ctx, types.NamespacedName{Name: job.Name, Namespace: paradedb.Namespace}, &batchv1.Job{})
+			if apierrors.IsNotFound(err) {
+				if err := controllerutil.SetControllerReference(paradedb, job, r.Scheme); err != nil {
+					return err
+				}
+				if err := r.Create(ctx, job); err != nil {
+					return err
+				}
+				log.Info("Building vector index", "index", index.Name, "job", job.Name)
+				r.Recorder.Event(paradedb, corev1.EventTypeNormal, "VectorIndexBuildStarted",
+					fmt.Sprintf("Building vector index %s in Job %s", index.Name, job.Name))
+			} else if err != nil {
+				return err
+			}
+		}
+
+		if index.RebuildSchedule == "" {
+			continue
+		}
+		desired := r.buildVectorReindexCronJob(paradedb, index)
+		cronJob := &batchv1.CronJob{}
+		err := r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: paradedb.Namespace}, cronJob)
+		if apierrors.IsNotFound(err) {
+			if err := controllerutil.SetControllerReference(paradedb, desired, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, desired); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		} else {
+			cronJob.Spec = desired.Spec
+			if err := r.Update(ctx, cronJob); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
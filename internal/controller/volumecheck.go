@@ -0,0 +1,102 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// volumeCheckContainerName is the init container that prepares the data directory
+const volumeCheckContainerName = "volume-check"
+
+// buildVolumeCheckContainer returns the init container that fixes the ownership of the
+// data directory and fails when it was initialized by another PostgreSQL major version
+// than the image runs, which PostgreSQL would otherwise only report in its own logs
+func buildVolumeCheckContainer(paradedb *databasev1alpha1.ParadeDB) corev1.Container {
+	script := `set -eu
+if [ -s "$PGDATA/PG_VERSION" ]; then
+  found=$(cat "$PGDATA/PG_VERSION")
+  image=$(postgres -V | awk '{split($3, v, "."); print v[1]}')
+  if [ "$found" != "$image" ]; then
+    echo "The data directory was initialized by PostgreSQL $found, but the image runs PostgreSQL $image (postgresVersion $POSTGRES_VERSION)" >&2
+    exit 1
+  fi
+fi
+if [ "$(id -u)" = "0" ]; then
+  mkdir -p "$PGDATA"
+  if [ "$(stat -c %u "$PGDATA")" != "$(id -u postgres)" ]; then
+    chown -R postgres:postgres "$PGDATA"
+  fi
+  chmod 0700 "$PGDATA"
+elif [ -e "$PGDATA" ] && [ "$(stat -c %u "$PGDATA")" != "$(id -u)" ]; then
+  echo "The data directory is owned by uid $(stat -c %u "$PGDATA"), not $(id -u); set podSecurityContext.fsGroup or run as root" >&2
+  exit 1
+elif [ ! -w "$(dirname "$PGDATA")" ]; then
+  echo "The data volume is not writable by uid $(id -u); set podSecurityContext.fsGroup or run as root" >&2
+  exit 1
+fi`
+
+	return corev1.Container{
+		Name:            volumeCheckContainerName,
+		Image:           paradedb.GetImage(),
+		ImagePullPolicy: paradedb.Spec.ImagePullPolicy,
+		Command:         []string{"/bin/sh", "-c", script},
+		Env: []corev1.EnvVar{
+			{Name: "PGDATA", Value: "/var/lib/postgresql/data/pgdata"},
+			{Name: "POSTGRES_VERSION", Value: paradedb.Spec.PostgresVersion},
+		},
+		VolumeMounts:             []corev1.VolumeMount{{Name: "data", MountPath: "/var/lib/postgresql/data"}},
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+}
+
+// checkVolumeCheckFailures returns an error with the message of the first pod whose
+// volume check failed, after recording it in a VolumeCheckFailed Event
+func (r *ParadeDBReconciler) checkVolumeCheckFailures(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(paradedb.Namespace), client.MatchingLabels(r.getSelectorLabels(paradedb))); err != nil {
+		return err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		for _, status := range pod.Status.InitContainerStatuses {
+			if status.Name != volumeCheckContainerName {
+				continue
+			}
+			terminated := status.State.Terminated
+			if terminated == nil {
+				terminated = status.LastTerminationState.Terminated
+			}
+			if terminated == nil || terminated.ExitCode == 0 || status.State.Running != nil {
+				continue
+			}
+
+			message := fmt.Sprintf("Data volume check failed on pod %s: %s", pod.Name, strings.TrimSpace(terminated.Message))
+			r.Recorder.Event(paradedb, corev1.EventTypeWarning, "VolumeCheckFailed", message)
+			return fmt.Errorf("%s", message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// defaultWALSegmentSizeMB is the WAL segment size initdb uses unless told otherwise
+	defaultWALSegmentSizeMB = 16
+
+	// maxArchiveTimeout is the largest archive_timeout PostgreSQL accepts
+	maxArchiveTimeout = (1<<30 - 1) * time.Second
+)
+
+// getWALSizes returns max_wal_size and min_wal_size in megabytes. Unset sizes are derived
+// from the volume the WAL is written to, but never below the two segments PostgreSQL needs.
+func getWALSizes(paradedb *databasev1alpha1.ParadeDB) (int64, int64) {
+	walConfig := paradedb.Spec.WALConfig
+	minimum := int64(2 * getWALSegmentSizeMB(paradedb))
+
+	var maxSize int64
+	if walConfig.MaxWALSize != nil {
+		maxSize = walConfig.MaxWALSize.Value() >> 20
+	} else {
+		volume := paradedb.Spec.Storage.Size
+		if paradedb.Spec.Storage.WalStorage != nil {
+			volume = paradedb.Spec.Storage.WalStorage.Size
+		}
+		maxSize = max(volume.Value()>>20/4, minimum)
+	}
+
+	var minSize int64
+	if walConfig.MinWALSize != nil {
+		minSize = walConfig.MinWALSize.Value() >> 20
+	} else {
+		minSize = max(maxSize/4, minimum)
+	}
+	return maxSize, minSize
+}
+
+// getWALSegmentSizeMB returns the WAL segment size the data directory is initialized with
+func getWALSegmentSizeMB(paradedb *databasev1alpha1.ParadeDB) int32 {
+	if paradedb.Spec.Bootstrap != nil && paradedb.Spec.Bootstrap.InitDB != nil && paradedb.Spec.Bootstrap.InitDB.WALSegmentSize > 0 {
+		return paradedb.Spec.Bootstrap.InitDB.WALSegmentSize
+	}
+	return defaultWALSegmentSizeMB
+}
+
+// validateWALConfig rejects spec.walConfig values PostgreSQL would refuse to start with
+func validateWALConfig(paradedb *databasev1alpha1.ParadeDB) error {
+	walConfig := paradedb.Spec.WALConfig
+	if walConfig == nil {
+		return nil
+	}
+
+	maxSize, minSize := getWALSizes(paradedb)
+	if minimum := int64(2 * getWALSegmentSizeMB(paradedb)); minSize < minimum {
+		return fmt.Errorf("spec.walConfig.minWalSize must be at least two WAL segments (%dMB)", minimum)
+	}
+	if maxSize < minSize {
+		return fmt.Errorf("spec.walConfig.maxWalSize (%dMB) must not be smaller than minWalSize (%dMB)", maxSize, minSize)
+	}
+	if timeout := walConfig.CheckpointTimeout; timeout != nil && (timeout.Duration < 30*time.Second || timeout.Duration > 24*time.Hour) {
+		return fmt.Errorf("spec.walConfig.checkpointTimeout must be between 30s and 24h, got %s", timeout.Duration)
+	}
+	if timeout := walConfig.ArchiveTimeout; timeout != nil && (timeout.Duration < 0 || timeout.Duration > maxArchiveTimeout) {
+		return fmt.Errorf("spec.walConfig.archiveTimeout must be between 0 and %s, got %s", maxArchiveTimeout, timeout.Duration)
+	}
+	return nil
+}
+
+// buildWALArgs returns the postgres arguments for spec.walConfig. Command-line settings
+// take precedence over the configuration files.
+func buildWALArgs(paradedb *databasev1alpha1.ParadeDB) []string {
+	walConfig := paradedb.Spec.WALConfig
+	if walConfig == nil {
+		return nil
+	}
+
+	maxSize, minSize := getWALSizes(paradedb)
+	args := []string{
+		"-c", fmt.Sprintf("max_wal_size=%dMB", maxSize),
+		"-c", fmt.Sprintf("min_wal_size=%dMB", minSize),
+	}
+	if walConfig.CheckpointTimeout != nil {
+		args = append(args, "-c", fmt.Sprintf("checkpoint_timeout=%ds", int64(walConfig.CheckpointTimeout.Seconds())))
+	}
+	if walConfig.ArchiveTimeout != nil {
+		args = append(args, "-c", fmt.Sprintf("archive_timeout=%ds", int64(walConfig.ArchiveTimeout.Seconds())))
+	}
+	if walConfig.WALCompression != "" {
+		args = append(args, "-c", "wal_compression="+walConfig.WALCompression)
+	}
+	return args
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+const (
+	// reportingStatementTimeout is the reporting user's statement_timeout unless set
+	reportingStatementTimeout = "5min"
+
+	// reportingConnectionLimit is the reporting user's connection limit unless set
+	reportingConnectionLimit = int32(10)
+)
+
+// workloadRole is an enabled search, analytics or reporting role with its settings
+type workloadRole struct {
+	Name string
+	Spec *databasev1alpha1.WorkloadRoleSpec
+	// ReadOnly makes the role's transactions read-only by default
+	ReadOnly bool
+}
+
+// getWorkloadRoles returns the enabled workload roles: search, analytics, then reporting
+func getWorkloadRoles(paradedb *databasev1alpha1.ParadeDB) []workloadRole {
+	var roles []workloadRole
+	if spec := paradedb.Spec.Auth.WorkloadRoles; spec != nil {
+		if spec.Search != nil && spec.Search.Enabled {
+			roles = append(roles, workloadRole{Name: "search_service", Spec: spec.Search})
+		}
+		if spec.Analytics != nil && spec.Analytics.Enabled {
+			roles = append(roles, workloadRole{Name: "analytics_service", Spec: spec.Analytics})
+		}
+	}
+
+	if paradedb.IsReportingUserEnabled() {
+		limits := paradedb.Spec.Auth.ReportingUser.SessionLimits
+		if limits.StatementTimeout == "" {
+			limits.StatementTimeout = reportingStatementTimeout
+		}
+		if limits.ConnectionLimit == nil {
+			connectionLimit := reportingConnectionLimit
+			limits.ConnectionLimit = &connectionLimit
+		}
+		roles = append(roles, workloadRole{
+			Name:     paradedb.GetReportingUsername(),
+			Spec:     &databasev1alpha1.WorkloadRoleSpec{Enabled: true, SessionLimits: limits},
+			ReadOnly: true,
+		})
+	}
+	return roles
+}
+
+// getWorkloadRoleSecretName returns the name of the Secret holding a workload role's credentials
+func getWorkloadRoleSecretName(paradedb *databasev1alpha1.ParadeDB, role string) string {
+	return paradedb.Name + "-" + strings.ReplaceAll(role, "_", "-")
+}
+
+// reconcileWorkloadRoleSecrets creates the Secrets holding the workload roles' credentials
+func (r *ParadeDBReconciler) reconcileWorkloadRoleSecrets(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	log := logf.FromContext(ctx)
+
+	for _, role := range getWorkloadRoles(paradedb) {
+		name := getWorkloadRoleSecretName(paradedb, role.Name)
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: paradedb.Namespace}, secret)
+		if err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return err
+		}
+
+		log.Info("Creating workload role secret", "name", name)
+
+		labels, annotations := withMetadata(paradedb.Spec.SecretMetadata, r.getLabels(paradedb), nil)
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   paradedb.Namespace,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Type: corev1.SecretTypeOpaque,
+			StringData: map[string]string{
+				"username": role.Name,
+				"password": generateRandomPassword(16),
+				"database": paradedb.Spec.Auth.Database,
+				"host":     paradedb.GetHost(),
+				"port":     fmt.Sprintf("%d", paradedb.GetPort()),
+			},
+		}
+
+		if err := controllerutil.SetControllerReference(paradedb, secret, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, secret); err != nil {
+			return err
+		}
+		r.Recorder.Event(paradedb, corev1.EventTypeNormal, "SecretCreated", fmt.Sprintf("Secret for role %s created", role.Name))
+	}
+	return nil
+}
+
+// setWorkloadRolesReadyCondition provisions the workload roles and reports the outcome
+func (r *ParadeDBReconciler) setWorkloadRolesReadyCondition(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) {
+	if !meta.IsStatusConditionTrue(paradedb.Status.Conditions, ConditionTypeDatabaseReachable) {
+		setCondition(paradedb, ConditionTypeWorkloadRolesReady, metav1.ConditionUnknown, "DatabaseUnreachable",
+			"Waiting for the database to accept connections")
+		return
+	}
+
+	if err := r.reconcileWorkloadRoles(ctx, paradedb); err != nil {
+		setCondition(paradedb, ConditionTypeWorkloadRolesReady, metav1.ConditionFalse, "ProvisioningFailed",
+			fmt.Sprintf("Failed to provision workload roles: %v", err))
+		return
+	}
+	setCondition(paradedb, ConditionTypeWorkloadRolesReady, metav1.ConditionTrue, "Provisioned",
+		fmt.Sprintf("%d workload roles are provisioned", len(getWorkloadRoles(paradedb))))
+}
+
+// reconcileWorkloadRoles runs the SQL that brings each workload role's password, grants
+// and limits in line with the spec and its Secret
+func (r *ParadeDBReconciler) reconcileWorkloadRoles(ctx context.Context, paradedb *databasev1alpha1.ParadeDB) error {
+	username, password, err := getSuperuserCredentials(ctx, r.Client, paradedb)
+	if err != nil {
+		return fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	for _, role := range getWorkloadRoles(paradedb) {
+		secret := &corev1.Secret{}
+		name := getWorkloadRoleSecretName(paradedb, role.Name)
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: paradedb.Namespace}, secret); err != nil {
+			return fmt.Errorf("failed to read secret %s: %w", name, err)
+		}
+
+		err := withDatabase(ctx, buildConnectionURL(paradedb, username, password), func(ctx context.Context, db *sql.DB) error {
+			var exists bool
+			if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)", role.Name).Scan(&exists); err != nil {
+				return err
+			}
+			for _, statement := range buildWorkloadRoleStatements(paradedb, role, string(secret.Data["password"]), exists) {
+				if _, err := db.ExecContext(ctx, statement); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("role %s: %w", role.Name, err)
+		}
+	}
+	return nil
+}
+
+// buildWorkloadRoleStatements returns the statements that create or update a workload
+// role, grant it read access to the public schema, including tables created later, and
+// set its work_mem, read-only default and session limits
+func buildWorkloadRoleStatements(paradedb *databasev1alpha1.ParadeDB, role workloadRole, password string, exists bool) []string {
+	quoted := pq.QuoteIdentifier(role.Name)
+
+	verb := "CREATE"
+	if exists {
+		verb = "ALTER"
+	}
+	statements := []string{
+		fmt.Sprintf("%s ROLE %s WITH LOGIN PASSWORD %s", verb, quoted, pq.QuoteLiteral(password)),
+		fmt.Sprintf("GRANT CONNECT ON DATABASE %s TO %s", pq.QuoteIdentifier(paradedb.Spec.Auth.Database), quoted),
+		fmt.Sprintf("GRANT USAGE ON SCHEMA public TO %s", quoted),
+		fmt.Sprintf("GRANT SELECT ON ALL TABLES IN SCHEMA public TO %s", quoted),
+		fmt.Sprintf("ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT ON TABLES TO %s", quoted),
+	}
+	if role.Spec.WorkMem != "" {
+		statements = append(statements, fmt.Sprintf("ALTER ROLE %s SET work_mem = %s", quoted, pq.QuoteLiteral(role.Spec.WorkMem)))
+	}
+	if role.ReadOnly {
+		statements = append(statements, fmt.Sprintf("ALTER ROLE %s SET default_transaction_read_only = on", quoted))
+	}
+	return append(statements, buildSessionLimitStatements("ROLE", role.Name, role.Spec.SessionLimits)...)
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var paradedblog = logf.Log.WithName("paradedb-resource")
+
+// SetupParadeDBWebhookWithManager registers the webhook for ParadeDB in the manager.
+func SetupParadeDBWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &databasev1alpha1.ParadeDB{}).
+		WithValidator(&ParadeDBCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-database-paradedb-io-v1alpha1-paradedb,mutating=false,failurePolicy=fail,sideEffects=None,groups=database.paradedb.io,resources=paradedbs,verbs=create;update,versions=v1alpha1,name=vparadedb-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ParadeDBCustomValidator rejects ParadeDB specs the operator cannot run correctly, so that
+// they fail at admission rather than at reconciliation. As the webhook can be disabled, the
+// reconciler also reports such specs as invalid, except for the replicas of instances that
+// already run, which only get a warning event.
+type ParadeDBCustomValidator struct{}
+
+// ValidateCreate rejects more than one replica without spec.replication and memory
+// settings that do not fit the memory limit.
+func (v *ParadeDBCustomValidator) ValidateCreate(_ context.Context, paradedb *databasev1alpha1.ParadeDB) (admission.Warnings, error) {
+	paradedblog.Info("Validation for ParadeDB upon creation", "name", paradedb.GetName())
+
+	if err := paradedb.ValidateReplicas(); err != nil {
+		return nil, err
+	}
+	return validateMemorySettings(paradedb)
+}
+
+// ValidateUpdate rejects raising the replicas above 1 without spec.replication, and memory
+// settings that do not fit the memory limit. Instances that already ran more than one
+// replica before the rule get a warning instead, so that they can still be updated.
+func (v *ParadeDBCustomValidator) ValidateUpdate(_ context.Context, oldParadeDB, paradedb *databasev1alpha1.ParadeDB) (admission.Warnings, error) {
+	paradedblog.Info("Validation for ParadeDB upon update", "name", paradedb.GetName())
+
+	var warnings admission.Warnings
+	if err := paradedb.ValidateReplicas(); err != nil {
+		if oldParadeDB.ValidateReplicas() == nil {
+			return nil, err
+		}
+		warnings = append(warnings, err.Error())
+	}
+
+	memoryWarnings, err := validateMemorySettings(paradedb)
+	if err != nil {
+		return warnings, err
+	}
+	return append(warnings, memoryWarnings...), nil
+}
+
+// validateMemorySettings rejects a shared_buffers that does not fit the memory limit, and
+// warns when work_mem for every connection may exceed it.
+func validateMemorySettings(paradedb *databasev1alpha1.ParadeDB) (admission.Warnings, error) {
+	warning, err := paradedb.ValidateMemorySettings()
+	if err != nil {
+		return nil, err
+	}
+	if warning != "" {
+		return admission.Warnings{warning}, nil
+	}
+	return nil, nil
+}
+
+// ValidateDelete accepts every deletion.
+func (v *ParadeDBCustomValidator) ValidateDelete(_ context.Context, _ *databasev1alpha1.ParadeDB) (admission.Warnings, error) {
+	return nil, nil
+}
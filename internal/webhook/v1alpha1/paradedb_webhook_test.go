@@ -0,0 +1,88 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	databasev1alpha1 "github.com/paradedb/paradedb-operator/api/v1alpha1"
+)
+
+var _ = Describe("ParadeDB Webhook", func() {
+	var (
+		ctx       context.Context
+		validator *ParadeDBCustomValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		validator = &ParadeDBCustomValidator{}
+	})
+
+	newParadeDB := func(replicas int32) *databasev1alpha1.ParadeDB {
+		return &databasev1alpha1.ParadeDB{Spec: databasev1alpha1.ParadeDBSpec{Replicas: &replicas}}
+	}
+
+	Context("When validating replicas", func() {
+		It("should reject creating more than one replica without replication", func() {
+			_, err := validator.ValidateCreate(ctx, newParadeDB(3))
+			Expect(err).To(MatchError(ContainSubstring("spec.replication.mode")))
+
+			paradedb := newParadeDB(3)
+			paradedb.Spec.Replication = &databasev1alpha1.ReplicationSpec{Mode: "Independent"}
+			Expect(validator.ValidateCreate(ctx, paradedb)).To(BeEmpty())
+			Expect(validator.ValidateCreate(ctx, newParadeDB(1))).To(BeEmpty())
+		})
+
+		It("should reject scaling up but only warn for instances that already had more replicas", func() {
+			_, err := validator.ValidateUpdate(ctx, newParadeDB(1), newParadeDB(2))
+			Expect(err).To(HaveOccurred())
+
+			warnings, err := validator.ValidateUpdate(ctx, newParadeDB(3), newParadeDB(3))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("spec.replicas is 3")))
+
+			Expect(validator.ValidateUpdate(ctx, newParadeDB(3), newParadeDB(1))).To(BeEmpty())
+		})
+	})
+
+	Context("When validating memory settings", func() {
+		It("should reject shared_buffers beyond the memory limit and warn on overcommitted work_mem", func() {
+			paradedb := newParadeDB(1)
+			paradedb.Spec.PostgresConfig = map[string]string{"shared_buffers": "2GB"}
+			paradedb.Spec.Resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}
+			_, err := validator.ValidateCreate(ctx, paradedb)
+			Expect(err).To(MatchError(ContainSubstring("shared_buffers (2048MB) does not fit")))
+			_, err = validator.ValidateUpdate(ctx, newParadeDB(1), paradedb)
+			Expect(err).To(HaveOccurred())
+
+			paradedb.Spec.PostgresConfig = map[string]string{"shared_buffers": "256MB", "work_mem": "16MB"}
+			warnings, err := validator.ValidateCreate(ctx, paradedb)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("busy periods may OOMKill the pod")))
+
+			paradedb.Spec.PostgresConfig["work_mem"] = "4MB"
+			Expect(validator.ValidateCreate(ctx, paradedb)).To(BeEmpty())
+		})
+	})
+})